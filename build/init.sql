@@ -3,6 +3,16 @@ USE `tastybyte`;
 
 GRANT CREATE, SELECT, INSERT, UPDATE, DELETE, REFERENCES ON `tastybyte`.* TO `tastybyte_user`@`%`;
 
+CREATE TABLE `workspaces` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- The shared catalogue, used by requests that don't select a workspace
+INSERT INTO `workspaces` (`id`, `name`, `created`) VALUES (1, 'Default', UTC_TIMESTAMP());
+
 CREATE TABLE `recipes` (
   `id` int NOT NULL AUTO_INCREMENT,
   `name` varchar(100) NOT NULL,
@@ -12,14 +22,29 @@ CREATE TABLE `recipes` (
   `cooking_time` varchar(10) NOT NULL,
   `portions` int NOT NULL,
   `created` datetime NOT NULL,
-  PRIMARY KEY (`id`)
+  `deleted_at` datetime DEFAULT NULL,
+  `rating_average` decimal(3,2) NOT NULL DEFAULT 0,
+  `rating_count` int NOT NULL DEFAULT 0,
+  `parent_id` int DEFAULT NULL,
+  `workspace_id` int NOT NULL DEFAULT 1,
+  PRIMARY KEY (`id`),
+  KEY `recipe_deleted_at` (`deleted_at`),
+  KEY `recipe_parent` (`parent_id`),
+  KEY `recipe_workspace` (`workspace_id`),
+  FOREIGN KEY (`parent_id`) REFERENCES `recipes`(`id`) ON DELETE SET NULL,
+  FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE TABLE `tags` (
   `id` int NOT NULL AUTO_INCREMENT,
   `name` varchar(255) NOT NULL,
+  `parent_id` int DEFAULT NULL,
+  `category` varchar(50) NOT NULL DEFAULT '',
+  `workspace_id` int NOT NULL DEFAULT 1,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `tag_name` (`name`)
+  UNIQUE KEY `tag_name` (`workspace_id`, `name`),
+  FOREIGN KEY (`parent_id`) REFERENCES `tags`(`id`) ON DELETE SET NULL,
+  FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE TABLE `recipe_tags` (
@@ -33,8 +58,13 @@ CREATE TABLE `recipe_tags` (
 CREATE TABLE `ingredients` (
                         `id` int NOT NULL AUTO_INCREMENT,
                         `name` varchar(255) NOT NULL,
+                        `allergens` SET('celery', 'crustaceans', 'dairy', 'eggs', 'fish', 'gluten', 'lupin', 'molluscs', 'mustard', 'nuts', 'peanuts', 'sesame', 'soy', 'sulphites') NOT NULL DEFAULT '',
+                        `diets` SET('halal', 'vegan', 'vegetarian') NOT NULL DEFAULT '',
+                        `classified` BOOLEAN NOT NULL DEFAULT FALSE,
+                        `workspace_id` int NOT NULL DEFAULT 1,
                         PRIMARY KEY (`id`),
-                        UNIQUE KEY `ingredient_name` (`name`)
+                        UNIQUE KEY `ingredient_name` (`workspace_id`, `name`),
+                        FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE TABLE `recipe_ingredients` (
@@ -47,6 +77,238 @@ CREATE TABLE `recipe_ingredients` (
                                FOREIGN KEY (`ingredient_id`) REFERENCES `ingredients`(`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+CREATE TABLE `recipe_revisions` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `recipe_id` int NOT NULL,
+  `revision` int NOT NULL,
+  `snapshot` json NOT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `recipe_revision` (`recipe_id`, `revision`),
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `recipe_steps` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `recipe_id` int NOT NULL,
+  `position` int NOT NULL,
+  `text` text NOT NULL,
+  `duration` varchar(10) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `recipe_step_position` (`recipe_id`, `position`),
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `recipe_step_ingredients` (
+  `step_id` int NOT NULL,
+  `ingredient_id` int NOT NULL,
+  PRIMARY KEY (`step_id`, `ingredient_id`),
+  FOREIGN KEY (`step_id`) REFERENCES `recipe_steps`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ingredient_id`) REFERENCES `ingredients`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `recipe_images` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `recipe_id` int NOT NULL,
+  `image_key` varchar(255) NOT NULL,
+  `thumbnail_key` varchar(255) NOT NULL,
+  `content_type` varchar(50) NOT NULL,
+  `width` int NOT NULL,
+  `height` int NOT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `recipe_image_recipe` (`recipe_id`),
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `nutrition` (
+  `name` varchar(255) NOT NULL,
+  `calories` decimal(7,2) NOT NULL,
+  `protein` decimal(6,2) NOT NULL,
+  `carbohydrates` decimal(6,2) NOT NULL,
+  `sugar` decimal(6,2) NOT NULL,
+  `fat` decimal(6,2) NOT NULL,
+  `fiber` decimal(6,2) NOT NULL,
+  `density` decimal(6,3) DEFAULT NULL,
+  `piece_weight` decimal(7,2) DEFAULT NULL,
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `shopping_lists` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `workspace_id` int NOT NULL DEFAULT 1,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `shopping_lists_workspace` (`workspace_id`, `created`),
+  FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `shopping_list_recipes` (
+  `list_id` int NOT NULL,
+  `position` int NOT NULL,
+  `recipe_id` int NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `portions` int NOT NULL,
+  PRIMARY KEY (`list_id`, `position`),
+  FOREIGN KEY (`list_id`) REFERENCES `shopping_lists`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `shopping_list_items` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `list_id` int NOT NULL,
+  `position` int NOT NULL,
+  `ingredient_id` int NULL,
+  `name` varchar(255) NOT NULL,
+  `quantity` decimal(8,2) NOT NULL,
+  `unit` varchar(50) NOT NULL,
+  `aisle` varchar(50) NOT NULL,
+  `checked` BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (`id`),
+  KEY `shopping_list_items_list` (`list_id`, `position`),
+  FOREIGN KEY (`list_id`) REFERENCES `shopping_lists`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ingredient_id`) REFERENCES `ingredients`(`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `meal_plans` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `date` date NOT NULL,
+  `slot` ENUM('breakfast', 'lunch', 'dinner') NOT NULL,
+  `recipe_id` int NOT NULL,
+  `portions` int NOT NULL,
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `workspace_id` int NOT NULL DEFAULT 1,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `meal_plans_date` (`workspace_id`, `date`),
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `users` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `email` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `hashed_password` char(60) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `is_admin` boolean NOT NULL DEFAULT FALSE,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `user_uc_email` (`email`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `pantry_items` (
+  `user_id` int NOT NULL,
+  `ingredient_id` int NOT NULL,
+  `quantity` decimal(8,2) NOT NULL,
+  `unit` varchar(50) NOT NULL,
+  `updated` datetime NOT NULL,
+  PRIMARY KEY (`user_id`, `ingredient_id`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ingredient_id`) REFERENCES `ingredients`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `ingredient_substitutions` (
+  `ingredient_id` int NOT NULL,
+  `substitute_id` int NOT NULL,
+  `ratio` decimal(6,3) NOT NULL,
+  `note` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`ingredient_id`, `substitute_id`),
+  FOREIGN KEY (`ingredient_id`) REFERENCES `ingredients`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`substitute_id`) REFERENCES `ingredients`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `reviews` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `recipe_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `rating` tinyint NOT NULL,
+  `comment` text NOT NULL,
+  `created` datetime NOT NULL,
+  `updated` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `review_recipe_user` (`recipe_id`, `user_id`),
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `favorites` (
+  `user_id` int NOT NULL,
+  `recipe_id` int NOT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`user_id`, `recipe_id`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `collections` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `share_token` char(43) DEFAULT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `collection_share_token` (`share_token`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `collection_recipes` (
+  `collection_id` int NOT NULL,
+  `recipe_id` int NOT NULL,
+  `position` int NOT NULL,
+  PRIMARY KEY (`collection_id`, `recipe_id`),
+  KEY `collection_recipe_position` (`collection_id`, `position`),
+  FOREIGN KEY (`collection_id`) REFERENCES `collections`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `cooking_history` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `recipe_id` int NOT NULL,
+  `cooked` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `cooking_history_user` (`user_id`, `cooked`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`recipe_id`) REFERENCES `recipes`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `ingredient_aliases` (
+  `workspace_id` int NOT NULL DEFAULT 1,
+  `alias` varchar(255) NOT NULL,
+  `ingredient_id` int NOT NULL,
+  PRIMARY KEY (`workspace_id`, `alias`),
+  KEY `ingredient_alias_ingredient` (`ingredient_id`),
+  FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ingredient_id`) REFERENCES `ingredients`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `workspace_members` (
+  `workspace_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `role` ENUM('owner', 'editor', 'viewer') NOT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`workspace_id`, `user_id`),
+  KEY `workspace_member_user` (`user_id`),
+  FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `api_keys` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `prefix` char(11) NOT NULL,
+  `hashed_key` char(64) NOT NULL,
+  `scopes` SET('recipes:read', 'recipes:write', 'collections:read', 'collections:write', 'pantry:read', 'pantry:write', 'workspaces:read', 'workspaces:write', 'admin') NOT NULL,
+  `expires` datetime DEFAULT NULL,
+  `last_used` datetime DEFAULT NULL,
+  `created` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `api_key_hash` (`hashed_key`),
+  KEY `api_key_user` (`user_id`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
 
 -- CREATE TABLE `sessions` (
 --   `token` char(43) COLLATE utf8mb4_unicode_ci NOT NULL,
@@ -55,13 +317,3 @@ CREATE TABLE `recipe_ingredients` (
 --   PRIMARY KEY (`token`),
 --   KEY `sessions_expiry_idx` (`expiry`)
 -- ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
-
--- CREATE TABLE `users` (
---   `id` int NOT NULL AUTO_INCREMENT,
---   `name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
---   `email` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
---   `hashed_password` char(60) COLLATE utf8mb4_unicode_ci NOT NULL,
---   `created` datetime NOT NULL,
---   PRIMARY KEY (`id`),
---   UNIQUE KEY `user_uc_email` (`email`)
--- ) ENGINE=InnoDB AUTO_INCREMENT=7 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
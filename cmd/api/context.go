@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+type contextKey string
+
+const (
+	userIDContextKey      = contextKey("userID")
+	workspaceIDContextKey = contextKey("workspaceID")
+	apiKeyContextKey      = contextKey("apiKey")
+)
+
+// contextSetUserID returns a copy of the request carrying the ID of the authenticated user.
+func (app *application) contextSetUserID(r *http.Request, userID int) *http.Request {
+	ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+	return r.WithContext(ctx)
+}
+
+// contextGetUserID returns the ID of the authenticated user, or 0 for anonymous requests.
+func (app *application) contextGetUserID(r *http.Request) int {
+	userID, ok := r.Context().Value(userIDContextKey).(int)
+	if !ok {
+		return 0
+	}
+	return userID
+}
+
+// contextSetWorkspaceID returns a copy of the request carrying the ID of the workspace it selected.
+func (app *application) contextSetWorkspaceID(r *http.Request, workspaceID int) *http.Request {
+	ctx := context.WithValue(r.Context(), workspaceIDContextKey, workspaceID)
+	return r.WithContext(ctx)
+}
+
+// contextGetWorkspaceID returns the ID of the workspace the request selected, or the default
+// workspace for requests that didn't select any.
+func (app *application) contextGetWorkspaceID(r *http.Request) int {
+	workspaceID, ok := r.Context().Value(workspaceIDContextKey).(int)
+	if !ok {
+		return models.DefaultWorkspaceID
+	}
+	return workspaceID
+}
+
+// contextSetAPIKey returns a copy of the request carrying the API key it was authenticated with.
+func (app *application) contextSetAPIKey(r *http.Request, key *models.APIKey) *http.Request {
+	ctx := context.WithValue(r.Context(), apiKeyContextKey, key)
+	return r.WithContext(ctx)
+}
+
+// contextGetAPIKey returns the API key the request was authenticated with, or nil for requests that
+// weren't authenticated with one.
+func (app *application) contextGetAPIKey(r *http.Request) *models.APIKey {
+	key, ok := r.Context().Value(apiKeyContextKey).(*models.APIKey)
+	if !ok {
+		return nil
+	}
+	return key
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/render"
+	"github.com/vladComan0/tasty-byte/internal/schemaorg"
+)
+
+// recipeFormats are the representations recipes can be served in, the first one being the default.
+var recipeFormats = []string{formatJSON, formatJSONLD, formatMarkdown, formatText, formatPDF}
+
+// formatError answers a request whose format couldn't be negotiated.
+func (app *application) formatError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUnknownFormat):
+		app.clientError(w, http.StatusBadRequest)
+	default:
+		app.clientError(w, http.StatusNotAcceptable)
+	}
+}
+
+// writeRecipe writes a single recipe in the negotiated format.
+func (app *application) writeRecipe(w http.ResponseWriter, status int, format string, recipe *models.Recipe) error {
+	switch format {
+	case formatJSONLD:
+		return app.writeDocument(w, status, format, schemaorg.NewRecipe(recipe))
+	case formatMarkdown, formatText:
+		return app.writeRendered(w, status, format, recipe)
+	case formatPDF:
+		return app.writePDF(w, status, recipe)
+	default:
+		return app.writeJSON(w, status, envelope{"recipe": recipe}, http.Header{"Vary": {"Accept"}})
+	}
+}
+
+// writeRecipeList writes the recipes in the given format, along with the extra data of the list in JSON,
+// such as its facets.
+func (app *application) writeRecipeList(w http.ResponseWriter, status int, format string, recipes []*models.Recipe, extra envelope) error {
+	switch format {
+	case formatJSONLD:
+		return app.writeDocument(w, status, format, schemaorg.NewGraph(recipes))
+	case formatMarkdown, formatText:
+		return app.writeRendered(w, status, format, recipes...)
+	case formatPDF:
+		return app.writePDF(w, status, recipes...)
+	default:
+		data := envelope{"recipes": recipes}
+		for key, value := range extra {
+			data[key] = value
+		}
+		return app.writeJSON(w, status, data, http.Header{"Vary": {"Accept"}})
+	}
+}
+
+func (app *application) writeDocument(w http.ResponseWriter, status int, format string, document any) error {
+	js, err := json.MarshalIndent(document, "", "\t")
+	if err != nil {
+		return err
+	}
+	js = append(js, '\n')
+
+	w.Header().Set("Content-Type", formatMediaTypes[format])
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+
+	_, err = w.Write(js)
+	return err
+}
+
+func (app *application) writeRendered(w http.ResponseWriter, status int, format string, recipes ...*models.Recipe) error {
+	var buf bytes.Buffer
+
+	renderFn := render.Text
+	if format == formatMarkdown {
+		renderFn = render.Markdown
+	}
+	if err := renderFn(&buf, recipes...); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", formatMediaTypes[format]+"; charset=utf-8")
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func (app *application) writePDF(w http.ResponseWriter, status int, recipes ...*models.Recipe) error {
+	var buf bytes.Buffer
+	if err := render.PDF(&buf, recipes...); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", formatMediaTypes[formatPDF])
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+)
+
+func TestGetRecipeFormats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name                string
+		accept              string
+		query               string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "Default JSON",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `"recipe": {`,
+		},
+		{
+			name:                "JSON-LD Through Accept",
+			accept:              "application/ld+json",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/ld+json",
+			expectedBody:        `"@type": "Recipe"`,
+		},
+		{
+			name:                "Markdown Preferred By Quality",
+			accept:              "text/plain;q=0.5, text/markdown",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/markdown; charset=utf-8",
+			expectedBody:        "# Test Recipe\n",
+		},
+		{
+			name:                "Text Wildcard Picks Markdown",
+			accept:              "text/*",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/markdown; charset=utf-8",
+			expectedBody:        "## Ingredients",
+		},
+		{
+			name:                "Format Override",
+			accept:              "application/json",
+			query:               "?format=text",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "TEST RECIPE\n===========\n",
+		},
+		{
+			name:                "PDF Through Accept",
+			accept:              "application/pdf",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/pdf",
+			expectedBody:        "%PDF-",
+		},
+		{
+			name:           "Unknown Format",
+			query:          "?format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Not Acceptable",
+			accept:         "application/xml",
+			expectedStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/recipes/1%s", ts.URL, tc.query), nil)
+			assert.NoError(t, err)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tc.expectedContentType, res.Header.Get("Content-Type"))
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), tc.expectedBody)
+		})
+	}
+}
+
+func TestListRecipesJSONLD(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	mockRecipes.EXPECT().List(gomock.Any()).Return(testRecipes, len(testRecipes), nil)
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes?format=jsonld", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Context string `json:"@context"`
+		Graph   []struct {
+			Type             string   `json:"@type"`
+			Name             string   `json:"name"`
+			PrepTime         string   `json:"prepTime"`
+			TotalTime        string   `json:"totalTime"`
+			RecipeIngredient []string `json:"recipeIngredient"`
+		} `json:"@graph"`
+	}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, "https://schema.org", body.Context)
+	assert.Len(t, body.Graph, 2)
+	assert.Equal(t, "Recipe", body.Graph[0].Type)
+	assert.Equal(t, "PT40M", body.Graph[0].PrepTime)
+	assert.Equal(t, "PT1H50M", body.Graph[0].TotalTime)
+	assert.Equal(t, []string{"2 cup Test Ingredient 2"}, body.Graph[0].RecipeIngredient)
+	assert.True(t, strings.HasPrefix(res.Header.Get("Content-Type"), "application/ld+json"))
+}
+
+func TestGetRecipePDF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1.pdf?portions=2", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/pdf", res.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), "%PDF-"))
+
+	res, err = ts.Client().Get(fmt.Sprintf("%s/v1/recipes/abc.pdf", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestGetRecipePortions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name               string
+		portions           string
+		recipePortions     int
+		expectGet          bool
+		expectedStatus     int
+		expectedQuantities []float64
+	}{
+		{
+			name:               "Scaled Down",
+			portions:           "2",
+			recipePortions:     4,
+			expectGet:          true,
+			expectedStatus:     http.StatusOK,
+			expectedQuantities: []float64{100, 150},
+		},
+		{
+			name:               "Scaled Up",
+			portions:           "6",
+			recipePortions:     4,
+			expectGet:          true,
+			expectedStatus:     http.StatusOK,
+			expectedQuantities: []float64{300, 450},
+		},
+		{
+			name:           "Recipe Without Portions",
+			portions:       "2",
+			expectGet:      true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Invalid Portions",
+			portions:       "0",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				recipe := newPatchableRecipe()
+				recipe.Portions = tc.recipePortions
+				mockRecipes.EXPECT().Get(1).Return(recipe, nil)
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1?portions=%s", ts.URL, tc.portions))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Recipe struct {
+					Portions    int `json:"portions"`
+					Ingredients []struct {
+						Quantity float64 `json:"quantity"`
+					} `json:"ingredients"`
+				} `json:"recipe"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+			quantities := make([]float64, 0, len(body.Recipe.Ingredients))
+			for _, ingredient := range body.Recipe.Ingredients {
+				quantities = append(quantities, ingredient.Quantity)
+			}
+			assert.Equal(t, tc.expectedQuantities, quantities)
+		})
+	}
+}
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/vladComan0/tasty-byte/internal/models"
 )
 
+const (
+	defaultRecipeLimit = 50
+	maxRecipeLimit     = 100
+)
+
 func (app *application) ping(w http.ResponseWriter, _ *http.Request) {
 	if err := app.recipes.Ping(); err != nil {
 		app.errorLog.Printf("Unable to establish connection with database: %v", err)
@@ -24,7 +30,25 @@ func (app *application) ping(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+// createRecipe adds a recipe. With "?check_duplicates=true", a recipe that looks like a duplicate of
+// existing ones isn't added, the likely duplicates being returned instead so that the client can confirm.
 func (app *application) createRecipe(w http.ResponseWriter, r *http.Request) {
+	var checkDuplicates bool
+	if value := r.URL.Query().Get("check_duplicates"); value != "" {
+		var err error
+		checkDuplicates, err = strconv.ParseBool(value)
+		if err != nil {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	threshold, err := app.readThreshold(r)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
 	var input struct {
 		Name            string                   `json:"name"`
 		Description     string                   `json:"description"`
@@ -34,6 +58,7 @@ func (app *application) createRecipe(w http.ResponseWriter, r *http.Request) {
 		Portions        int                      `json:"portions"`
 		Ingredients     []*models.FullIngredient `json:"ingredients"`
 		Tags            []*models.Tag            `json:"tags"`
+		Steps           []*models.Step           `json:"steps"`
 	}
 	if err := app.readJSON(w, r, &input); err != nil {
 		app.clientError(w, http.StatusBadRequest)
@@ -49,16 +74,40 @@ func (app *application) createRecipe(w http.ResponseWriter, r *http.Request) {
 		Portions:        input.Portions,
 		Ingredients:     input.Ingredients,
 		Tags:            input.Tags,
+		Steps:           input.Steps,
+	}
+	if !validRecipe(recipe) {
+		app.clientError(w, http.StatusBadRequest)
+		return
 	}
 
-	id, err := app.recipes.Insert(recipe)
+	if checkDuplicates {
+		duplicates, err := app.findDuplicates(r, recipe, threshold)
+		if err != nil {
+			app.serverError(w, err)
+			return
+		}
+		if len(duplicates) > 0 {
+			if err := app.writeJSON(w, http.StatusConflict, envelope{"duplicates": duplicates}, nil); err != nil {
+				app.serverError(w, err)
+			}
+			return
+		}
+	}
+
+	id, err := app.recipesFor(r).Insert(recipe)
 	if err != nil {
-		app.serverError(w, err)
+		switch {
+		case errors.Is(err, models.ErrInvalidStepIngredient):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
 		return
 	}
 
 	// Fetch the newly created recipe from the database to update the ID
-	recipe, err = app.recipes.Get(id)
+	recipe, err = app.recipesFor(r).Get(id)
 	if err != nil {
 		app.serverError(w, err)
 		return
@@ -76,8 +125,72 @@ func (app *application) createRecipe(w http.ResponseWriter, r *http.Request) {
 	app.infoLog.Printf("Created new recipe with id: %d", id)
 }
 
-func (app *application) listRecipes(w http.ResponseWriter, _ *http.Request) {
-	recipes, err := app.recipes.GetAll()
+// listRecipes returns a page of the recipes, "?limit=" long and starting after "?offset=" recipes,
+// filtered by allergens, diets and tags and ordered by "?sort=". The facets, in JSON, are counted over
+// every recipe matching the filters rather than over the page.
+func (app *application) listRecipes(w http.ResponseWriter, r *http.Request) {
+	format, err := app.negotiateFormat(r, recipeFormats...)
+	if err != nil {
+		app.formatError(w, err)
+		return
+	}
+
+	filter := &models.RecipeFilter{
+		ExcludedAllergens: app.readListParam(r, "exclude_allergen"),
+		Diets:             app.readListParam(r, "diet"),
+		Sort:              r.URL.Query().Get("sort"),
+	}
+	for _, allergen := range filter.ExcludedAllergens {
+		if !models.ValidAllergen(allergen) {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+	for _, diet := range filter.Diets {
+		if !models.ValidDiet(diet) {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+	if filter.Sort != "" && !models.ValidSort(filter.Sort) {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// Recipes are counted by tag unless other facets are asked for
+	facetNames := app.readListParam(r, "facets")
+	for _, facet := range facetNames {
+		if !models.ValidFacet(facet) {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+	if len(facetNames) == 0 {
+		facetNames = []string{models.FacetTags}
+	}
+
+	filter.Limit, err = app.readLimit(r, defaultRecipeLimit, maxRecipeLimit)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+	filter.Offset, err = app.readOffset(r)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if tagNames := app.readListParam(r, "tag"); len(tagNames) > 0 {
+		// Filtering by a parent tag includes the recipes of its descendants
+		tags, err := app.tagsFor(r).GetAll()
+		if err != nil {
+			app.serverError(w, err)
+			return
+		}
+		filter.TagGroups = tagGroups(models.NewTagHierarchy(tags), tagNames)
+	}
+
+	recipes, total, err := app.recipesFor(r).List(filter)
 	if err != nil {
 		switch {
 		case errors.Is(err, models.ErrNoRecord):
@@ -88,22 +201,56 @@ func (app *application) listRecipes(w http.ResponseWriter, _ *http.Request) {
 		return
 	}
 
-	if err := app.writeJSON(w, http.StatusOK, envelope{"recipes": recipes}, nil); err != nil {
+	extra := envelope{
+		"metadata": envelope{"total": total, "limit": filter.Limit, "offset": filter.Offset},
+	}
+	if format == formatJSON {
+		facets, err := app.facetsFor(r).Count(filter, facetNames)
+		if err != nil {
+			app.serverError(w, err)
+			return
+		}
+		extra["facets"] = facets
+	}
+
+	if err := app.writeRecipeList(w, http.StatusOK, format, recipes, extra); err != nil {
 		app.serverError(w, err)
 		return
 	}
-	app.infoLog.Printf("Retrieved all recipes")
+	app.infoLog.Printf("Retrieved %d of %d recipes", len(recipes), total)
 }
 
 func (app *application) getRecipe(w http.ResponseWriter, r *http.Request) {
 	params := httprouter.ParamsFromContext(r.Context())
-	id, err := strconv.Atoi(params.ByName("id"))
+
+	// "/v1/recipes/:id.pdf" is a shortcut for asking for the PDF card
+	param, isPDF := strings.CutSuffix(params.ByName("id"), ".pdf")
+
+	id, err := strconv.Atoi(param)
 	if err != nil || id < 1 {
 		app.clientError(w, http.StatusBadRequest)
 		return
 	}
 
-	recipe, err := app.recipes.Get(id)
+	format := formatPDF
+	if !isPDF {
+		format, err = app.negotiateFormat(r, recipeFormats...)
+		if err != nil {
+			app.formatError(w, err)
+			return
+		}
+	}
+
+	var portions int
+	if value := r.URL.Query().Get("portions"); value != "" {
+		portions, err = strconv.Atoi(value)
+		if err != nil || portions < 1 {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
 	if err != nil {
 		switch {
 		case errors.Is(err, models.ErrNoRecord):
@@ -114,7 +261,23 @@ func (app *application) getRecipe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = app.writeJSON(w, http.StatusOK, envelope{"recipe": recipe}, nil); err != nil {
+	if portions > 0 {
+		if err = recipe.Scale(portions); err != nil {
+			app.clientError(w, http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	if userID := app.contextGetUserID(r); userID != 0 {
+		favorited, err := app.favorites.Exists(userID, id)
+		if err != nil {
+			app.serverError(w, err)
+			return
+		}
+		recipe.Favorited = &favorited
+	}
+
+	if err = app.writeRecipe(w, http.StatusOK, format, recipe); err != nil {
 		app.serverError(w, err)
 		return
 	}
@@ -130,7 +293,7 @@ func (app *application) updateRecipe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	recipe, err := app.recipes.Get(id)
+	recipe, err := app.recipesFor(r).Get(id)
 	if err != nil {
 		switch {
 		case errors.Is(err, models.ErrNoRecord):
@@ -141,15 +304,18 @@ func (app *application) updateRecipe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// PUT replaces the recipe as a whole: fields that are left out are cleared,
+	// partial updates go through PATCH instead.
 	var input struct {
-		Name            *string                  `json:"name"`
-		Description     *string                  `json:"description"`
-		Instructions    *string                  `json:"instructions"`
-		PreparationTime *string                  `json:"preparation_time"`
-		CookingTime     *string                  `json:"cooking_time"`
-		Portions        *int                     `json:"portions"`
+		Name            string                   `json:"name"`
+		Description     string                   `json:"description"`
+		Instructions    string                   `json:"instructions"`
+		PreparationTime string                   `json:"preparation_time"`
+		CookingTime     string                   `json:"cooking_time"`
+		Portions        int                      `json:"portions"`
 		Ingredients     []*models.FullIngredient `json:"ingredients"`
 		Tags            []*models.Tag            `json:"tags"`
+		Steps           []*models.Step           `json:"steps"`
 	}
 
 	if err := app.readJSON(w, r, &input); err != nil {
@@ -157,52 +323,27 @@ func (app *application) updateRecipe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if input.Name != nil {
-		recipe.Name = *input.Name
-	}
-
-	if input.Description != nil {
-		recipe.Description = *input.Description
-	}
-
-	if input.Instructions != nil {
-		recipe.Instructions = *input.Instructions
-	}
-
-	if input.PreparationTime != nil {
-		recipe.PreparationTime = *input.PreparationTime
-	}
-
-	if input.CookingTime != nil {
-		recipe.CookingTime = *input.CookingTime
-	}
-
-	if input.Portions != nil {
-		recipe.Portions = *input.Portions
-	}
-
-	if input.Ingredients != nil {
-		for _, ingredient := range input.Ingredients {
-			if ingredient.Name == "" {
-				app.clientError(w, http.StatusBadRequest)
-				return
-			}
-		}
-		recipe.Ingredients = input.Ingredients
+	recipe.Name = input.Name
+	recipe.Description = input.Description
+	recipe.Instructions = input.Instructions
+	recipe.PreparationTime = input.PreparationTime
+	recipe.CookingTime = input.CookingTime
+	recipe.Portions = input.Portions
+	recipe.Ingredients = input.Ingredients
+	recipe.Tags = input.Tags
+	recipe.Steps = input.Steps
+	if !validRecipe(recipe) {
+		app.clientError(w, http.StatusBadRequest)
+		return
 	}
 
-	if input.Tags != nil {
-		for _, tag := range input.Tags {
-			if tag.Name == "" {
-				app.clientError(w, http.StatusBadRequest)
-				return
-			}
+	if err := app.recipesFor(r).Update(recipe); err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidStepIngredient):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
 		}
-		recipe.Tags = input.Tags
-	}
-
-	if err := app.recipes.Update(recipe); err != nil {
-		app.serverError(w, err)
 		return
 	}
 
@@ -222,7 +363,7 @@ func (app *application) deleteRecipe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := app.recipes.Delete(id); err != nil {
+	if err := app.recipesFor(r).Delete(id); err != nil {
 		switch {
 		case errors.Is(err, models.ErrNoRecord):
 			app.clientError(w, http.StatusNotFound)
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// createAPIKey creates an API key for the user with the given scopes, expiring at "expires_at" when
+// given. Only administrators can give their keys the admin scope, so that a key can never do more than
+// its owner. The key itself is only ever returned here.
+func (app *application) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 || len(input.Scopes) == 0 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	key := &models.APIKey{
+		UserID:    app.contextGetUserID(r),
+		Name:      name,
+		ExpiresAt: input.ExpiresAt,
+	}
+	seen := make(map[string]bool, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !models.ValidScope(scope) {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			key.Scopes = append(key.Scopes, scope)
+		}
+	}
+
+	if seen[models.ScopeAdmin] {
+		user, err := app.users.Get(key.UserID)
+		if err != nil {
+			switch {
+			case errors.Is(err, models.ErrNoRecord):
+				app.authenticationError(w)
+			default:
+				app.serverError(w, err)
+			}
+			return
+		}
+		if !user.Admin {
+			app.clientError(w, http.StatusForbidden)
+			return
+		}
+	}
+
+	token, err := app.apiKeys.Insert(key)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidScope):
+			app.clientError(w, http.StatusBadRequest)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/api-keys/%d", key.ID))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"api_key": key, "key": token}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new API key with id: %d", key.ID)
+}
+
+// listAPIKeys returns the API keys of the user, without the keys themselves.
+func (app *application) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := app.apiKeys.GetByUserID(app.contextGetUserID(r))
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"api_keys": keys}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved API keys")
+}
+
+// revokeAPIKey deletes one of the API keys of the user, which stops working right away.
+func (app *application) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.apiKeys.Delete(id, app.contextGetUserID(r)); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "API key successfully revoked"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Revoked API key with id: %d", id)
+}
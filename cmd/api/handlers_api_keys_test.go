@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockAPIKeys := mocks.NewMockAPIKeyModelInterface(ctrl)
+	app.users = mockUsers
+	app.apiKeys = mockAPIKeys
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	expires := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	expired := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	testCases := []struct {
+		name           string
+		body           string
+		owner          *models.User
+		expectedScopes []string
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Key",
+			body:           `{"name": " CI ", "scopes": ["recipes:read", "Recipes:Write", "recipes:read"]}`,
+			expectedScopes: []string{models.ScopeRecipesRead, models.ScopeRecipesWrite},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Valid Key With Expiry",
+			body:           fmt.Sprintf(`{"name": "Kitchen display", "scopes": ["recipes:read"], "expires_at": %q}`, expires),
+			expectedScopes: []string{models.ScopeRecipesRead},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Valid Key For The Pantry",
+			body:           `{"name": "Fridge", "scopes": ["pantry:read", "pantry:write"]}`,
+			expectedScopes: []string{models.ScopePantryRead, models.ScopePantryWrite},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Unknown Scope",
+			body:           `{"name": "CI", "scopes": ["recipes:delete"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "No Scopes",
+			body:           `{"name": "CI", "scopes": []}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Admin Key",
+			body:           `{"name": "Moderation", "scopes": ["admin"]}`,
+			owner:          &models.User{ID: 1, Name: "Alice", Admin: true},
+			expectedScopes: []string{models.ScopeAdmin},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Admin Key Of A User",
+			body:           `{"name": "Moderation", "scopes": ["recipes:read", "admin"]}`,
+			owner:          newTestUser(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "No Name",
+			body:           `{"name": " ", "scopes": ["admin"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Expired",
+			body:           fmt.Sprintf(`{"name": "CI", "scopes": ["admin"], "expires_at": %q}`, expired),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, ts.URL+"/v1/api-keys", strings.NewReader(tc.body))
+			if tc.owner != nil {
+				mockUsers.EXPECT().Get(1).Return(tc.owner, nil)
+			}
+
+			if tc.expectedScopes != nil {
+				mockAPIKeys.EXPECT().Insert(gomock.Any()).DoAndReturn(func(key *models.APIKey) (string, error) {
+					assert.Equal(t, 1, key.UserID)
+					assert.Equal(t, tc.expectedScopes, key.Scopes)
+					key.ID = 3
+					key.Prefix = "tb_abcdefgh"
+					return "tb_abcdefghsecret", nil
+				})
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Equal(t, "v1/api-keys/3", res.Header.Get("Location"))
+
+				body, err := io.ReadAll(res.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), "tb_abcdefghsecret")
+			}
+		})
+	}
+}
+
+func TestListAndRevokeAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockAPIKeys := mocks.NewMockAPIKeyModelInterface(ctrl)
+	app.users = mockUsers
+	app.apiKeys = mockAPIKeys
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	t.Run("List", func(t *testing.T) {
+		req := authenticatedRequest(t, mockUsers, http.MethodGet, ts.URL+"/v1/api-keys", nil)
+		mockAPIKeys.EXPECT().GetByUserID(1).Return([]*models.APIKey{
+			{ID: 3, UserID: 1, Name: "CI", Prefix: "tb_abcdefgh", Scopes: []string{models.ScopeRecipesRead}},
+		}, nil)
+
+		res, err := ts.Client().Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "tb_abcdefgh")
+		assert.NotContains(t, string(body), "user_id")
+	})
+
+	t.Run("List Anonymously", func(t *testing.T) {
+		res, err := ts.Client().Get(ts.URL + "/v1/api-keys")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		req := authenticatedRequest(t, mockUsers, http.MethodDelete, ts.URL+"/v1/api-keys/3", nil)
+		mockAPIKeys.EXPECT().Delete(3, 1).Return(nil)
+
+		res, err := ts.Client().Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("Revoke Key Of Another User", func(t *testing.T) {
+		req := authenticatedRequest(t, mockUsers, http.MethodDelete, ts.URL+"/v1/api-keys/4", nil)
+		mockAPIKeys.EXPECT().Delete(4, 1).Return(models.ErrNoRecord)
+
+		res, err := ts.Client().Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	// The pantry, collections and workspaces mocks only expect what a test case sets up, so that a
+	// key reaching their handlers without the scope fails the test
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockAPIKeys := mocks.NewMockAPIKeyModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	mockPantry := mocks.NewMockPantryModelInterface(ctrl)
+	app.users = mockUsers
+	app.apiKeys = mockAPIKeys
+	app.recipes = mockRecipes
+	app.favorites = mockFavorites
+	app.pantry = mockPantry
+	app.collections = mocks.NewMockCollectionModelInterface(ctrl)
+	app.workspaces = mocks.NewMockWorkspaceModelInterface(ctrl)
+
+	mockFavorites.EXPECT().Exists(1, 1).Return(false, nil).AnyTimes()
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	readKey := &models.APIKey{ID: 3, UserID: 1, Scopes: []string{models.ScopeRecipesRead}}
+	writeKey := &models.APIKey{ID: 5, UserID: 1, Scopes: []string{models.ScopeRecipesRead, models.ScopeRecipesWrite}}
+	pantryKey := &models.APIKey{ID: 6, UserID: 1, Scopes: []string{models.ScopePantryRead}}
+	adminKey := &models.APIKey{ID: 4, UserID: 1, Scopes: []string{models.ScopeAdmin}}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		authorization  string
+		key            *models.APIKey
+		setup          func()
+		expectedStatus int
+	}{
+		{
+			name:          "Read With Read Scope",
+			method:        http.MethodGet,
+			path:          "/v1/recipes/1",
+			authorization: "Bearer tb_read",
+			key:           readKey,
+			setup: func() {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Write With Read Scope",
+			method:         http.MethodDelete,
+			path:           "/v1/recipes/1",
+			authorization:  "bearer tb_read",
+			key:            readKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Administration With Read Scope",
+			method:         http.MethodGet,
+			path:           "/v1/admin/duplicates",
+			authorization:  "Bearer tb_read",
+			key:            readKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Administration With Admin Scope",
+			method:        http.MethodGet,
+			path:          "/v1/admin/duplicates",
+			authorization: "Bearer tb_admin",
+			key:           adminKey,
+			setup: func() {
+				mockUsers.EXPECT().Get(1).Return(&models.User{ID: 1, Name: "Alice", Admin: true}, nil)
+				mockRecipes.EXPECT().GetAll().Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Administration With Admin Scope Of A User",
+			method:        http.MethodGet,
+			path:          "/v1/admin/duplicates",
+			authorization: "Bearer tb_admin",
+			key:           adminKey,
+			setup: func() {
+				mockUsers.EXPECT().Get(1).Return(newTestUser(), nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Members With Write Scope",
+			method:         http.MethodPut,
+			path:           "/v1/workspaces/2/members/3",
+			authorization:  "Bearer tb_write",
+			key:            writeKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Workspaces With Read Scope",
+			method:         http.MethodGet,
+			path:           "/v1/workspaces",
+			authorization:  "Bearer tb_read",
+			key:            readKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Pantry With Write Scope",
+			method:         http.MethodDelete,
+			path:           "/v1/me/pantry/1",
+			authorization:  "Bearer tb_write",
+			key:            writeKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Pantry With Pantry Scope",
+			method:        http.MethodGet,
+			path:          "/v1/me/pantry",
+			authorization: "Bearer tb_pantry",
+			key:           pantryKey,
+			setup: func() {
+				mockPantry.EXPECT().GetByUserID(1).Return([]*models.PantryItem{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Cooking With Write Scope",
+			method:         http.MethodPost,
+			path:           "/v1/recipes/1/cooked",
+			authorization:  "Bearer tb_write",
+			key:            writeKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Cookable Recipes With Read Scope",
+			method:         http.MethodGet,
+			path:           "/v1/recipes/cookable",
+			authorization:  "Bearer tb_read",
+			key:            readKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Cookable Recipes With Pantry Scope",
+			method:        http.MethodGet,
+			path:          "/v1/recipes/cookable",
+			authorization: "Bearer tb_pantry",
+			key:           pantryKey,
+			setup: func() {
+				mockPantry.EXPECT().GetByUserID(1).Return([]*models.PantryItem{}, nil)
+				mockRecipes.EXPECT().GetAll().Return([]*models.Recipe{testRecipe}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Collections With Write Scope",
+			method:         http.MethodDelete,
+			path:           "/v1/collections/1",
+			authorization:  "Bearer tb_write",
+			key:            writeKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Managing Keys With A Key",
+			method:         http.MethodGet,
+			path:           "/v1/api-keys",
+			authorization:  "Bearer tb_admin",
+			key:            adminKey,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Invalid Key",
+			method:         http.MethodGet,
+			path:           "/v1/recipes/1",
+			authorization:  "Bearer tb_revoked",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "No Key",
+			method: http.MethodGet,
+			path:   "/v1/recipes/1",
+			setup: func() {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, ts.URL+tc.path, nil)
+			assert.NoError(t, err)
+
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+				token := strings.Fields(tc.authorization)[1]
+				if tc.key != nil {
+					mockAPIKeys.EXPECT().Authenticate(token).Return(tc.key, nil)
+				} else {
+					mockAPIKeys.EXPECT().Authenticate(token).Return(nil, models.ErrInvalidCredentials)
+				}
+			}
+			if tc.setup != nil {
+				tc.setup()
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, res.Header.Values("WWW-Authenticate"), `Bearer realm="tasty-byte"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+const (
+	ndjsonMediaType = "application/x-ndjson"
+
+	defaultBatchSize = 100
+	maxBatchSize     = 1000
+	maxImportLine    = 1_048_576
+	maxImportBytes   = 100 * 1_048_576
+
+	// The server's timeouts are far too short for a whole catalogue, so the bulk handlers extend them
+	exportBatchDeadline = 30 * time.Second
+	importDeadline      = 5 * time.Minute
+)
+
+// importLine is the outcome of a single line of an NDJSON import.
+type importLine struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	ID     int    `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type importSummary struct {
+	Total   int `json:"total"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Failed  int `json:"failed"`
+}
+
+// readBatchSize reads the "batch_size" query parameter, falling back to the default batch size.
+func (app *application) readBatchSize(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("batch_size")
+	if value == "" {
+		return defaultBatchSize, nil
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 1 || size > maxBatchSize {
+		return 0, errors.New("invalid batch_size parameter")
+	}
+	return size, nil
+}
+
+// exportRecipes streams every recipe as newline-delimited JSON, one recipe per line.
+func (app *application) exportRecipes(w http.ResponseWriter, r *http.Request) {
+	batchSize, err := app.readBatchSize(r)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// Give the first batch as long as the following ones, rather than what is left of the server's timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Now().Add(exportBatchDeadline))
+
+	encoder := json.NewEncoder(w)
+	count := 0
+
+	err = app.recipesFor(r).Stream(batchSize, func(recipe *models.Recipe) error {
+		if count == 0 {
+			w.Header().Set("Content-Type", ndjsonMediaType)
+			w.Header().Set("Content-Disposition", `attachment; filename="recipes.ndjson"`)
+			w.WriteHeader(http.StatusOK)
+		}
+
+		if err := encoder.Encode(recipe); err != nil {
+			return err
+		}
+		count++
+
+		// Push every completed batch to the client and give the next one time to be written
+		if count%batchSize == 0 {
+			_ = rc.SetWriteDeadline(time.Now().Add(exportBatchDeadline))
+			if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if count == 0 {
+			app.serverError(w, err)
+			return
+		}
+		// The status line has already been sent, all that can be done is to cut the stream short
+		app.errorLog.Printf("Export interrupted after %d recipes: %v", count, err)
+		return
+	}
+
+	if count == 0 {
+		w.Header().Set("Content-Type", ndjsonMediaType)
+		w.Header().Set("Content-Disposition", `attachment; filename="recipes.ndjson"`)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	app.infoLog.Printf("Exported %d recipes", count)
+}
+
+// importRecipes reads newline-delimited JSON recipes from the body and saves them in batches, each batch
+// in its own transaction. "?dry_run=true" validates everything without saving it and "?mode=upsert" replaces
+// existing recipes with the same name. The response reports the outcome of every non-blank line. Recipes
+// failing validation are reported as such, while any other error aborts the import.
+func (app *application) importRecipes(w http.ResponseWriter, r *http.Request) {
+	batchSize, err := app.readBatchSize(r)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	dryRun := query.Get("dry_run") == "true"
+
+	var upsert bool
+	switch query.Get("mode") {
+	case "", "create":
+	case "upsert":
+		upsert = true
+	default:
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// The body is read, and the report written, long after the server's timeouts have run out
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(importDeadline))
+	_ = rc.SetWriteDeadline(time.Now().Add(importDeadline))
+
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxImportBytes))
+
+	var (
+		lines   []*importLine
+		batch   []*models.Recipe
+		pending []*importLine
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		results, err := app.recipesFor(r).ImportBatch(batch, upsert, dryRun)
+		if err != nil {
+			return err
+		}
+
+		for i, result := range results {
+			pending[i].Status = result.Status
+			if !dryRun {
+				pending[i].ID = result.ID
+			}
+			if result.Err != nil {
+				message, err := importError(result.Err)
+				if err != nil {
+					return err
+				}
+				pending[i].Error = message
+			}
+		}
+
+		batch, pending = batch[:0], pending[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	for number := 1; scanner.Scan(); number++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		line := &importLine{Line: number}
+		lines = append(lines, line)
+
+		recipe, err := decodeImportLine(data)
+		if err != nil {
+			line.Status = models.ImportFailed
+			line.Error = err.Error()
+			continue
+		}
+		line.Name = recipe.Name
+
+		batch = append(batch, recipe)
+		pending = append(pending, line)
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				app.serverError(w, err)
+				return
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		var maxBytesError *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesError):
+			app.clientError(w, http.StatusRequestEntityTooLarge)
+		default:
+			app.clientError(w, http.StatusBadRequest)
+		}
+		return
+	}
+
+	if err := flush(); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	summary := importSummary{Total: len(lines)}
+	for _, line := range lines {
+		switch line.Status {
+		case models.ImportCreated:
+			summary.Created++
+		case models.ImportUpdated:
+			summary.Updated++
+		default:
+			summary.Failed++
+		}
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"dry_run": dryRun, "summary": summary, "results": lines}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Imported %d recipes (%d created, %d updated, %d failed, dry run: %t)",
+		summary.Total, summary.Created, summary.Updated, summary.Failed, dryRun)
+}
+
+// importError describes why a recipe failed validation, returning the error itself when the recipe
+// isn't at fault, such as when the database fails.
+func importError(err error) (string, error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidStepIngredient):
+		return "a step references an ingredient the recipe doesn't have", nil
+	default:
+		return "", err
+	}
+}
+
+// decodeImportLine decodes and validates a single recipe. Fields owned by the database, such as the ID,
+// the creation date, the parent and the images and ratings found in exported files, are ignored.
+func decodeImportLine(data []byte) (*models.Recipe, error) {
+	recipe := &models.Recipe{}
+	if err := json.Unmarshal(data, recipe); err != nil {
+		return nil, errors.New("malformed JSON")
+	}
+
+	if !validRecipe(recipe) {
+		return nil, errors.New("a field is missing or too long, or a value out of range")
+	}
+
+	recipe.ID = 0
+	recipe.CreatedAt = time.Time{}
+	recipe.DeletedAt = nil
+	recipe.Images = nil
+	recipe.ParentID = 0
+	recipe.RatingAverage = 0
+	recipe.RatingCount = 0
+	recipe.Favorited = nil
+
+	return recipe, nil
+}
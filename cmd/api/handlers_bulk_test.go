@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestExportRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	mockRecipes.EXPECT().Stream(1, gomock.Any()).DoAndReturn(func(_ int, fn func(*models.Recipe) error) error {
+		for _, recipe := range testRecipes {
+			if err := fn(recipe); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/export?batch_size=1", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+	var names []string
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		var recipe models.Recipe
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &recipe))
+		names = append(names, recipe.Name)
+	}
+	assert.Equal(t, []string{"Test Recipe 2", "Test Recipe 3"}, names)
+
+	res, err = ts.Client().Get(fmt.Sprintf("%s/v1/export?batch_size=0", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestImportRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	body := strings.Join([]string{
+		`{"id": 7, "name": "Pancakes", "ingredients": [{"name": "flour", "quantity": 200, "unit": "g"}]}`,
+		``,
+		`{"name": "Broken"`,
+		`{"name": "Omelette"}`,
+		`{"name": ""}`,
+		`{"name": "Waffles"}`,
+	}, "\n")
+
+	testCases := []struct {
+		name            string
+		query           string
+		upsert          bool
+		dryRun          bool
+		expectedStatus  int
+		expectedSummary importSummary
+	}{
+		{
+			name:            "Create In Batches",
+			query:           "?batch_size=2",
+			expectedStatus:  http.StatusOK,
+			expectedSummary: importSummary{Total: 5, Created: 2, Failed: 3},
+		},
+		{
+			name:            "Upsert Dry Run",
+			query:           "?batch_size=2&mode=upsert&dry_run=true",
+			upsert:          true,
+			dryRun:          true,
+			expectedStatus:  http.StatusOK,
+			expectedSummary: importSummary{Total: 5, Created: 1, Updated: 1, Failed: 3},
+		},
+		{
+			name:           "Unknown Mode",
+			query:          "?mode=replace",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				first := mockRecipes.EXPECT().ImportBatch(gomock.Any(), tc.upsert, tc.dryRun).DoAndReturn(
+					func(recipes []*models.Recipe, _, _ bool) ([]*models.ImportResult, error) {
+						assert.Len(t, recipes, 2)
+						assert.Equal(t, "Pancakes", recipes[0].Name)
+						assert.Zero(t, recipes[0].ID)
+						assert.Equal(t, "Omelette", recipes[1].Name)
+
+						status := models.ImportCreated
+						if tc.upsert {
+							status = models.ImportUpdated
+						}
+						return []*models.ImportResult{
+							{ID: 10, Status: status},
+							{Status: models.ImportFailed, Err: models.ErrInvalidStepIngredient},
+						}, nil
+					})
+				mockRecipes.EXPECT().ImportBatch(gomock.Any(), tc.upsert, tc.dryRun).After(first).DoAndReturn(
+					func(recipes []*models.Recipe, _, _ bool) ([]*models.ImportResult, error) {
+						assert.Len(t, recipes, 1)
+						assert.Equal(t, "Waffles", recipes[0].Name)
+						return []*models.ImportResult{{ID: 11, Status: models.ImportCreated}}, nil
+					})
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/import%s", ts.URL, tc.query), "application/x-ndjson", strings.NewReader(body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				DryRun  bool          `json:"dry_run"`
+				Summary importSummary `json:"summary"`
+				Results []*importLine `json:"results"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+			assert.Equal(t, tc.dryRun, response.DryRun)
+			assert.Equal(t, tc.expectedSummary, response.Summary)
+			assert.Len(t, response.Results, 5)
+
+			assert.Equal(t, 1, response.Results[0].Line)
+			assert.Equal(t, 3, response.Results[1].Line)
+			assert.Equal(t, "malformed JSON", response.Results[1].Error)
+			assert.Equal(t, "a step references an ingredient the recipe doesn't have", response.Results[2].Error)
+			assert.Equal(t, "a field is missing or too long, or a value out of range", response.Results[3].Error)
+			if tc.dryRun {
+				assert.Zero(t, response.Results[0].ID)
+			} else {
+				assert.Equal(t, 10, response.Results[0].ID)
+				assert.Equal(t, 11, response.Results[4].ID)
+			}
+		})
+	}
+
+	t.Run("Database Failure", func(t *testing.T) {
+		mockRecipes.EXPECT().ImportBatch(gomock.Any(), false, false).Return([]*models.ImportResult{
+			{Status: models.ImportFailed, Err: errors.New("Error 1205: Lock wait timeout exceeded")},
+		}, nil)
+
+		res, err := ts.Client().Post(fmt.Sprintf("%s/v1/import", ts.URL), "application/x-ndjson", strings.NewReader(`{"name": "Pancakes"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
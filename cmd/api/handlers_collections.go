@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+type collectionInput struct {
+	Name      string `json:"name"`
+	RecipeIDs []int  `json:"recipe_ids"`
+	Shared    bool   `json:"shared"`
+}
+
+// apply validates the input and copies it onto the collection, the recipes in the order they are given.
+func (input *collectionInput) apply(collection *models.Collection) bool {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > 255 {
+		return false
+	}
+
+	recipes := make([]*models.CollectionRecipe, 0, len(input.RecipeIDs))
+	for i, id := range input.RecipeIDs {
+		if id < 1 || slices.Contains(input.RecipeIDs[:i], id) {
+			return false
+		}
+		recipes = append(recipes, &models.CollectionRecipe{RecipeID: id})
+	}
+
+	collection.Name = name
+	collection.Shared = input.Shared
+	collection.Recipes = recipes
+	return true
+}
+
+func (app *application) createCollection(w http.ResponseWriter, r *http.Request) {
+	var input collectionInput
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	collection := &models.Collection{UserID: app.contextGetUserID(r)}
+	if !input.apply(collection) {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	id, err := app.collections.Insert(collection)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidCollectionRecipe):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	collection, err = app.collections.Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/collections/%d", id))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"collection": collection}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new collection with id: %d", id)
+}
+
+func (app *application) listCollections(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	collections, err := app.collections.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"collections": collections}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the collections of user %d", userID)
+}
+
+// readOwnCollection looks up the collection named in the URL, answering the request itself when the
+// collection doesn't exist. Collections of other users are reported as missing rather than forbidden,
+// so that their existence doesn't leak.
+func (app *application) readOwnCollection(w http.ResponseWriter, r *http.Request) (*models.Collection, bool) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return nil, false
+	}
+
+	collection, err := app.collections.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return nil, false
+	}
+
+	if collection.UserID != app.contextGetUserID(r) {
+		app.clientError(w, http.StatusNotFound)
+		return nil, false
+	}
+
+	return collection, true
+}
+
+func (app *application) getCollection(w http.ResponseWriter, r *http.Request) {
+	collection, ok := app.readOwnCollection(w, r)
+	if !ok {
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"collection": collection}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved collection with id: %d", collection.ID)
+}
+
+// updateCollection replaces the name, the sharing and the recipes of a collection, which is how
+// its recipes get reordered.
+func (app *application) updateCollection(w http.ResponseWriter, r *http.Request) {
+	collection, ok := app.readOwnCollection(w, r)
+	if !ok {
+		return
+	}
+
+	var input collectionInput
+	if err := app.readJSON(w, r, &input); err != nil || !input.apply(collection) {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	app.saveCollection(w, collection)
+}
+
+// addCollectionRecipe adds a recipe to a collection at the given 1-based position, or at the end.
+func (app *application) addCollectionRecipe(w http.ResponseWriter, r *http.Request) {
+	collection, ok := app.readOwnCollection(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		RecipeID int `json:"recipe_id"`
+		Position int `json:"position"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if input.RecipeID < 1 || input.Position < 0 || input.Position > len(collection.Recipes)+1 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	containsRecipe := slices.ContainsFunc(collection.Recipes, func(recipe *models.CollectionRecipe) bool {
+		return recipe.RecipeID == input.RecipeID
+	})
+	if containsRecipe {
+		app.clientError(w, http.StatusConflict)
+		return
+	}
+
+	index := len(collection.Recipes)
+	if input.Position > 0 {
+		index = input.Position - 1
+	}
+	collection.Recipes = slices.Insert(collection.Recipes, index, &models.CollectionRecipe{RecipeID: input.RecipeID})
+
+	app.saveCollection(w, collection)
+}
+
+func (app *application) removeCollectionRecipe(w http.ResponseWriter, r *http.Request) {
+	collection, ok := app.readOwnCollection(w, r)
+	if !ok {
+		return
+	}
+
+	recipeID, err := app.readIDParam(r, "recipe")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	index := slices.IndexFunc(collection.Recipes, func(recipe *models.CollectionRecipe) bool {
+		return recipe.RecipeID == recipeID
+	})
+	if index < 0 {
+		app.clientError(w, http.StatusNotFound)
+		return
+	}
+	collection.Recipes = slices.Delete(collection.Recipes, index, index+1)
+
+	app.saveCollection(w, collection)
+}
+
+// saveCollection updates the collection and responds with it as stored.
+func (app *application) saveCollection(w http.ResponseWriter, collection *models.Collection) {
+	if err := app.collections.Update(collection); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrInvalidCollectionRecipe):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	collection, err := app.collections.Get(collection.ID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"collection": collection}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Updated collection with id: %d", collection.ID)
+}
+
+func (app *application) deleteCollection(w http.ResponseWriter, r *http.Request) {
+	collection, ok := app.readOwnCollection(w, r)
+	if !ok {
+		return
+	}
+
+	if err := app.collections.Delete(collection.ID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Collection successfully deleted"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Deleted collection with id: %d", collection.ID)
+}
+
+// getSharedCollection serves a shared collection to anyone knowing its share token.
+func (app *application) getSharedCollection(w http.ResponseWriter, r *http.Request) {
+	token := httprouter.ParamsFromContext(r.Context()).ByName("token")
+
+	collection, err := app.collections.GetByShareToken(token)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"collection": collection}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved shared collection with id: %d", collection.ID)
+}
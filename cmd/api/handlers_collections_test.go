@@ -0,0 +1,311 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newCollection() *models.Collection {
+	return &models.Collection{
+		ID:        1,
+		UserID:    1,
+		Name:      "Christmas menu",
+		CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+		Recipes: []*models.CollectionRecipe{
+			{RecipeID: 1, Name: "Pancakes"},
+			{RecipeID: 2, Name: "Roast turkey"},
+		},
+	}
+}
+
+func collectionRecipeIDs(collection *models.Collection) []int {
+	ids := make([]int, 0, len(collection.Recipes))
+	for _, recipe := range collection.Recipes {
+		ids = append(ids, recipe.RecipeID)
+	}
+	return ids
+}
+
+func TestCreateCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockCollections := mocks.NewMockCollectionModelInterface(ctrl)
+	app.users = mockUsers
+	app.collections = mockCollections
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		insertErr      error
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Collection",
+			body:           `{"name": " Christmas menu ", "recipe_ids": [2, 1], "shared": true}`,
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Unknown Recipe",
+			body:           `{"name": "Christmas menu", "recipe_ids": [2, 1]}`,
+			insertErr:      models.ErrInvalidCollectionRecipe,
+			expectInsert:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Repeated Recipe",
+			body:           `{"name": "Christmas menu", "recipe_ids": [1, 1]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Name",
+			body:           `{"recipe_ids": [1]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectInsert {
+				mockCollections.EXPECT().Insert(gomock.Any()).DoAndReturn(func(collection *models.Collection) (int, error) {
+					assert.Equal(t, 1, collection.UserID)
+					assert.Equal(t, "Christmas menu", collection.Name)
+					assert.Equal(t, []int{2, 1}, collectionRecipeIDs(collection))
+					return 1, tc.insertErr
+				})
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockCollections.EXPECT().Get(1).Return(newCollection(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/collections", ts.URL), strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestGetCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockCollections := mocks.NewMockCollectionModelInterface(ctrl)
+	app.users = mockUsers
+	app.collections = mockCollections
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		ownerID        int
+		expectedStatus int
+	}{
+		{
+			name:           "Own Collection",
+			ownerID:        1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Someone Else's Collection",
+			ownerID:        2,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collection := newCollection()
+			collection.UserID = tc.ownerID
+			mockCollections.EXPECT().Get(1).Return(collection, nil)
+
+			req := authenticatedRequest(t, mockUsers, http.MethodGet, fmt.Sprintf("%s/v1/collections/1", ts.URL), nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestAddCollectionRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockCollections := mocks.NewMockCollectionModelInterface(ctrl)
+	app.users = mockUsers
+	app.collections = mockCollections
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedIDs    []int
+		expectedStatus int
+	}{
+		{
+			name:           "At The End",
+			body:           `{"recipe_id": 3}`,
+			expectedIDs:    []int{1, 2, 3},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "At A Position",
+			body:           `{"recipe_id": 3, "position": 1}`,
+			expectedIDs:    []int{3, 1, 2},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Position Out Of Range",
+			body:           `{"recipe_id": 3, "position": 5}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Already In Collection",
+			body:           `{"recipe_id": 2}`,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCollections.EXPECT().Get(1).Return(newCollection(), nil)
+			if tc.expectedStatus == http.StatusOK {
+				mockCollections.EXPECT().Update(gomock.Any()).DoAndReturn(func(collection *models.Collection) error {
+					assert.Equal(t, tc.expectedIDs, collectionRecipeIDs(collection))
+					return nil
+				})
+				mockCollections.EXPECT().Get(1).Return(newCollection(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/collections/1/recipes", ts.URL), strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestRemoveCollectionRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockCollections := mocks.NewMockCollectionModelInterface(ctrl)
+	app.users = mockUsers
+	app.collections = mockCollections
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "In Collection",
+			path:           "/v1/collections/1/recipes/1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Not In Collection",
+			path:           "/v1/collections/1/recipes/3",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCollections.EXPECT().Get(1).Return(newCollection(), nil)
+			if tc.expectedStatus == http.StatusOK {
+				mockCollections.EXPECT().Update(gomock.Any()).DoAndReturn(func(collection *models.Collection) error {
+					assert.Equal(t, []int{2}, collectionRecipeIDs(collection))
+					return nil
+				})
+				mockCollections.EXPECT().Get(1).Return(newCollection(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodDelete, ts.URL+tc.path, nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestGetSharedCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockCollections := mocks.NewMockCollectionModelInterface(ctrl)
+	app.collections = mockCollections
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		lookupErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "Shared Collection",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Token",
+			lookupErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collection := newCollection()
+			collection.Shared = true
+			collection.ShareToken = "c2hhcmVk"
+			mockCollections.EXPECT().GetByShareToken("c2hhcmVk").Return(collection, tc.lookupErr)
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/shared/collections/c2hhcmVk", ts.URL))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body map[string]map[string]any
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.NotContains(t, body["collection"], "user_id")
+				assert.Equal(t, "Christmas menu", body["collection"]["name"])
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func (app *application) listFavorites(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	favorites, err := app.favoritesFor(r).GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"favorites": favorites}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the favorites of user %d", userID)
+}
+
+// addFavorite marks a recipe as a favorite of the user, doing it twice being the same as doing it once.
+func (app *application) addFavorite(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	recipe, ok := app.readRecipe(w, r, "recipe")
+	if !ok {
+		return
+	}
+	recipeID := recipe.ID
+
+	if err := app.favoritesFor(r).Insert(userID, recipeID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Recipe successfully added to the favorites"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("User %d favorited recipe with id: %d", userID, recipeID)
+}
+
+func (app *application) removeFavorite(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	recipeID, err := app.readIDParam(r, "recipe")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.favoritesFor(r).Delete(userID, recipeID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Recipe successfully removed from the favorites"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("User %d unfavorited recipe with id: %d", userID, recipeID)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestListFavorites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	app.users = mockUsers
+	app.favorites = mockFavorites
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	favorites := []*models.Favorite{
+		{RecipeID: 1, Name: "Pancakes", CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)},
+	}
+	mockFavorites.EXPECT().GetByUserID(1).Return(favorites, nil)
+
+	req := authenticatedRequest(t, mockUsers, http.MethodGet, fmt.Sprintf("%s/v1/me/favorites", ts.URL), nil)
+	res, err := ts.Client().Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Favorites []*models.Favorite `json:"favorites"`
+	}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, favorites, body.Favorites)
+}
+
+func TestAddFavorite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	app.users = mockUsers
+	app.recipes = mockRecipes
+	app.favorites = mockFavorites
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		path           string
+		recipeErr      error
+		insertErr      error
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Recipe",
+			path:           "/v1/me/favorites/1",
+			expectInsert:   true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Recipe Not In Workspace",
+			path:           "/v1/me/favorites/1",
+			recipeErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Recipe Trashed Meanwhile",
+			path:           "/v1/me/favorites/1",
+			insertErr:      models.ErrNoRecord,
+			expectInsert:   true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid ID",
+			path:           "/v1/me/favorites/pancakes",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.path == "/v1/me/favorites/1" {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			}
+			if tc.expectInsert {
+				mockFavorites.EXPECT().Insert(1, 1).Return(tc.insertErr)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPut, ts.URL+tc.path, nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	app.users = mockUsers
+	app.favorites = mockFavorites
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		deleteErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "Favorited Recipe",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Not A Favorite",
+			deleteErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFavorites.EXPECT().Delete(1, 1).Return(tc.deleteErr)
+
+			req := authenticatedRequest(t, mockUsers, http.MethodDelete, fmt.Sprintf("%s/v1/me/favorites/1", ts.URL), nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestGetRecipeFavorited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	app.users = mockUsers
+	app.recipes = mockRecipes
+	app.favorites = mockFavorites
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name              string
+		authenticated     bool
+		favorited         bool
+		expectedFavorited *bool
+	}{
+		{
+			name:              "Favorited",
+			authenticated:     true,
+			favorited:         true,
+			expectedFavorited: func() *bool { b := true; return &b }(),
+		},
+		{
+			name:              "Not Favorited",
+			authenticated:     true,
+			expectedFavorited: func() *bool { b := false; return &b }(),
+		},
+		{
+			name: "Anonymous",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+
+			url := fmt.Sprintf("%s/v1/recipes/1", ts.URL)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			assert.NoError(t, err)
+			if tc.authenticated {
+				req = authenticatedRequest(t, mockUsers, http.MethodGet, url, nil)
+				mockFavorites.EXPECT().Exists(1, 1).Return(tc.favorited, nil)
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Subset(t, res.Header.Values("Vary"), []string{"Accept", "Authorization"})
+
+			var body struct {
+				Recipe *models.Recipe `json:"recipe"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, tc.expectedFavorited, body.Recipe.Favorited)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// forkRecipe copies a recipe into a new variant of it, named after the original unless a name is given.
+func (app *application) forkRecipe(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// The body is optional, forking with an empty one keeps the name
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil && !errors.Is(err, io.EOF) {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if utf8.RuneCountInString(input.Name) > 100 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	forkID, err := app.recipesFor(r).Fork(id, input.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	fork, err := app.recipesFor(r).Get(forkID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/recipes/%d", forkID))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"recipe": fork}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Forked recipe with id %d into recipe with id: %d", id, forkID)
+}
+
+// listRecipeVariants returns the recipes forked from the given one.
+func (app *application) listRecipeVariants(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := app.recipesFor(r).Get(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	recipes, err := app.recipesFor(r).GetAll()
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, err)
+		return
+	}
+
+	variants := slices.DeleteFunc(recipes, func(recipe *models.Recipe) bool {
+		return recipe.ParentID != id
+	})
+	if variants == nil {
+		variants = []*models.Recipe{}
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"variants": variants}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the variants of recipe with id: %d", id)
+}
+
+// diffRecipeFork compares the ingredients and instructions of a fork against the recipe it was forked from.
+func (app *application) diffRecipeFork(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	fork, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	// Only forks have something to be compared against
+	if fork.ParentID == 0 {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	parent, err := app.recipesFor(r).Get(fork.ParentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	changes := slices.DeleteFunc(models.DiffRecipes(parent, fork), func(change *models.FieldChange) bool {
+		return change.Field != "instructions" &&
+			!strings.HasPrefix(change.Field, "ingredients.") &&
+			!strings.HasPrefix(change.Field, "steps.")
+	})
+
+	data := envelope{
+		"parent_id": parent.ID,
+		"changes":   changes,
+	}
+	if err := app.writeJSON(w, http.StatusOK, data, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Computed diff for fork %d of recipe with id: %d", id, parent.ID)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// newFork returns a gluten-free variant of the patchable recipe.
+func newFork() *models.Recipe {
+	fork := newPatchableRecipe()
+	fork.ID = 2
+	fork.ParentID = 1
+	fork.Name = "Gluten-free pancakes"
+	fork.Ingredients[0] = &models.FullIngredient{Ingredient: &models.Ingredient{ID: 3, Name: "rice flour"}, Quantity: 180, Unit: "g"}
+	return fork
+}
+
+func TestForkRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedName   string
+		forkErr        error
+		expectFork     bool
+		expectedStatus int
+	}{
+		{
+			name:           "Named Fork",
+			body:           `{"name": " Gluten-free pancakes "}`,
+			expectedName:   "Gluten-free pancakes",
+			expectFork:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Empty Body",
+			expectFork:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Recipe Not Found",
+			forkErr:        models.ErrNoRecord,
+			expectFork:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Body",
+			body:           `{"title": "Gluten-free pancakes"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectFork {
+				mockRecipes.EXPECT().Fork(1, tc.expectedName).Return(2, tc.forkErr)
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockRecipes.EXPECT().Get(2).Return(newFork(), nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/1/fork", ts.URL), "application/json", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Equal(t, "v1/recipes/2", res.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestListRecipeVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+	mockRecipes.EXPECT().GetAll().Return([]*models.Recipe{newPatchableRecipe(), newFork(), testRecipe}, nil)
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1/variants", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Variants []*models.Recipe `json:"variants"`
+	}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Len(t, body.Variants, 1)
+	assert.Equal(t, 2, body.Variants[0].ID)
+}
+
+func TestDiffRecipeFork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	t.Run("Fork", func(t *testing.T) {
+		mockRecipes.EXPECT().Get(2).Return(newFork(), nil)
+		mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+
+		res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/2/diff", ts.URL))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		var body struct {
+			ParentID int                   `json:"parent_id"`
+			Changes  []*models.FieldChange `json:"changes"`
+		}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		assert.Equal(t, 1, body.ParentID)
+
+		// The name differs as well, but only ingredients and instructions are compared
+		fields := make([]string, 0, len(body.Changes))
+		for _, change := range body.Changes {
+			fields = append(fields, change.Field)
+		}
+		assert.Equal(t, []string{"ingredients.flour", "ingredients.rice flour"}, fields)
+	})
+
+	t.Run("Not A Fork", func(t *testing.T) {
+		mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+
+		res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1/diff", ts.URL))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	})
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vladComan0/tasty-byte/internal/blobstore"
+	"github.com/vladComan0/tasty-byte/internal/images"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// Stored images are scaled down so that their largest side fits these dimensions, in pixels
+const (
+	maxImageDimension  = 2048
+	thumbnailDimension = 320
+)
+
+// uploadRecipeImage adds an image to a recipe from the "image" field of a multipart form.
+// The format is sniffed from the content rather than trusted from the client.
+func (app *application) uploadRecipeImage(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// Leave some room for the multipart boundaries and headers around the file itself
+	r.Body = http.MaxBytesReader(w, r.Body, app.config.MaxImageSize+1_048_576)
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesError):
+			app.clientError(w, http.StatusRequestEntityTooLarge)
+		default:
+			app.clientError(w, http.StatusBadRequest)
+		}
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(file, app.config.MaxImageSize+1))
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+	if int64(len(data)) > app.config.MaxImageSize {
+		app.clientError(w, http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if _, err := app.recipesFor(r).Get(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	full, thumbnail, err := images.Process(data, maxImageDimension, thumbnailDimension)
+	if err != nil {
+		switch {
+		case errors.Is(err, images.ErrUnsupportedFormat):
+			app.clientError(w, http.StatusUnsupportedMediaType)
+		case errors.Is(err, images.ErrTooLarge):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	image, err := app.images.Insert(id, full, thumbnail)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", image.URL)
+
+	if err = app.writeJSON(w, http.StatusCreated, envelope{"image": image}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Added image %d to recipe with id: %d", image.ID, id)
+}
+
+func (app *application) deleteRecipeImage(w http.ResponseWriter, r *http.Request) {
+	recipe, ok := app.readRecipe(w, r, "id")
+	if !ok {
+		return
+	}
+	id := recipe.ID
+
+	imageID, err := app.readIDParam(r, "image")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.images.Delete(id, imageID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Image successfully deleted"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Deleted image %d of recipe with id: %d", imageID, id)
+}
+
+// serveImage serves a stored image. Image keys are never reused, so the files can be cached indefinitely.
+func (app *application) serveImage(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(httprouter.ParamsFromContext(r.Context()).ByName("key"), "/")
+
+	blob, err := app.blobs.Get(key)
+	if err != nil {
+		switch {
+		case errors.Is(err, blobstore.ErrNotFound), errors.Is(err, blobstore.ErrInvalidKey):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+	defer func() {
+		_ = blob.Close()
+	}()
+
+	name := path.Base(key)
+	w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", fmt.Sprintf("%q", strings.TrimSuffix(name, path.Ext(name))))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent takes care of conditional and range requests
+	http.ServeContent(w, r, name, blob.ModTime, blob)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/blobstore"
+	"github.com/vladComan0/tasty-byte/internal/images"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newImageUpload(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile(field, "upload.png")
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	return &body, writer.FormDataContentType()
+}
+
+func TestUploadRecipeImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+	app.config.MaxImageSize = 64 * 1024
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockImages := mocks.NewMockRecipeImageModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.images = mockImages
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	var pngData bytes.Buffer
+	assert.NoError(t, png.Encode(&pngData, image.NewNRGBA(image.Rect(0, 0, 640, 480))))
+
+	testCases := []struct {
+		name           string
+		field          string
+		data           []byte
+		recipeErr      error
+		expectGet      bool
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Image",
+			field:          "image",
+			data:           pngData.Bytes(),
+			expectGet:      true,
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Recipe Not Found",
+			field:          "image",
+			data:           pngData.Bytes(),
+			recipeErr:      models.ErrNoRecord,
+			expectGet:      true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Not An Image",
+			field:          "image",
+			data:           []byte("GIF? no, just text"),
+			expectGet:      true,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "Missing Field",
+			field:          "file",
+			data:           pngData.Bytes(),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Too Large",
+			field:          "image",
+			data:           bytes.Repeat([]byte{0}, 128*1024),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			}
+			if tc.expectInsert {
+				mockImages.EXPECT().Insert(1, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ int, full, thumbnail *images.Encoded) (*models.Image, error) {
+						assert.Equal(t, "image/png", full.ContentType)
+						assert.Equal(t, 640, full.Width)
+						assert.Equal(t, 320, thumbnail.Width)
+						assert.Equal(t, 240, thumbnail.Height)
+						return &models.Image{ID: 1, URL: "/v1/images/recipes/1/abc.png"}, nil
+					})
+			}
+
+			body, contentType := newImageUpload(t, tc.field, tc.data)
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/1/images", ts.URL), contentType, body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Equal(t, "/v1/images/recipes/1/abc.png", res.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestDeleteRecipeImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockImages := mocks.NewMockRecipeImageModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.images = mockImages
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		recipeErr      error
+		deleteErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "Image Deleted",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Recipe Not In Workspace",
+			recipeErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Image Not Found",
+			deleteErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			if tc.recipeErr == nil {
+				mockImages.EXPECT().Delete(1, 2).Return(tc.deleteErr)
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/v1/recipes/1/images/2", ts.URL), nil)
+			assert.NoError(t, err)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestServeImage(t *testing.T) {
+	app := newTestApplication()
+
+	store, err := blobstore.NewLocal(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put("recipes/1/abc_thumb.png", strings.NewReader("png data")))
+	app.blobs = store
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/images/recipes/1/abc_thumb.png", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+	assert.Equal(t, "public, max-age=31536000, immutable", res.Header.Get("Cache-Control"))
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "png data", string(body))
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/images/recipes/1/abc_thumb.png", ts.URL), nil)
+	assert.NoError(t, err)
+	req.Header.Set("If-None-Match", res.Header.Get("ETag"))
+	res, err = ts.Client().Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	res, err = ts.Client().Get(fmt.Sprintf("%s/v1/images/recipes/1/missing.png", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
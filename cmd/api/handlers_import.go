@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/schemaorg"
+)
+
+// importRecipe extracts a schema.org Recipe from a JSON-LD document or an HTML page sent in the body.
+// By default the parsed recipe is only returned as a preview, "?persist=true" saves it as well.
+func (app *application) importRecipe(w http.ResponseWriter, r *http.Request) {
+	const maxBytes = 5 * 1_048_576
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes))
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var recipe *models.Recipe
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		recipe, err = schemaorg.ParseHTML(body)
+	case mediaType == "application/ld+json" || mediaType == "application/json":
+		recipe, err = schemaorg.ParseJSONLD(body)
+	case bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")):
+		recipe, err = schemaorg.ParseHTML(body)
+	default:
+		recipe, err = schemaorg.ParseJSONLD(body)
+	}
+	if err != nil || !validRecipe(recipe) {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if r.URL.Query().Get("persist") != "true" {
+		if err := app.writeJSON(w, http.StatusOK, envelope{"recipe": recipe}, nil); err != nil {
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	id, err := app.recipesFor(r).Insert(recipe)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidStepIngredient):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	recipe, err = app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/recipes/%d", id))
+
+	if err = app.writeJSON(w, http.StatusCreated, envelope{"recipe": recipe}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Imported new recipe with id: %d", id)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+const testImportJSONLD = `{
+	"@context": "https://schema.org",
+	"@type": "Recipe",
+	"name": "Tomato Soup",
+	"prepTime": "PT10M",
+	"cookTime": "PT30M",
+	"recipeYield": "4 servings",
+	"recipeIngredient": ["1 kg tomatoes", "2 tbsp olive oil"],
+	"recipeInstructions": "Roast the tomatoes.\nBlend."
+}`
+
+func TestImportRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		contentType    string
+		body           string
+		query          string
+		expectInsert   bool
+		insertErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "JSON-LD Preview",
+			contentType:    "application/ld+json",
+			body:           testImportJSONLD,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "HTML Persisted",
+			contentType:    "text/html; charset=utf-8",
+			body:           `<html><head><script type="application/ld+json">` + testImportJSONLD + `</script></head></html>`,
+			query:          "?persist=true",
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Step Of Another Ingredient",
+			contentType:    "application/ld+json",
+			body:           testImportJSONLD,
+			query:          "?persist=true",
+			expectInsert:   true,
+			insertErr:      models.ErrInvalidStepIngredient,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "No Recipe In Page",
+			contentType:    "text/html",
+			body:           `<html><body>Nothing to see</body></html>`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Quantity Too Large",
+			contentType:    "application/ld+json",
+			body:           strings.Replace(testImportJSONLD, "1 kg tomatoes", "1000 g tomatoes", 1),
+			query:          "?persist=true",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Name Too Long",
+			contentType:    "application/ld+json",
+			body:           strings.Replace(testImportJSONLD, "Tomato Soup", strings.Repeat("Tomato ", 20), 1),
+			query:          "?persist=true",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Empty Body",
+			contentType:    "application/ld+json",
+			body:           ``,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectInsert {
+				mockRecipes.EXPECT().Insert(gomock.Any()).DoAndReturn(func(recipe *models.Recipe) (int, error) {
+					assert.Equal(t, "Tomato Soup", recipe.Name)
+					return 5, tc.insertErr
+				})
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockRecipes.EXPECT().Get(5).Return(&models.Recipe{ID: 5, Name: "Tomato Soup"}, nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/import%s", ts.URL, tc.query), tc.contentType, bytes.NewBufferString(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Recipe *models.Recipe `json:"recipe"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, "Tomato Soup", body.Recipe.Name)
+			assert.Equal(t, "10m", body.Recipe.PreparationTime)
+			assert.Equal(t, "30m", body.Recipe.CookingTime)
+			assert.Equal(t, 4, body.Recipe.Portions)
+			assert.Len(t, body.Recipe.Ingredients, 2)
+		})
+	}
+
+	res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/unknown", ts.URL), "application/json", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
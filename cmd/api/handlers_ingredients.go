@@ -0,0 +1,251 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// ingredientResponse is an ingredient as returned by the ingredient endpoints, telling apart an
+// ingredient without allergens from one nobody has classified yet.
+type ingredientResponse struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Allergens  []string `json:"allergens"`
+	Diets      []string `json:"diets"`
+	Classified bool     `json:"classified"`
+	Aliases    []string `json:"aliases,omitempty"`
+}
+
+func newIngredientResponse(ingredient *models.Ingredient) *ingredientResponse {
+	return &ingredientResponse{
+		ID:         ingredient.ID,
+		Name:       ingredient.Name,
+		Allergens:  ingredient.Allergens,
+		Diets:      ingredient.Diets,
+		Classified: ingredient.Classified,
+	}
+}
+
+// getIngredientWithAliases returns the ingredient along with the aliases it resolves from.
+func (app *application) getIngredientWithAliases(r *http.Request, id int) (*ingredientResponse, error) {
+	ingredient, err := app.ingredientsFor(r).Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newIngredientResponse(ingredient)
+	response.Aliases, err = app.ingredientsFor(r).GetAliases(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (app *application) getIngredient(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	ingredient, err := app.getIngredientWithAliases(r, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"ingredient": ingredient}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved ingredient with id: %d", id)
+}
+
+// classifyIngredient replaces the allergens an ingredient contains and the diets it is suitable for.
+func (app *application) classifyIngredient(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Allergens []string `json:"allergens"`
+		Diets     []string `json:"diets"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.ingredientsFor(r).SetClassification(id, input.Allergens, input.Diets); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrInvalidClassification):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	ingredient, err := app.ingredientsFor(r).Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"ingredient": newIngredientResponse(ingredient)}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Classified ingredient with id: %d", id)
+}
+
+// addIngredientAlias makes another name resolve to the ingredient, e.g. "roma tomato" to "tomato".
+func (app *application) addIngredientAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Alias string `json:"alias"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.ingredientsFor(r).AddAlias(id, input.Alias); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrInvalidAlias):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		case errors.Is(err, models.ErrDuplicateAlias):
+			app.clientError(w, http.StatusConflict)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	ingredient, err := app.getIngredientWithAliases(r, id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"ingredient": ingredient}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Added alias %q to ingredient with id: %d", models.NormalizeIngredientName(input.Alias), id)
+}
+
+func (app *application) removeIngredientAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	alias := httprouter.ParamsFromContext(r.Context()).ByName("alias")
+
+	if err := app.ingredientsFor(r).RemoveAlias(id, alias); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "alias successfully deleted"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Removed alias %q from ingredient with id: %d", alias, id)
+}
+
+// mergeIngredients folds duplicate ingredients into the one in the path, which the recipes, pantries,
+// shopping lists and substitutions using them then refer to instead.
+func (app *application) mergeIngredients(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		IngredientIDs []int `json:"ingredient_ids"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	recipeIDs, err := app.ingredientsFor(r).Merge(id, input.IngredientIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrInvalidMerge):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		case errors.Is(err, models.ErrIncompatibleUnits), errors.Is(err, models.ErrQuantityOverflow):
+			app.clientError(w, http.StatusConflict)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	// The recipes changed behind the back of the recipe model, so the recommendations are refreshed here
+	for _, recipeID := range recipeIDs {
+		recipe, err := app.recipesFor(r).Get(recipeID)
+		switch {
+		case err == nil:
+			app.recommender.Put(recipe)
+		case !errors.Is(err, models.ErrNoRecord):
+			app.serverError(w, err)
+			return
+		}
+	}
+
+	ingredient, err := app.getIngredientWithAliases(r, id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	data := envelope{
+		"ingredient":      ingredient,
+		"updated_recipes": recipeIDs,
+	}
+	if err := app.writeJSON(w, http.StatusOK, data, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Merged ingredients %v into ingredient with id: %d", input.IngredientIDs, id)
+}
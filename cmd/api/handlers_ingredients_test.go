@@ -0,0 +1,538 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestListRecipesDietary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	mockFacets.EXPECT().Count(gomock.Any(), gomock.Any()).Return(&models.Facets{}, nil).AnyTimes()
+	app.facets = mockFacets
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	// The recipes are filtered by the database, so only the filter it is given is checked here
+	testCases := []struct {
+		name              string
+		query             string
+		expectedAllergens []string
+		expectedDiets     []string
+		expectedStatus    int
+	}{
+		{
+			name:           "No Filter",
+			query:          "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "Exclude Allergen",
+			query:             "?exclude_allergen=nuts",
+			expectedAllergens: []string{"nuts"},
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:              "Exclude Several Allergens",
+			query:             "?exclude_allergen=nuts,Eggs",
+			expectedAllergens: []string{"nuts", "eggs"},
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:           "Diet",
+			query:          "?diet=vegetarian",
+			expectedDiets:  []string{"vegetarian"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "Allergen And Diet",
+			query:             "?exclude_allergen=sesame&diet=vegetarian",
+			expectedAllergens: []string{"sesame"},
+			expectedDiets:     []string{"vegetarian"},
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:           "Unknown Allergen",
+			query:          "?exclude_allergen=msg",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Diet",
+			query:          "?diet=keto",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockRecipes.EXPECT().List(gomock.Any()).DoAndReturn(func(filter *models.RecipeFilter) ([]*models.Recipe, int, error) {
+					assert.Equal(t, tc.expectedAllergens, filter.ExcludedAllergens)
+					assert.Equal(t, tc.expectedDiets, filter.Diets)
+					return []*models.Recipe{{ID: 2, Name: "Hummus"}}, 1, nil
+				})
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestGetIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	app.ingredients = mockIngredients
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		id             string
+		mockReturn     *models.Ingredient
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Valid ID",
+			id:             "1",
+			mockReturn:     &models.Ingredient{ID: 1, Name: "flour", Allergens: []string{"gluten"}, Diets: []string{"vegan"}, Classified: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			id:             "abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Ingredient Not Found",
+			id:             "1",
+			mockReturnErr:  models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.id == "1" {
+				mockIngredients.EXPECT().Get(1).Return(tc.mockReturn, tc.mockReturnErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockIngredients.EXPECT().GetAliases(1).Return([]string{"flour", "plain flour"}, nil)
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/ingredients/%s", ts.URL, tc.id))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Ingredient ingredientResponse `json:"ingredient"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, []string{"gluten"}, body.Ingredient.Allergens)
+			assert.True(t, body.Ingredient.Classified)
+			assert.Equal(t, []string{"flour", "plain flour"}, body.Ingredient.Aliases)
+		})
+	}
+}
+
+func TestClassifyIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	app.ingredients = mockIngredients
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		setErr         error
+		expectSet      bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Classification",
+			body:           `{"allergens": ["nuts"], "diets": ["vegan", "vegetarian"]}`,
+			expectSet:      true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Allergen",
+			body:           `{"allergens": ["msg"], "diets": []}`,
+			expectSet:      true,
+			setErr:         models.ErrInvalidClassification,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Ingredient Not Found",
+			body:           `{"allergens": [], "diets": []}`,
+			expectSet:      true,
+			setErr:         models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Malformed Body",
+			body:           `{"allergens": "nuts"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectSet {
+				mockIngredients.EXPECT().SetClassification(1, gomock.Any(), gomock.Any()).Return(tc.setErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockIngredients.EXPECT().Get(1).Return(&models.Ingredient{
+					ID:         1,
+					Name:       "walnuts",
+					Allergens:  []string{"nuts"},
+					Diets:      []string{"vegan", "vegetarian"},
+					Classified: true,
+				}, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/v1/ingredients/1/classification", ts.URL), strings.NewReader(tc.body))
+			assert.NoError(t, err)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestAddIngredientAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	app.users = mockUsers
+	app.ingredients = mockIngredients
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		addErr         error
+		expectAdd      bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Alias",
+			body:           `{"alias": "Roma Tomatoes"}`,
+			expectAdd:      true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Empty Alias",
+			body:           `{"alias": " "}`,
+			expectAdd:      true,
+			addErr:         models.ErrInvalidAlias,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Alias Of Another Ingredient",
+			body:           `{"alias": "cherry tomatoes"}`,
+			expectAdd:      true,
+			addErr:         models.ErrDuplicateAlias,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Ingredient Not Found",
+			body:           `{"alias": "roma tomato"}`,
+			expectAdd:      true,
+			addErr:         models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Malformed Body",
+			body:           `{"aliases": ["roma tomato"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectAdd {
+				mockIngredients.EXPECT().AddAlias(1, gomock.Any()).Return(tc.addErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockIngredients.EXPECT().Get(1).Return(&models.Ingredient{ID: 1, Name: "tomato"}, nil)
+				mockIngredients.EXPECT().GetAliases(1).Return([]string{"roma tomato", "tomato"}, nil)
+			}
+
+			req := adminRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/admin/ingredients/1/aliases", ts.URL), strings.NewReader(tc.body))
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestRemoveIngredientAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	app.users = mockUsers
+	app.ingredients = mockIngredients
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		removeErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "Existing Alias",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Alias Not Found",
+			removeErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockIngredients.EXPECT().RemoveAlias(1, "roma tomato").Return(tc.removeErr)
+
+			req := adminRequest(t, mockUsers, http.MethodDelete, fmt.Sprintf("%s/v1/admin/ingredients/1/aliases/roma%%20tomato", ts.URL), nil)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestMergeIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.users = mockUsers
+	app.ingredients = mockIngredients
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		recipeIDs      []int
+		mergeErr       error
+		expectMerge    bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Merge",
+			body:           `{"ingredient_ids": [2, 3]}`,
+			recipeIDs:      []int{1},
+			expectMerge:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Merge Into Itself",
+			body:           `{"ingredient_ids": [1]}`,
+			mergeErr:       models.ErrInvalidMerge,
+			expectMerge:    true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Incompatible Units",
+			body:           `{"ingredient_ids": [2]}`,
+			mergeErr:       models.ErrIncompatibleUnits,
+			expectMerge:    true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Combined Quantity Too Large",
+			body:           `{"ingredient_ids": [2]}`,
+			mergeErr:       models.ErrQuantityOverflow,
+			expectMerge:    true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Ingredient Not Found",
+			body:           `{"ingredient_ids": [42]}`,
+			mergeErr:       models.ErrNoRecord,
+			expectMerge:    true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Malformed Body",
+			body:           `{"ingredient_ids": "2"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectMerge {
+				mockIngredients.EXPECT().Merge(1, gomock.Any()).Return(tc.recipeIDs, tc.mergeErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				merged := newPatchableRecipe()
+				merged.Ingredients[0].Name = "tomato"
+				mockRecipes.EXPECT().Get(1).Return(merged, nil)
+				mockIngredients.EXPECT().Get(1).Return(&models.Ingredient{ID: 1, Name: "tomato"}, nil)
+				mockIngredients.EXPECT().GetAliases(1).Return([]string{"roma tomato", "tomato"}, nil)
+			}
+
+			req := adminRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/admin/ingredients/1/merge", ts.URL), strings.NewReader(tc.body))
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Ingredient     ingredientResponse `json:"ingredient"`
+				UpdatedRecipes []int              `json:"updated_recipes"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, []string{"roma tomato", "tomato"}, body.Ingredient.Aliases)
+			assert.Equal(t, tc.recipeIDs, body.UpdatedRecipes)
+
+			// The merged recipe is reindexed with its new ingredient
+			assert.True(t, app.recommender.Contains(models.DefaultWorkspaceID, 1))
+		})
+	}
+}
+
+func TestIngredientAdministrationAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	// The ingredients and recipes mocks expect nothing, so reaching the handlers fails the test
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	app.users = mockUsers
+	app.ingredients = mocks.NewMockIngredientModelInterface(ctrl)
+	app.recipes = mocks.NewMockRecipeModelInterface(ctrl)
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		anonymous      bool
+		expectedStatus int
+	}{
+		{
+			name:           "Anonymous Merge",
+			method:         http.MethodPost,
+			path:           "/v1/admin/ingredients/1/merge",
+			body:           `{"ingredient_ids": [2]}`,
+			anonymous:      true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Merge By A User",
+			method:         http.MethodPost,
+			path:           "/v1/admin/ingredients/1/merge",
+			body:           `{"ingredient_ids": [2]}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Alias Added By A User",
+			method:         http.MethodPost,
+			path:           "/v1/admin/ingredients/1/aliases",
+			body:           `{"alias": "roma tomato"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Alias Removed By A User",
+			method:         http.MethodDelete,
+			path:           "/v1/admin/ingredients/1/aliases/tomato",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Anonymous Duplicates",
+			method:         http.MethodGet,
+			path:           "/v1/admin/duplicates",
+			anonymous:      true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Duplicates Listed By A User",
+			method:         http.MethodGet,
+			path:           "/v1/admin/duplicates",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req *http.Request
+			if tc.anonymous {
+				var err error
+				req, err = http.NewRequest(tc.method, ts.URL+tc.path, strings.NewReader(tc.body))
+				assert.NoError(t, err)
+			} else {
+				req = authenticatedRequest(t, mockUsers, tc.method, ts.URL+tc.path, strings.NewReader(tc.body))
+				mockUsers.EXPECT().Get(1).Return(newTestUser(), nil)
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/render"
+)
+
+// mealPlanFormats are the representations planned meals can be served in, the first one being the default.
+var mealPlanFormats = []string{formatJSON, formatICS}
+
+const (
+	// defaultMealPlanDays is how many days are listed when no end date is given, a week.
+	defaultMealPlanDays = 7
+	// maxMealPlanDays caps the range of dates listed at once.
+	maxMealPlanDays = 366
+	maxMealPlanNote = 255
+)
+
+var errInvalidDateRange = errors.New("invalid date range")
+
+type mealPlanInput struct {
+	Date     string `json:"date"`
+	Slot     string `json:"slot"`
+	RecipeID int    `json:"recipe_id"`
+	Portions int    `json:"portions"`
+	Note     string `json:"note"`
+}
+
+// valid checks the input, normalizing the slot and the note on the way.
+func (input *mealPlanInput) valid() bool {
+	input.Slot = strings.ToLower(strings.TrimSpace(input.Slot))
+	input.Note = strings.TrimSpace(input.Note)
+
+	if _, err := time.Parse(models.DateLayout, input.Date); err != nil {
+		return false
+	}
+	return models.ValidMealSlot(input.Slot) &&
+		input.RecipeID > 0 &&
+		input.Portions >= 0 &&
+		len([]rune(input.Note)) <= maxMealPlanNote
+}
+
+// readDateRange parses the "from" and "to" dates of a range, both included. They default to today
+// and to a week from the start date.
+func (app *application) readDateRange(from, to string) (time.Time, time.Time, error) {
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	if from != "" {
+		parsed, err := time.Parse(models.DateLayout, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidDateRange
+		}
+		start = parsed
+	}
+
+	end := start.AddDate(0, 0, defaultMealPlanDays-1)
+	if to != "" {
+		parsed, err := time.Parse(models.DateLayout, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidDateRange
+		}
+		end = parsed
+	}
+
+	if end.Before(start) || end.Sub(start) >= maxMealPlanDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errInvalidDateRange
+	}
+
+	return start, end, nil
+}
+
+// newMealPlan turns the input into a meal plan, for the portions of the recipe unless others are given.
+// It fails with models.ErrNoRecord when the recipe doesn't exist.
+func (app *application) newMealPlan(r *http.Request, input *mealPlanInput) (*models.MealPlan, error) {
+	recipe, err := app.recipesFor(r).Get(input.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &models.MealPlan{
+		Date:     input.Date,
+		Slot:     input.Slot,
+		RecipeID: recipe.ID,
+		Portions: input.Portions,
+		Note:     input.Note,
+	}
+	if plan.Portions == 0 {
+		plan.Portions = recipe.Portions
+	}
+
+	return plan, nil
+}
+
+func (app *application) createMealPlan(w http.ResponseWriter, r *http.Request) {
+	var input mealPlanInput
+
+	if err := app.readJSON(w, r, &input); err != nil || !input.valid() {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	plan, err := app.newMealPlan(r, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	id, err := app.mealPlansFor(r).Insert(plan)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	plan, err = app.mealPlansFor(r).Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/meal-plans/%d", id))
+
+	if err = app.writeJSON(w, http.StatusCreated, envelope{"meal_plan": plan}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new meal plan with id: %d", id)
+}
+
+// listMealPlans lists the meals planned in a range of dates, as JSON or as an iCalendar file.
+func (app *application) listMealPlans(w http.ResponseWriter, r *http.Request) {
+	format, err := app.negotiateFormat(r, mealPlanFormats...)
+	if err != nil {
+		app.formatError(w, err)
+		return
+	}
+
+	app.writeMealPlans(w, r, format)
+}
+
+// exportMealPlans serves "/v1/meal-plans.ics", a shortcut for asking for the iCalendar file.
+func (app *application) exportMealPlans(w http.ResponseWriter, r *http.Request) {
+	app.writeMealPlans(w, r, formatICS)
+}
+
+func (app *application) writeMealPlans(w http.ResponseWriter, r *http.Request, format string) {
+	from, to, err := app.readDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	plans, err := app.mealPlansFor(r).GetRange(from, to)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if format == formatICS {
+		err = app.writeICS(w, from, to, plans)
+	} else {
+		data := envelope{
+			"from":       from.Format(models.DateLayout),
+			"to":         to.Format(models.DateLayout),
+			"meal_plans": plans,
+		}
+		err = app.writeJSON(w, http.StatusOK, data, http.Header{"Vary": {"Accept"}})
+	}
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the meal plans from %s to %s", from.Format(models.DateLayout), to.Format(models.DateLayout))
+}
+
+// writeICS writes the planned meals as an iCalendar file to download.
+func (app *application) writeICS(w http.ResponseWriter, from, to time.Time, plans []*models.MealPlan) error {
+	var buf bytes.Buffer
+	if err := render.ICS(&buf, plans); err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("meal-plan-%s-%s.ics", from.Format(models.DateLayout), to.Format(models.DateLayout))
+	w.Header().Set("Content-Type", formatMediaTypes[formatICS]+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(http.StatusOK)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func (app *application) getMealPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	plan, err := app.mealPlansFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"meal_plan": plan}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved meal plan with id: %d", id)
+}
+
+func (app *application) updateMealPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := app.mealPlansFor(r).Get(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	var input mealPlanInput
+
+	if err := app.readJSON(w, r, &input); err != nil || !input.valid() {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	plan, err := app.newMealPlan(r, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+	plan.ID = id
+
+	if err := app.mealPlansFor(r).Update(plan); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	plan, err = app.mealPlansFor(r).Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"meal_plan": plan}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Updated meal plan with id: %d", id)
+}
+
+func (app *application) deleteMealPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.mealPlansFor(r).Delete(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Meal plan successfully deleted"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Deleted meal plan with id: %d", id)
+}
+
+// createMealPlanShoppingList generates a shopping list with the ingredients of every meal planned
+// in a range of dates, each recipe being scaled to the portions of its meal.
+func (app *application) createMealPlanShoppingList(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Name string `json:"name"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil || input.From == "" || input.To == "" {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	from, to, err := app.readDateRange(input.From, input.To)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	plans, err := app.mealPlansFor(r).GetRange(from, to)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	if len(plans) == 0 {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	requested := make([]*models.ShoppingListRecipe, 0, len(plans))
+	for _, plan := range plans {
+		requested = append(requested, &models.ShoppingListRecipe{RecipeID: plan.RecipeID, Portions: plan.Portions})
+	}
+
+	name := input.Name
+	if strings.TrimSpace(name) == "" {
+		name = fmt.Sprintf("%s %s to %s", defaultShoppingListName, input.From, input.To)
+	}
+
+	list, err := app.insertShoppingList(r, name, requested)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord), errors.Is(err, models.ErrNoPortions):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/shopping-lists/%d", list.ID))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"shopping_list": list}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new shopping list with id %d from the meal plans from %s to %s", list.ID, input.From, input.To)
+}
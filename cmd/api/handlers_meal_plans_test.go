@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newMealPlans() []*models.MealPlan {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	return []*models.MealPlan{
+		{ID: 1, Date: "2026-10-19", Slot: "breakfast", RecipeID: 1, RecipeName: "Pancakes", Portions: 2, CreatedAt: created},
+		{ID: 2, Date: "2026-10-19", Slot: "dinner", RecipeID: 2, RecipeName: "Soup, with croutons", Portions: 4, Note: "Make extra; freeze half", CreatedAt: created},
+	}
+}
+
+func TestCreateMealPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.mealPlans = mockPlans
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name             string
+		body             string
+		recipeErr        error
+		expectGet        bool
+		expectedPortions int
+		expectedStatus   int
+	}{
+		{
+			name:             "Valid Plan",
+			body:             `{"date": "2026-10-19", "slot": "Dinner", "recipe_id": 1, "portions": 6}`,
+			expectGet:        true,
+			expectedPortions: 6,
+			expectedStatus:   http.StatusCreated,
+		},
+		{
+			name:             "Recipe Portions",
+			body:             `{"date": "2026-10-19", "slot": "lunch", "recipe_id": 1}`,
+			expectGet:        true,
+			expectedPortions: 4,
+			expectedStatus:   http.StatusCreated,
+		},
+		{
+			name:           "Invalid Date",
+			body:           `{"date": "19/10/2026", "slot": "lunch", "recipe_id": 1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Slot",
+			body:           `{"date": "2026-10-19", "slot": "brunch", "recipe_id": 1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipe Not Found",
+			body:           `{"date": "2026-10-19", "slot": "lunch", "recipe_id": 1}`,
+			recipeErr:      models.ErrNoRecord,
+			expectGet:      true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockPlans.EXPECT().Insert(gomock.Any()).DoAndReturn(func(plan *models.MealPlan) (int, error) {
+					assert.Equal(t, tc.expectedPortions, plan.Portions)
+					assert.True(t, models.ValidMealSlot(plan.Slot))
+					return 1, nil
+				})
+				mockPlans.EXPECT().Get(1).Return(newMealPlans()[0], nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/meal-plans", ts.URL), "application/json", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestListMealPlans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	app.mealPlans = mockPlans
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedTo     time.Time
+		expectedStatus int
+	}{
+		{
+			name:           "Range",
+			path:           "/v1/meal-plans?from=2026-10-19&to=2026-10-20",
+			expectedTo:     from.AddDate(0, 0, 1),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Default End",
+			path:           "/v1/meal-plans?from=2026-10-19",
+			expectedTo:     from.AddDate(0, 0, 6),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "End Before Start",
+			path:           "/v1/meal-plans?from=2026-10-19&to=2026-10-18",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Range Too Long",
+			path:           "/v1/meal-plans?from=2026-01-01&to=2027-06-01",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Date",
+			path:           "/v1/meal-plans?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockPlans.EXPECT().GetRange(from, tc.expectedTo).Return(newMealPlans(), nil)
+			}
+
+			res, err := ts.Client().Get(ts.URL + tc.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				MealPlans []*models.MealPlan `json:"meal_plans"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Len(t, body.MealPlans, 2)
+		})
+	}
+}
+
+func TestExportMealPlans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	app.mealPlans = mockPlans
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//tasty-byte//meal plans//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:meal-plan-1@tasty-byte",
+		"DTSTAMP:20261001T093000Z",
+		"DTSTART:20261019T080000",
+		"DTEND:20261019T083000",
+		"SUMMARY:Breakfast: Pancakes (2 portions)",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:meal-plan-2@tasty-byte",
+		"DTSTAMP:20261001T093000Z",
+		"DTSTART:20261019T190000",
+		"DTEND:20261019T200000",
+		`SUMMARY:Dinner: Soup\, with croutons (4 portions)`,
+		`DESCRIPTION:Make extra\; freeze half`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	for _, path := range []string{"/v1/meal-plans.ics", "/v1/meal-plans"} {
+		t.Run(path, func(t *testing.T) {
+			mockPlans.EXPECT().GetRange(day, day).Return(newMealPlans(), nil)
+
+			req, err := http.NewRequest(http.MethodGet, ts.URL+path+"?from=2026-10-19&to=2026-10-19", nil)
+			assert.NoError(t, err)
+			req.Header.Set("Accept", "text/calendar")
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, "text/calendar; charset=utf-8", res.Header.Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="meal-plan-2026-10-19-2026-10-19.ics"`, res.Header.Get("Content-Disposition"))
+
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(body))
+		})
+	}
+}
+
+func TestUpdateMealPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.mealPlans = mockPlans
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	body := `{"date": "2026-10-20", "slot": "lunch", "recipe_id": 1, "portions": 3, "note": "leftovers"}`
+
+	t.Run("Valid Update", func(t *testing.T) {
+		mockPlans.EXPECT().Get(1).Return(newMealPlans()[0], nil)
+		mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+		mockPlans.EXPECT().Update(&models.MealPlan{
+			ID:       1,
+			Date:     "2026-10-20",
+			Slot:     "lunch",
+			RecipeID: 1,
+			Portions: 3,
+			Note:     "leftovers",
+		}).Return(nil)
+		mockPlans.EXPECT().Get(1).Return(newMealPlans()[0], nil)
+
+		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/v1/meal-plans/1", ts.URL), strings.NewReader(body))
+		assert.NoError(t, err)
+		res, err := ts.Client().Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockPlans.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+
+		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/v1/meal-plans/1", ts.URL), strings.NewReader(body))
+		assert.NoError(t, err)
+		res, err := ts.Client().Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestCreateMealPlanShoppingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	mockLists := mocks.NewMockShoppingListModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.mealPlans = mockPlans
+	app.shoppingLists = mockLists
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 6)
+
+	t.Run("Valid Range", func(t *testing.T) {
+		plans := newMealPlans()
+		plans[1].RecipeID = 1
+		mockPlans.EXPECT().GetRange(from, to).Return(plans, nil)
+		mockRecipes.EXPECT().Get(1).DoAndReturn(func(int) (*models.Recipe, error) {
+			return newPatchableRecipe(), nil
+		}).Times(2)
+		mockLists.EXPECT().Insert(gomock.Any()).DoAndReturn(func(list *models.ShoppingList) (int, error) {
+			assert.Equal(t, "Shopping list 2026-10-19 to 2026-10-25", list.Name)
+			assert.Len(t, list.Recipes, 2)
+			// 2 and 4 portions of a recipe for 4 make 1.5 times its ingredients
+			assert.Equal(t, "milk", list.Items[0].Name)
+			assert.Equal(t, 450.0, list.Items[0].Quantity)
+			return 1, nil
+		})
+		mockLists.EXPECT().Get(1).Return(newShoppingList(), nil)
+
+		res, err := ts.Client().Post(fmt.Sprintf("%s/v1/meal-plans/shopping-list", ts.URL), "application/json",
+			strings.NewReader(`{"from": "2026-10-19", "to": "2026-10-25"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "v1/shopping-lists/1", res.Header.Get("Location"))
+	})
+
+	t.Run("Nothing Planned", func(t *testing.T) {
+		mockPlans.EXPECT().GetRange(from, to).Return([]*models.MealPlan{}, nil)
+
+		res, err := ts.Client().Post(fmt.Sprintf("%s/v1/meal-plans/shopping-list", ts.URL), "application/json",
+			strings.NewReader(`{"from": "2026-10-19", "to": "2026-10-25"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	})
+
+	t.Run("Missing Range", func(t *testing.T) {
+		res, err := ts.Client().Post(fmt.Sprintf("%s/v1/meal-plans/shopping-list", ts.URL), "application/json",
+			strings.NewReader(`{"from": "2026-10-19"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
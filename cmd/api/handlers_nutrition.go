@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/nutrition"
+)
+
+// getRecipeNutrition reports the calories and macros of a recipe, in total and per portion,
+// flagging the ingredients whose amounts couldn't be turned into grams.
+func (app *application) getRecipeNutrition(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	facts, err := app.nutrition.GetByNames(nutrition.LookupNames(recipe.Ingredients))
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"nutrition": nutrition.Calculate(recipe, facts)}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Calculated nutrition of recipe with id: %d", id)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/nutrition"
+)
+
+func TestGetRecipeNutrition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockNutrition := mocks.NewMockNutritionModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.nutrition = mockNutrition
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	density := 0.5
+	facts := map[string]*models.NutritionFacts{
+		"test ingredient": {
+			Name:    "test ingredient",
+			Per100g: models.Nutrients{Calories: 100},
+			Density: &density,
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		id             string
+		recipeErr      error
+		expectFacts    bool
+		factsErr       error
+		expectedStatus int
+	}{
+		{
+			name:           "Valid ID",
+			id:             "1",
+			expectFacts:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			id:             "abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipe Not Found",
+			id:             "1",
+			recipeErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Nutrition Fetch Failed",
+			id:             "1",
+			expectFacts:    true,
+			factsErr:       fmt.Errorf("server error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.id == "1" {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			}
+			if tc.expectFacts {
+				mockNutrition.EXPECT().GetByNames(nutrition.LookupNames(testRecipe.Ingredients)).Return(facts, tc.factsErr)
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/%s/nutrition", ts.URL, tc.id))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Nutrition nutrition.RecipeNutrition `json:"nutrition"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.True(t, body.Nutrition.Complete)
+			// 1 cup is about 236.6 ml, so 118.3 g at a density of 0.5
+			assert.Equal(t, 118.3, body.Nutrition.Total.Calories)
+			assert.Equal(t, 29.6, body.Nutrition.PerPortion.Calories)
+		})
+	}
+}
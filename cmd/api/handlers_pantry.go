@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/pantry"
+)
+
+func (app *application) getPantry(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	items, err := app.pantry.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"pantry": items}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the pantry of user %d", userID)
+}
+
+// setPantryItem stores how much of an ingredient the user has, a quantity of zero meaning some,
+// without keeping track of how much.
+func (app *application) setPantryItem(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	var input struct {
+		Name     string  `json:"name"`
+		Quantity float64 `json:"quantity"`
+		Unit     string  `json:"unit"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	item := &models.PantryItem{
+		Name:     strings.TrimSpace(input.Name),
+		Quantity: input.Quantity,
+		Unit:     strings.TrimSpace(input.Unit),
+	}
+	if item.Name == "" || item.Quantity < 0 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.pantry.Set(userID, item); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	items, err := app.pantry.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"pantry": items}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Set ingredient %d in the pantry of user %d", item.IngredientID, userID)
+}
+
+func (app *application) deletePantryItem(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	ingredientID, err := app.readIDParam(r, "ingredient")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.pantry.Delete(userID, ingredientID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Ingredient successfully removed from the pantry"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Removed ingredient %d from the pantry of user %d", ingredientID, userID)
+}
+
+// listCookableRecipes ranks the recipes by how much of them the pantry of the user covers,
+// optionally leaving out the ones missing more than "?max_missing=" ingredients.
+func (app *application) listCookableRecipes(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	maxMissing := -1
+	if value := r.URL.Query().Get("max_missing"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+		maxMissing = parsed
+	}
+
+	items, err := app.pantry.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	recipes, err := app.recipesFor(r).GetAll()
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"cookable": pantry.Rank(recipes, items, maxMissing)}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Ranked the recipes cookable by user %d", userID)
+}
+
+// cookRecipe marks a recipe as cooked, taking its ingredients, scaled to "?portions=" if given,
+// out of the pantry of the user and adding the recipe to their cooking history, at once so that a retry
+// doesn't take them out twice.
+func (app *application) cookRecipe(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var portions int
+	if value := r.URL.Query().Get("portions"); value != "" {
+		portions, err = strconv.Atoi(value)
+		if err != nil || portions < 1 {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if portions > 0 {
+		if err := recipe.Scale(portions); err != nil {
+			app.clientError(w, http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	if err := app.pantry.Cook(userID, id, recipe.Ingredients); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	items, err := app.pantry.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"pantry": items}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("User %d cooked recipe with id: %d", userID, id)
+}
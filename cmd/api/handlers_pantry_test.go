@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/pantry"
+)
+
+func newPantry() []*models.PantryItem {
+	return []*models.PantryItem{
+		{IngredientID: 11, Name: "flour", Quantity: 1, Unit: "kg"},
+		{IngredientID: 12, Name: "milk", Quantity: 200, Unit: "ml"},
+	}
+}
+
+// authenticatedRequest builds a request made by the user with id 1.
+func authenticatedRequest(t *testing.T, mockUsers *mocks.MockUserModelInterface, method, url string, body io.Reader) *http.Request {
+	req, err := http.NewRequest(method, url, body)
+	assert.NoError(t, err)
+	req.SetBasicAuth("alice@example.com", "correct horse")
+	mockUsers.EXPECT().Authenticate("alice@example.com", "correct horse").Return(1, nil)
+	return req
+}
+
+// adminRequest returns a request authenticated as alice, user 1, who is an administrator.
+func adminRequest(t *testing.T, mockUsers *mocks.MockUserModelInterface, method, url string, body io.Reader) *http.Request {
+	req := authenticatedRequest(t, mockUsers, method, url, body)
+	mockUsers.EXPECT().Get(1).Return(&models.User{ID: 1, Name: "Alice", Admin: true}, nil)
+	return req
+}
+
+func TestSetPantryItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockPantry := mocks.NewMockPantryModelInterface(ctrl)
+	app.users = mockUsers
+	app.pantry = mockPantry
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Item",
+			body:           `{"name": " flour ", "quantity": 1, "unit": "kg"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Untracked Quantity",
+			body:           `{"name": "salt"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Negative Quantity",
+			body:           `{"name": "flour", "quantity": -1, "unit": "kg"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Name",
+			body:           `{"quantity": 1, "unit": "kg"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockPantry.EXPECT().Set(1, gomock.Any()).DoAndReturn(func(userID int, item *models.PantryItem) error {
+					assert.Equal(t, strings.TrimSpace(item.Name), item.Name)
+					return nil
+				})
+				mockPantry.EXPECT().GetByUserID(1).Return(newPantry(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/me/pantry", ts.URL), strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		res, err := ts.Client().Post(fmt.Sprintf("%s/v1/me/pantry", ts.URL), "application/json", strings.NewReader(`{"name": "flour"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
+
+func TestListCookableRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockPantry := mocks.NewMockPantryModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.users = mockUsers
+	app.pantry = mockPantry
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name            string
+		query           string
+		expectedMatches int
+		expectedStatus  int
+	}{
+		{
+			name:            "All Recipes",
+			expectedMatches: 2,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "Nothing Missing",
+			query:           "?max_missing=0",
+			expectedMatches: 0,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:           "Invalid Max Missing",
+			query:          "?max_missing=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockPantry.EXPECT().GetByUserID(1).Return(newPantry(), nil)
+				mockRecipes.EXPECT().GetAll().Return([]*models.Recipe{newPatchableRecipe(), testRecipe}, nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodGet, fmt.Sprintf("%s/v1/recipes/cookable%s", ts.URL, tc.query), nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body struct {
+					Cookable []*pantry.Match `json:"cookable"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Len(t, body.Cookable, tc.expectedMatches)
+				if len(body.Cookable) > 0 {
+					// The pancakes only lack part of the milk, the test recipe lacks its only ingredient.
+					assert.Equal(t, "Pancakes", body.Cookable[0].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestCookRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockPantry := mocks.NewMockPantryModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.users = mockUsers
+	app.pantry = mockPantry
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		query          string
+		recipeErr      error
+		expectGet      bool
+		expectedFlour  float64
+		expectedStatus int
+	}{
+		{
+			name:           "Recipe Portions",
+			expectGet:      true,
+			expectedFlour:  200,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Scaled Portions",
+			query:          "?portions=2",
+			expectGet:      true,
+			expectedFlour:  100,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Portions",
+			query:          "?portions=zero",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipe Not Found",
+			recipeErr:      models.ErrNoRecord,
+			expectGet:      true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), tc.recipeErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockPantry.EXPECT().Cook(1, 1, gomock.Any()).DoAndReturn(func(userID, recipeID int, ingredients []*models.FullIngredient) error {
+					assert.Equal(t, tc.expectedFlour, ingredients[0].Quantity)
+					return nil
+				})
+				mockPantry.EXPECT().GetByUserID(1).Return(newPantry(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/recipes/1/cooked%s", ts.URL, tc.query), nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
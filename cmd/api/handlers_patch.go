@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/pkg/jsonpatch"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// recipeDocument is the JSON document that PATCH requests operate on. Ingredients and tags
+// are keyed by name so that patches can address a single one of them, e.g.
+// {"op": "remove", "path": "/tags/spicy"} or {"ingredients": {"sugar": {"quantity": 2, "unit": "tbsp"}}}.
+// Steps are kept as an ordered array, e.g. {"op": "replace", "path": "/steps/0/duration", "value": "5m"}.
+type recipeDocument struct {
+	Name            string                               `json:"name"`
+	Description     string                               `json:"description"`
+	Instructions    string                               `json:"instructions"`
+	PreparationTime string                               `json:"preparation_time"`
+	CookingTime     string                               `json:"cooking_time"`
+	Portions        int                                  `json:"portions"`
+	Ingredients     map[string]*recipeDocumentIngredient `json:"ingredients"`
+	Tags            map[string]bool                      `json:"tags"`
+	Steps           []*recipeDocumentStep                `json:"steps"`
+}
+
+type recipeDocumentIngredient struct {
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+}
+
+type recipeDocumentStep struct {
+	Text          string `json:"text"`
+	Duration      string `json:"duration"`
+	IngredientIDs []int  `json:"ingredient_ids"`
+}
+
+func newRecipeDocument(recipe *models.Recipe) *recipeDocument {
+	doc := &recipeDocument{
+		Name:            recipe.Name,
+		Description:     recipe.Description,
+		Instructions:    recipe.Instructions,
+		PreparationTime: recipe.PreparationTime,
+		CookingTime:     recipe.CookingTime,
+		Portions:        recipe.Portions,
+		Ingredients:     make(map[string]*recipeDocumentIngredient, len(recipe.Ingredients)),
+		Tags:            make(map[string]bool, len(recipe.Tags)),
+		Steps:           make([]*recipeDocumentStep, 0, len(recipe.Steps)),
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		doc.Ingredients[ingredient.Name] = &recipeDocumentIngredient{
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		}
+	}
+
+	for _, tag := range recipe.Tags {
+		doc.Tags[tag.Name] = true
+	}
+
+	for _, step := range recipe.Steps {
+		doc.Steps = append(doc.Steps, &recipeDocumentStep{
+			Text:          step.Text,
+			Duration:      step.Duration,
+			IngredientIDs: step.IngredientIDs,
+		})
+	}
+
+	return doc
+}
+
+// applyTo copies the document onto the recipe. Ingredients and tags that were already on
+// the recipe keep their position, new ones are appended in alphabetical order. When only one of
+// the instructions and the steps was patched, the other one is derived from it again on update.
+func (doc *recipeDocument) applyTo(recipe *models.Recipe) {
+	original := newRecipeDocument(recipe)
+	instructionsChanged := doc.Instructions != original.Instructions
+	stepsChanged := !reflect.DeepEqual(doc.Steps, original.Steps)
+
+	recipe.Name = doc.Name
+	recipe.Description = doc.Description
+	recipe.Instructions = doc.Instructions
+	recipe.PreparationTime = doc.PreparationTime
+	recipe.CookingTime = doc.CookingTime
+	recipe.Portions = doc.Portions
+
+	ingredients := make([]*models.FullIngredient, 0, len(doc.Ingredients))
+	seen := make(map[string]bool, len(doc.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		if amount, exists := doc.Ingredients[ingredient.Name]; exists && amount != nil {
+			ingredient.Quantity = amount.Quantity
+			ingredient.Unit = amount.Unit
+			ingredients = append(ingredients, ingredient)
+			seen[ingredient.Name] = true
+		}
+	}
+	for _, name := range sortedKeys(doc.Ingredients) {
+		if amount := doc.Ingredients[name]; !seen[name] && amount != nil {
+			ingredients = append(ingredients, &models.FullIngredient{
+				Ingredient: &models.Ingredient{Name: name},
+				Quantity:   amount.Quantity,
+				Unit:       amount.Unit,
+			})
+		}
+	}
+	recipe.Ingredients = ingredients
+
+	tags := make([]*models.Tag, 0, len(doc.Tags))
+	seen = make(map[string]bool, len(doc.Tags))
+	for _, tag := range recipe.Tags {
+		if doc.Tags[tag.Name] {
+			tags = append(tags, tag)
+			seen[tag.Name] = true
+		}
+	}
+	for _, name := range sortedKeys(doc.Tags) {
+		if doc.Tags[name] && !seen[name] {
+			tags = append(tags, &models.Tag{Name: name})
+		}
+	}
+	recipe.Tags = tags
+
+	steps := make([]*models.Step, 0, len(doc.Steps))
+	for _, step := range doc.Steps {
+		if step != nil {
+			steps = append(steps, &models.Step{
+				Text:          step.Text,
+				Duration:      step.Duration,
+				IngredientIDs: step.IngredientIDs,
+			})
+		}
+	}
+	recipe.Steps = steps
+
+	switch {
+	case stepsChanged && !instructionsChanged:
+		recipe.Instructions = ""
+	case instructionsChanged && !stepsChanged:
+		recipe.Steps = nil
+	}
+}
+
+func (app *application) patchRecipe(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != mergePatchContentType && mediaType != jsonPatchContentType) {
+		w.Header().Set("Accept-Patch", mergePatchContentType+", "+jsonPatchContentType)
+		app.clientError(w, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	// Work on the generic JSON representation of the document, which is what both patch formats are defined against
+	var doc any
+	js, err := json.Marshal(newRecipeDocument(recipe))
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	if err := json.Unmarshal(js, &doc); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	switch mediaType {
+	case mergePatchContentType:
+		var patch any
+		if err := app.readJSON(w, r, &patch); err != nil {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+		doc = jsonpatch.MergePatch(doc, patch)
+	case jsonPatchContentType:
+		var patch jsonpatch.Patch
+		if err := app.readJSON(w, r, &patch); err != nil {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+		doc, err = patch.Apply(doc)
+		if err != nil {
+			switch {
+			case errors.Is(err, jsonpatch.ErrTestFailed):
+				app.clientError(w, http.StatusConflict)
+			case errors.Is(err, jsonpatch.ErrPathNotFound):
+				app.clientError(w, http.StatusUnprocessableEntity)
+			default:
+				app.clientError(w, http.StatusBadRequest)
+			}
+			return
+		}
+	}
+
+	// Decode the patched document strictly, so that patches adding unknown fields or values of the wrong type are rejected
+	js, err = json.Marshal(doc)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	patched := &recipeDocument{}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(patched); err != nil {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if patched.Name == "" {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	for name := range patched.Ingredients {
+		if name == "" {
+			app.clientError(w, http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	for name := range patched.Tags {
+		if name == "" {
+			app.clientError(w, http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	patched.applyTo(recipe)
+
+	if !validRecipe(recipe) {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := app.recipesFor(r).Update(recipe); err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidStepIngredient):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err = app.writeJSON(w, http.StatusOK, envelope{"recipe": recipe}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Patched recipe with id: %d", id)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newPatchableRecipe() *models.Recipe {
+	return &models.Recipe{
+		ID:              1,
+		Name:            "Pancakes",
+		Instructions:    "Mix and fry",
+		PreparationTime: "10m",
+		CookingTime:     "15m",
+		Portions:        4,
+		Ingredients: []*models.FullIngredient{
+			{Ingredient: &models.Ingredient{ID: 1, Name: "flour"}, Quantity: 200, Unit: "g"},
+			{Ingredient: &models.Ingredient{ID: 2, Name: "milk"}, Quantity: 300, Unit: "ml"},
+		},
+		Tags: []*models.Tag{
+			{ID: 1, Name: "breakfast"},
+			{ID: 2, Name: "sweet"},
+		},
+	}
+}
+
+func TestPatchRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name                string
+		contentType         string
+		body                string
+		expectUpdate        bool
+		expectedStatus      int
+		expectedName        string
+		expectedIngredients map[string]float64
+		expectedTags        []string
+	}{
+		{
+			name:                "Merge Patch Updates One Ingredient And Removes One Tag",
+			contentType:         "application/merge-patch+json",
+			body:                `{"name": "Fluffy Pancakes", "ingredients": {"milk": {"quantity": 250, "unit": "ml"}}, "tags": {"sweet": null}}`,
+			expectUpdate:        true,
+			expectedStatus:      http.StatusOK,
+			expectedName:        "Fluffy Pancakes",
+			expectedIngredients: map[string]float64{"flour": 200, "milk": 250},
+			expectedTags:        []string{"breakfast"},
+		},
+		{
+			name:                "JSON Patch Adds An Ingredient And A Tag",
+			contentType:         "application/json-patch+json",
+			body:                `[{"op": "add", "path": "/ingredients/sugar", "value": {"quantity": 2, "unit": "tbsp"}}, {"op": "add", "path": "/tags/quick", "value": true}]`,
+			expectUpdate:        true,
+			expectedStatus:      http.StatusOK,
+			expectedName:        "Pancakes",
+			expectedIngredients: map[string]float64{"flour": 200, "milk": 300, "sugar": 2},
+			expectedTags:        []string{"breakfast", "sweet", "quick"},
+		},
+		{
+			name:                "JSON Patch Removes An Ingredient",
+			contentType:         "application/json-patch+json",
+			body:                `[{"op": "test", "path": "/ingredients/milk/unit", "value": "ml"}, {"op": "remove", "path": "/ingredients/milk"}]`,
+			expectUpdate:        true,
+			expectedStatus:      http.StatusOK,
+			expectedName:        "Pancakes",
+			expectedIngredients: map[string]float64{"flour": 200},
+			expectedTags:        []string{"breakfast", "sweet"},
+		},
+		{
+			name:           "Failed JSON Patch Test",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op": "test", "path": "/portions", "value": 2}]`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "JSON Patch On Missing Ingredient",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op": "remove", "path": "/ingredients/eggs"}]`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Merge Patch With Unknown Field",
+			contentType:    "application/merge-patch+json",
+			body:           `{"colour": "golden"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Unsupported Content Type",
+			contentType:    "application/json",
+			body:           `{"name": "Crepes"}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.contentType != "application/json" {
+				mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+			}
+			if tc.expectUpdate {
+				mockRecipes.EXPECT().Update(gomock.Any()).Return(nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/v1/recipes/1", ts.URL), bytes.NewBufferString(tc.body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", tc.contentType)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Recipe *models.Recipe `json:"recipe"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, tc.expectedName, body.Recipe.Name)
+
+			ingredients := make(map[string]float64)
+			for _, ingredient := range body.Recipe.Ingredients {
+				ingredients[ingredient.Name] = ingredient.Quantity
+			}
+			assert.Equal(t, tc.expectedIngredients, ingredients)
+
+			var tags []string
+			for _, tag := range body.Recipe.Tags {
+				tags = append(tags, tag.Name)
+			}
+			assert.Equal(t, tc.expectedTags, tags)
+		})
+	}
+}
+
+func TestUpdateRecipeIsFullReplacement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectUpdate   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Omitted Fields Are Cleared",
+			body:           `{"name": "Crepes"}`,
+			expectUpdate:   true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing Name",
+			body:           `{"description": "Thin pancakes"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+			if tc.expectUpdate {
+				mockRecipes.EXPECT().Update(&models.Recipe{ID: 1, Name: "Crepes"}).Return(nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/v1/recipes/1", ts.URL), bytes.NewBufferString(tc.body))
+			assert.NoError(t, err)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/vladComan0/tasty-byte/internal/recommend"
+)
+
+const (
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 100
+)
+
+// getRecipeRecommendations recommends the recipes whose ingredients and tags are closest to the
+// given recipe, up to "?limit=" of them.
+func (app *application) getRecipeRecommendations(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	limit, err := app.readLimit(r, defaultRecommendationLimit, maxRecommendationLimit)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// The index holds every recipe that isn't in the trash
+	workspaceID := app.contextGetWorkspaceID(r)
+	if !app.recommender.Contains(workspaceID, id) {
+		app.clientError(w, http.StatusNotFound)
+		return
+	}
+
+	recommendations := app.recommender.Similar(workspaceID, id, limit)
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"recommendations": recommendations}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Recommended %d recipes for recipe with id: %d", len(recommendations), id)
+}
+
+// getUserRecommendations recommends recipes to the user, up to "?limit=" of them, based on their
+// favorites, the ratings they gave and the recipes they cooked. The recipes the user already knows
+// are left out, and a user who did none of these gets no recommendations. Only recipes of the workspace
+// are recommended, whichever workspaces the user got to know recipes in.
+func (app *application) getUserRecommendations(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUserID(r)
+
+	limit, err := app.readLimit(r, defaultRecommendationLimit, maxRecommendationLimit)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	preferences := recommend.Preferences{}
+
+	favorites, err := app.favoritesFor(r).GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	for _, favorite := range favorites {
+		preferences.Favorite(favorite.RecipeID)
+	}
+
+	reviews, err := app.reviews.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	for _, review := range reviews {
+		preferences.Rate(review.RecipeID, review.Rating)
+	}
+
+	cooked, err := app.history.GetByUserID(userID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	for _, recipe := range cooked {
+		preferences.Cook(recipe.RecipeID, recipe.Times)
+	}
+
+	recommendations := app.recommender.Recommend(app.contextGetWorkspaceID(r), preferences, limit)
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"recommendations": recommendations}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Recommended %d recipes to user %d", len(recommendations), userID)
+}
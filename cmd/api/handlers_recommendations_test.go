@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/recommend"
+)
+
+// newRecommender indexes the catalogue, along with a salad that shares its tomatoes with the soup.
+func newRecommender() *recommend.Index {
+	index := recommend.NewIndex()
+	for _, recipe := range newCatalogue() {
+		index.Put(recipe)
+	}
+	index.Put(&models.Recipe{
+		ID:   4,
+		Name: "Tomato salad",
+		Ingredients: []*models.FullIngredient{
+			{Ingredient: &models.Ingredient{ID: 4, Name: "tomatoes"}, Quantity: 500, Unit: "g"},
+			{Ingredient: &models.Ingredient{ID: 5, Name: "basil"}, Quantity: 1, Unit: "bunch"},
+		},
+	})
+	return index
+}
+
+func readRecommendedIDs(t *testing.T, res *http.Response) []int {
+	var response struct {
+		Recommendations []*recommend.Recommendation `json:"recommendations"`
+	}
+	err := json.NewDecoder(res.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	ids := []int{}
+	for _, recommendation := range response.Recommendations {
+		ids = append(ids, recommendation.RecipeID)
+	}
+	return ids
+}
+
+func TestGetRecipeRecommendations(t *testing.T) {
+	app := newTestApplication()
+	app.recommender = newRecommender()
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedIDs    []int
+		expectedStatus int
+	}{
+		{
+			name:           "Similar Ingredients And Tags",
+			url:            "/v1/recipes/1/recommendations",
+			expectedIDs:    []int{2},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Limited",
+			url:            "/v1/recipes/3/recommendations?limit=1",
+			expectedIDs:    []int{4},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Limit",
+			url:            "/v1/recipes/1/recommendations?limit=0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipe Not Found",
+			url:            "/v1/recipes/42/recommendations",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.Client().Get(ts.URL + tc.url)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedIDs, readRecommendedIDs(t, res))
+			}
+		})
+	}
+}
+
+func TestGetUserRecommendations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+	app.recommender = newRecommender()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	mockReviews := mocks.NewMockReviewModelInterface(ctrl)
+	mockHistory := mocks.NewMockCookingHistoryModelInterface(ctrl)
+	app.users = mockUsers
+	app.favorites = mockFavorites
+	app.reviews = mockReviews
+	app.history = mockHistory
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		favorites      []*models.Favorite
+		reviews        []*models.Review
+		cooked         []*models.CookedRecipe
+		expectedIDs    []int
+		expectedStatus int
+	}{
+		{
+			name:           "Favorites And Ratings",
+			favorites:      []*models.Favorite{{RecipeID: 3, Name: "Tomato soup"}},
+			reviews:        []*models.Review{newReview()},
+			cooked:         []*models.CookedRecipe{},
+			expectedIDs:    []int{2, 4},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Cooking History",
+			favorites:      []*models.Favorite{},
+			reviews:        []*models.Review{},
+			cooked:         []*models.CookedRecipe{{RecipeID: 4, Name: "Tomato salad", Times: 2}},
+			expectedIDs:    []int{3},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Disliked Recipes",
+			favorites:      []*models.Favorite{},
+			reviews:        []*models.Review{{RecipeID: 1, Rating: models.MinRating}},
+			cooked:         []*models.CookedRecipe{},
+			expectedIDs:    []int{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "No History",
+			favorites:      []*models.Favorite{},
+			reviews:        []*models.Review{},
+			cooked:         []*models.CookedRecipe{},
+			expectedIDs:    []int{},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFavorites.EXPECT().GetByUserID(1).Return(tc.favorites, nil)
+			mockReviews.EXPECT().GetByUserID(1).Return(tc.reviews, nil)
+			mockHistory.EXPECT().GetByUserID(1).Return(tc.cooked, nil)
+
+			req := authenticatedRequest(t, mockUsers, http.MethodGet, fmt.Sprintf("%s/v1/me/recommendations", ts.URL), nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			assert.ElementsMatch(t, tc.expectedIDs, readRecommendedIDs(t, res))
+		})
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		res, err := ts.Client().Get(ts.URL + "/v1/me/recommendations")
+		assert.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
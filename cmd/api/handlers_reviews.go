@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+const maxCommentLength = 5000
+
+type reviewInput struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+func (input *reviewInput) valid() bool {
+	input.Comment = strings.TrimSpace(input.Comment)
+	return input.Rating >= models.MinRating && input.Rating <= models.MaxRating &&
+		utf8.RuneCountInString(input.Comment) <= maxCommentLength
+}
+
+func (app *application) listReviews(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	reviews, err := app.reviews.GetByRecipeID(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	data := envelope{
+		"rating_average": recipe.RatingAverage,
+		"rating_count":   recipe.RatingCount,
+		"reviews":        reviews,
+	}
+	if err := app.writeJSON(w, http.StatusOK, data, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the reviews of recipe with id: %d", id)
+}
+
+// createReview adds the review of the authenticated user, who can only review a recipe once.
+func (app *application) createReview(w http.ResponseWriter, r *http.Request) {
+	recipe, ok := app.readRecipe(w, r, "id")
+	if !ok {
+		return
+	}
+	id := recipe.ID
+
+	var input reviewInput
+	if err := app.readJSON(w, r, &input); err != nil || !input.valid() {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	review := &models.Review{
+		RecipeID: id,
+		UserID:   app.contextGetUserID(r),
+		Rating:   input.Rating,
+		Comment:  input.Comment,
+	}
+
+	reviewID, err := app.reviews.Insert(review)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrDuplicateReview):
+			app.clientError(w, http.StatusConflict)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	review, err = app.reviews.Get(reviewID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/recipes/%d/reviews/%d", id, reviewID))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"review": review}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new review with id: %d", reviewID)
+}
+
+// readOwnReview looks up the review named in the URL, answering the request itself when the review
+// doesn't exist, its recipe isn't in the workspace or it belongs to somebody else than the authenticated user.
+func (app *application) readOwnReview(w http.ResponseWriter, r *http.Request) (*models.Review, bool) {
+	recipe, ok := app.readRecipe(w, r, "id")
+	if !ok {
+		return nil, false
+	}
+	recipeID := recipe.ID
+
+	reviewID, err := app.readIDParam(r, "review")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return nil, false
+	}
+
+	review, err := app.reviews.Get(reviewID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return nil, false
+	}
+
+	if review.RecipeID != recipeID {
+		app.clientError(w, http.StatusNotFound)
+		return nil, false
+	}
+	if review.UserID != app.contextGetUserID(r) {
+		app.clientError(w, http.StatusForbidden)
+		return nil, false
+	}
+
+	return review, true
+}
+
+func (app *application) updateReview(w http.ResponseWriter, r *http.Request) {
+	review, ok := app.readOwnReview(w, r)
+	if !ok {
+		return
+	}
+
+	var input reviewInput
+	if err := app.readJSON(w, r, &input); err != nil || !input.valid() {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	review.Rating = input.Rating
+	review.Comment = input.Comment
+
+	if err := app.reviews.Update(review); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	review, err := app.reviews.Get(review.ID)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"review": review}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Updated review with id: %d", review.ID)
+}
+
+func (app *application) deleteReview(w http.ResponseWriter, r *http.Request) {
+	review, ok := app.readOwnReview(w, r)
+	if !ok {
+		return
+	}
+
+	if err := app.reviews.Delete(review.ID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Review successfully deleted"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Deleted review with id: %d", review.ID)
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newReview() *models.Review {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	return &models.Review{
+		ID:        1,
+		RecipeID:  1,
+		UserID:    1,
+		UserName:  "Alice",
+		Rating:    5,
+		Comment:   "Fluffy!",
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+}
+
+func TestCreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockReviews := mocks.NewMockReviewModelInterface(ctrl)
+	app.users = mockUsers
+	app.recipes = mockRecipes
+	app.reviews = mockReviews
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		recipeErr      error
+		insertErr      error
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Review",
+			body:           `{"rating": 5, "comment": " Fluffy! "}`,
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Already Reviewed",
+			body:           `{"rating": 4}`,
+			insertErr:      models.ErrDuplicateReview,
+			expectInsert:   true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Recipe Not In Workspace",
+			body:           `{"rating": 4}`,
+			recipeErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Recipe Trashed Meanwhile",
+			body:           `{"rating": 4}`,
+			insertErr:      models.ErrNoRecord,
+			expectInsert:   true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Rating Too High",
+			body:           `{"rating": 6}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Rating",
+			body:           `{"comment": "Fluffy!"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			if tc.expectInsert {
+				mockReviews.EXPECT().Insert(gomock.Any()).DoAndReturn(func(review *models.Review) (int, error) {
+					assert.Equal(t, 1, review.RecipeID)
+					assert.Equal(t, 1, review.UserID)
+					assert.Equal(t, strings.TrimSpace(review.Comment), review.Comment)
+					return 1, tc.insertErr
+				})
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockReviews.EXPECT().Get(1).Return(newReview(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, fmt.Sprintf("%s/v1/recipes/1/reviews", ts.URL), strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/1/reviews", ts.URL), "application/json", strings.NewReader(`{"rating": 5}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
+
+func TestUpdateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockReviews := mocks.NewMockReviewModelInterface(ctrl)
+	app.users = mockUsers
+	app.recipes = mockRecipes
+	app.reviews = mockReviews
+
+	mockRecipes.EXPECT().Get(gomock.Any()).DoAndReturn(func(id int) (*models.Recipe, error) {
+		return &models.Recipe{ID: id, Name: "Pancakes"}, nil
+	}).AnyTimes()
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		path           string
+		authorID       int
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "Own Review",
+			path:           "/v1/recipes/1/reviews/1",
+			authorID:       1,
+			body:           `{"rating": 3, "comment": "A bit dry"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Someone Else's Review",
+			path:           "/v1/recipes/1/reviews/1",
+			authorID:       2,
+			body:           `{"rating": 1}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Review Of Another Recipe",
+			path:           "/v1/recipes/2/reviews/1",
+			authorID:       1,
+			body:           `{"rating": 3}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Rating",
+			path:           "/v1/recipes/1/reviews/1",
+			authorID:       1,
+			body:           `{"rating": 0}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			review := newReview()
+			review.UserID = tc.authorID
+			mockReviews.EXPECT().Get(1).Return(review, nil)
+
+			if tc.expectedStatus == http.StatusOK {
+				mockReviews.EXPECT().Update(gomock.Any()).DoAndReturn(func(review *models.Review) error {
+					assert.Equal(t, 3, review.Rating)
+					assert.Equal(t, "A bit dry", review.Comment)
+					return nil
+				})
+				mockReviews.EXPECT().Get(1).Return(newReview(), nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPut, ts.URL+tc.path, strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestDeleteReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockReviews := mocks.NewMockReviewModelInterface(ctrl)
+	app.users = mockUsers
+	app.recipes = mockRecipes
+	app.reviews = mockReviews
+
+	mockRecipes.EXPECT().Get(gomock.Any()).DoAndReturn(func(id int) (*models.Recipe, error) {
+		return &models.Recipe{ID: id, Name: "Pancakes"}, nil
+	}).AnyTimes()
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		authorID       int
+		reviewErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "Own Review",
+			authorID:       1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Someone Else's Review",
+			authorID:       2,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Review Not Found",
+			reviewErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			review := newReview()
+			review.UserID = tc.authorID
+			mockReviews.EXPECT().Get(1).Return(review, tc.reviewErr)
+
+			if tc.expectedStatus == http.StatusOK {
+				mockReviews.EXPECT().Delete(1).Return(nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodDelete, fmt.Sprintf("%s/v1/recipes/1/reviews/1", ts.URL), nil)
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestListRecipesByRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	mockFacets.EXPECT().Count(gomock.Any(), gomock.Any()).Return(&models.Facets{}, nil).AnyTimes()
+	app.facets = mockFacets
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	// The database sorts the recipes, the page coming back in its order
+	page := []*models.Recipe{
+		{ID: 3, Name: "Best", RatingAverage: 4.5, RatingCount: 2},
+		{ID: 4, Name: "Good and popular", RatingAverage: 4, RatingCount: 10},
+	}
+
+	testCases := []struct {
+		name           string
+		sort           string
+		expectedStatus int
+	}{
+		{
+			name:           "Best First",
+			sort:           models.SortRatingDesc,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Worst First",
+			sort:           models.SortRating,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Order",
+			sort:           "-spiciness",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockRecipes.EXPECT().List(gomock.Any()).DoAndReturn(func(filter *models.RecipeFilter) ([]*models.Recipe, int, error) {
+					assert.Equal(t, tc.sort, filter.Sort)
+					return page, 4, nil
+				})
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes?sort=%s", ts.URL, tc.sort))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body struct {
+					Recipes []*models.Recipe `json:"recipes"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+				ids := make([]int, 0, len(body.Recipes))
+				for _, recipe := range body.Recipes {
+					ids = append(ids, recipe.ID)
+				}
+				assert.Equal(t, []int{3, 4}, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func (app *application) listRecipeRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := app.recipesFor(r).Get(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	revisions, err := app.revisions.GetAll(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"revisions": revisions}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved revisions for recipe with id: %d", id)
+}
+
+func (app *application) getRecipeRevision(w http.ResponseWriter, r *http.Request) {
+	recipe, ok := app.readRecipe(w, r, "id")
+	if !ok {
+		return
+	}
+	id := recipe.ID
+
+	rev, err := app.readIDParam(r, "rev")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	revision, err := app.revisions.Get(id, rev)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"revision": revision}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved revision %d for recipe with id: %d", rev, id)
+}
+
+// diffRecipeRevision compares a revision against another revision given through the
+// "against" query parameter, or against the current version of the recipe if it is omitted.
+func (app *application) diffRecipeRevision(w http.ResponseWriter, r *http.Request) {
+	recipe, ok := app.readRecipe(w, r, "id")
+	if !ok {
+		return
+	}
+	id := recipe.ID
+
+	rev, err := app.readIDParam(r, "rev")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	revision, err := app.revisions.Get(id, rev)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	against := recipe
+	if value := r.URL.Query().Get("against"); value != "" {
+		againstRev, err := strconv.Atoi(value)
+		if err != nil || againstRev < 1 {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+
+		againstRevision, err := app.revisions.Get(id, againstRev)
+		if err != nil {
+			switch {
+			case errors.Is(err, models.ErrNoRecord):
+				app.clientError(w, http.StatusNotFound)
+			default:
+				app.serverError(w, err)
+			}
+			return
+		}
+		against = againstRevision.Recipe
+	}
+
+	changes := models.DiffRecipes(revision.Recipe, against)
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"changes": changes}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Computed diff for revision %d of recipe with id: %d", rev, id)
+}
+
+func (app *application) restoreRecipeRevision(w http.ResponseWriter, r *http.Request) {
+	current, ok := app.readRecipe(w, r, "id")
+	if !ok {
+		return
+	}
+	id := current.ID
+
+	rev, err := app.readIDParam(r, "rev")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	revision, err := app.revisions.Get(id, rev)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	// Restoring goes through a regular update, so the version being replaced gets its own revision
+	recipe := revision.Recipe
+	recipe.ID = id
+	if err := app.recipesFor(r).Update(recipe); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	recipe, err = app.recipesFor(r).Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"recipe": recipe}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Restored revision %d of recipe with id: %d", rev, id)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+var testRevision = &models.RecipeRevision{
+	ID:       1,
+	RecipeID: 1,
+	Revision: 1,
+	Recipe: &models.Recipe{
+		ID:              1,
+		Name:            "Old Test Recipe",
+		Description:     "Test Description",
+		Instructions:    "Old Test Instructions",
+		PreparationTime: "30m",
+		CookingTime:     "1h",
+		Portions:        2,
+		Ingredients: []*models.FullIngredient{
+			{
+				Ingredient: &models.Ingredient{
+					ID:   1,
+					Name: "Test Ingredient",
+				},
+				Quantity: 0.5,
+				Unit:     "cup",
+			},
+		},
+	},
+}
+
+func TestListRecipeRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockRevisions := mocks.NewMockRecipeRevisionModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.revisions = mockRevisions
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		id             int
+		mockGetErr     error
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Revisions Found",
+			id:             1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Recipe Not Found",
+			id:             2,
+			mockGetErr:     models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Server Error",
+			id:             1,
+			mockReturnErr:  fmt.Errorf("server error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Bad Request",
+			id:             0,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.id > 0 {
+				mockRecipes.EXPECT().Get(tc.id).Return(testRecipe, tc.mockGetErr)
+				if tc.mockGetErr == nil {
+					mockRevisions.EXPECT().GetAll(tc.id).Return([]*models.RecipeRevision{testRevision}, tc.mockReturnErr)
+				}
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/%d/revisions", ts.URL, tc.id))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestGetRecipeRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockRevisions := mocks.NewMockRecipeRevisionModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.revisions = mockRevisions
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		rev            int
+		recipeErr      error
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Revision Found",
+			rev:            1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Recipe Not In Workspace",
+			rev:            1,
+			recipeErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Revision Not Found",
+			rev:            2,
+			mockReturnErr:  models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Bad Request",
+			rev:            -1,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().Get(1).Return(testRecipe, tc.recipeErr)
+			if tc.rev > 0 && tc.recipeErr == nil {
+				mockRevisions.EXPECT().Get(1, tc.rev).Return(testRevision, tc.mockReturnErr)
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1/revisions/%d", ts.URL, tc.rev))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestDiffRecipeRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockRevisions := mocks.NewMockRecipeRevisionModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.revisions = mockRevisions
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	mockRevisions.EXPECT().Get(1, 1).Return(testRevision, nil)
+	mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1/revisions/1/diff", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Changes []*models.FieldChange `json:"changes"`
+	}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+	fields := make([]string, 0, len(body.Changes))
+	for _, change := range body.Changes {
+		fields = append(fields, change.Field)
+	}
+	assert.Equal(t, []string{"name", "instructions", "portions", "ingredients.Test Ingredient", "tags.Test Tag"}, fields)
+}
+
+func TestRestoreRecipeRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockRevisions := mocks.NewMockRecipeRevisionModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.revisions = mockRevisions
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		rev            int
+		mockGetErr     error
+		mockUpdateErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Successful Restore",
+			rev:            1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Revision Not Found",
+			rev:            2,
+			mockGetErr:     models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Server Error",
+			rev:            1,
+			mockUpdateErr:  fmt.Errorf("server error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+			mockRevisions.EXPECT().Get(1, tc.rev).Return(testRevision, tc.mockGetErr)
+			if tc.mockGetErr == nil {
+				mockRecipes.EXPECT().Update(testRevision.Recipe).Return(tc.mockUpdateErr)
+				if tc.mockUpdateErr == nil {
+					mockRecipes.EXPECT().Get(1).Return(testRevision.Recipe, nil)
+				}
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/1/revisions/%d/restore", ts.URL, tc.rev), "application/json", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/render"
+	"github.com/vladComan0/tasty-byte/internal/shopping"
+)
+
+// shoppingListFormats are the representations shopping lists can be served in, the first one being the default.
+var shoppingListFormats = []string{formatJSON, formatMarkdown, formatText}
+
+const defaultShoppingListName = "Shopping list"
+
+// createShoppingList aggregates the ingredients of the given recipes, each scaled to the requested
+// number of portions, into a new shopping list.
+func (app *application) createShoppingList(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name    string `json:"name"`
+		Recipes []*struct {
+			RecipeID int `json:"recipe_id"`
+			Portions int `json:"portions"`
+		} `json:"recipes"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if len(input.Recipes) == 0 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	requested := make([]*models.ShoppingListRecipe, 0, len(input.Recipes))
+	for _, recipe := range input.Recipes {
+		if recipe == nil || recipe.RecipeID < 1 || recipe.Portions < 0 {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+		requested = append(requested, &models.ShoppingListRecipe{RecipeID: recipe.RecipeID, Portions: recipe.Portions})
+	}
+
+	list, err := app.insertShoppingList(r, input.Name, requested)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord), errors.Is(err, models.ErrNoPortions):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/shopping-lists/%d", list.ID))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"shopping_list": list}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new shopping list with id: %d", list.ID)
+}
+
+// insertShoppingList stores a new shopping list with the ingredients of the requested recipes, each scaled
+// to its portions unless there are none. It fails with models.ErrNoRecord when a recipe doesn't exist,
+// and with models.ErrNoPortions when one has no portions to be scaled from.
+func (app *application) insertShoppingList(r *http.Request, name string, requested []*models.ShoppingListRecipe) (*models.ShoppingList, error) {
+	list := &models.ShoppingList{
+		Name:    strings.TrimSpace(name),
+		Recipes: make([]*models.ShoppingListRecipe, 0, len(requested)),
+	}
+	if list.Name == "" {
+		list.Name = defaultShoppingListName
+	}
+
+	recipes := make([]*models.Recipe, 0, len(requested))
+	for _, entry := range requested {
+		recipe, err := app.recipesFor(r).Get(entry.RecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Portions > 0 {
+			if err := recipe.Scale(entry.Portions); err != nil {
+				return nil, err
+			}
+		}
+
+		recipes = append(recipes, recipe)
+		list.Recipes = append(list.Recipes, &models.ShoppingListRecipe{
+			RecipeID: recipe.ID,
+			Name:     recipe.Name,
+			Portions: recipe.Portions,
+		})
+	}
+	list.Items = shopping.Aggregate(recipes...)
+
+	id, err := app.shoppingListsFor(r).Insert(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.shoppingListsFor(r).Get(id)
+}
+
+func (app *application) listShoppingLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := app.shoppingListsFor(r).GetAll()
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"shopping_lists": lists}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved all shopping lists")
+}
+
+func (app *application) getShoppingList(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	format, err := app.negotiateFormat(r, shoppingListFormats...)
+	if err != nil {
+		app.formatError(w, err)
+		return
+	}
+
+	list, err := app.shoppingListsFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeShoppingList(w, http.StatusOK, format, list); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved shopping list with id: %d", id)
+}
+
+// checkShoppingItem checks off an item of a shopping list, or unchecks it.
+func (app *application) checkShoppingItem(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	itemID, err := app.readIDParam(r, "item")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Checked *bool `json:"checked"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil || input.Checked == nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.shoppingListsFor(r).SetChecked(id, itemID, *input.Checked); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	list, err := app.shoppingListsFor(r).Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"shopping_list": list}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Set item %d of shopping list %d as checked: %t", itemID, id, *input.Checked)
+}
+
+func (app *application) deleteShoppingList(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.shoppingListsFor(r).Delete(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "Shopping list successfully deleted"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Deleted shopping list with id: %d", id)
+}
+
+// writeShoppingList writes a shopping list in the negotiated format.
+func (app *application) writeShoppingList(w http.ResponseWriter, status int, format string, list *models.ShoppingList) error {
+	if format != formatMarkdown && format != formatText {
+		return app.writeJSON(w, status, envelope{"shopping_list": list}, http.Header{"Vary": {"Accept"}})
+	}
+
+	renderFn := render.ShoppingListText
+	if format == formatMarkdown {
+		renderFn = render.ShoppingListMarkdown
+	}
+
+	var buf bytes.Buffer
+	if err := renderFn(&buf, list); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", formatMediaTypes[format]+"; charset=utf-8")
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
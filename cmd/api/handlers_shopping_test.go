@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newShoppingList() *models.ShoppingList {
+	return &models.ShoppingList{
+		ID:      1,
+		Name:    "Weekend",
+		Recipes: []*models.ShoppingListRecipe{{RecipeID: 1, Name: "Test Recipe", Portions: 8}},
+		Items: []*models.ShoppingItem{
+			{ID: 1, Name: "tomatoes", Quantity: 4, Aisle: "produce"},
+			{ID: 2, Name: "milk", Quantity: 1.5, Unit: "cup", Aisle: "dairy & eggs", Checked: true},
+			{ID: 3, Name: "butter", Quantity: 50, Unit: "g", Aisle: "dairy & eggs"},
+		},
+	}
+}
+
+func TestCreateShoppingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockLists := mocks.NewMockShoppingListModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.shoppingLists = mockLists
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		recipe         *models.Recipe
+		recipeErr      error
+		expectGet      bool
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid List",
+			body:           `{"name": "Weekend", "recipes": [{"recipe_id": 1, "portions": 8}]}`,
+			recipe:         newPatchableRecipe(),
+			expectGet:      true,
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "No Recipes",
+			body:           `{"name": "Weekend", "recipes": []}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Negative Portions",
+			body:           `{"recipes": [{"recipe_id": 1, "portions": -2}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipe Not Found",
+			body:           `{"recipes": [{"recipe_id": 1}]}`,
+			recipeErr:      models.ErrNoRecord,
+			expectGet:      true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Recipe Without Portions",
+			body:           `{"recipes": [{"recipe_id": 1, "portions": 2}]}`,
+			recipe:         &models.Recipe{ID: 1, Name: "Soup"},
+			expectGet:      true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockRecipes.EXPECT().Get(1).Return(tc.recipe, tc.recipeErr)
+			}
+			if tc.expectInsert {
+				mockLists.EXPECT().Insert(gomock.Any()).DoAndReturn(func(list *models.ShoppingList) (int, error) {
+					assert.Equal(t, "Weekend", list.Name)
+					assert.Equal(t, 8, list.Recipes[0].Portions)
+					// The recipe is for 4 portions, so everything is doubled, and milk comes before flour in the store
+					assert.Equal(t, "milk", list.Items[0].Name)
+					assert.Equal(t, 600.0, list.Items[0].Quantity)
+					assert.Equal(t, 400.0, list.Items[1].Quantity)
+					return 1, nil
+				})
+				mockLists.EXPECT().Get(1).Return(newShoppingList(), nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/shopping-lists", ts.URL), "application/json", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Equal(t, "v1/shopping-lists/1", res.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestGetShoppingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockLists := mocks.NewMockShoppingListModelInterface(ctrl)
+	app.shoppingLists = mockLists
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name                string
+		query               string
+		mockReturnErr       error
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "JSON",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+		},
+		{
+			name:                "Markdown",
+			query:               "?format=markdown",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/markdown; charset=utf-8",
+			expectedBody: `# Weekend
+
+_For: Test Recipe (8 portions)_
+
+## produce
+
+- [ ] 4 tomatoes
+
+## dairy & eggs
+
+- [x] 1 1/2 cup milk
+- [ ] 50 g butter
+`,
+		},
+		{
+			name:                "Text",
+			query:               "?format=text",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody: `WEEKEND
+=======
+
+For: Test Recipe (8 portions)
+
+PRODUCE
+  [ ] 4 tomatoes
+
+DAIRY & EGGS
+  [x] 1 1/2 cup milk
+  [ ] 50 g butter
+`,
+		},
+		{
+			name:           "Not Found",
+			mockReturnErr:  models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var list *models.ShoppingList
+			if tc.mockReturnErr == nil {
+				list = newShoppingList()
+			}
+			mockLists.EXPECT().Get(1).Return(list, tc.mockReturnErr)
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/shopping-lists/1%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tc.expectedContentType, res.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestCheckShoppingItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockLists := mocks.NewMockShoppingListModelInterface(ctrl)
+	app.shoppingLists = mockLists
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		item           string
+		body           string
+		setErr         error
+		expectSet      bool
+		expectedStatus int
+	}{
+		{
+			name:           "Check Item",
+			item:           "2",
+			body:           `{"checked": true}`,
+			expectSet:      true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Item Not Found",
+			item:           "2",
+			body:           `{"checked": false}`,
+			expectSet:      true,
+			setErr:         models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Missing Checked",
+			item:           "2",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Item",
+			item:           "abc",
+			body:           `{"checked": true}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectSet {
+				mockLists.EXPECT().SetChecked(1, 2, strings.Contains(tc.body, "true")).Return(tc.setErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockLists.EXPECT().Get(1).Return(newShoppingList(), nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/v1/shopping-lists/1/items/%s", ts.URL, tc.item), strings.NewReader(tc.body))
+			assert.NoError(t, err)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body struct {
+					ShoppingList *models.ShoppingList `json:"shopping_list"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Len(t, body.ShoppingList.Items, 3)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/similarity"
+)
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 100
+)
+
+// readThreshold reads the "?threshold=" similarity score from which recipes are reported, between 0 and 1.
+func (app *application) readThreshold(r *http.Request) (float64, error) {
+	value := r.URL.Query().Get("threshold")
+	if value == "" {
+		return similarity.DefaultThreshold, nil
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold < 0 || threshold > 1 {
+		return 0, errors.New("invalid threshold parameter")
+	}
+	return threshold, nil
+}
+
+// findDuplicates returns the recipes that are likely duplicates of the given one.
+func (app *application) findDuplicates(r *http.Request, recipe *models.Recipe, threshold float64) ([]*similarity.Match, error) {
+	recipes, err := app.recipesFor(r).GetAll()
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		return nil, err
+	}
+	return similarity.Similar(recipe, recipes, threshold), nil
+}
+
+// getSimilarRecipes returns the recipes most similar to the given one, up to "?limit=" of them.
+func (app *application) getSimilarRecipes(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	threshold, err := app.readThreshold(r)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	limit, err := app.readLimit(r, defaultSimilarLimit, maxSimilarLimit)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	matches, err := app.findDuplicates(r, recipe, threshold)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"similar": matches}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the recipes similar to recipe with id: %d", id)
+}
+
+// listDuplicates reports every pair of recipes that are likely duplicates of each other.
+func (app *application) listDuplicates(w http.ResponseWriter, r *http.Request) {
+	threshold, err := app.readThreshold(r)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	recipes, err := app.recipesFor(r).GetAll()
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, err)
+		return
+	}
+
+	pairs := similarity.Duplicates(recipes, threshold)
+
+	data := envelope{
+		"threshold":  threshold,
+		"duplicates": pairs,
+	}
+	if err := app.writeJSON(w, http.StatusOK, data, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Found %d likely duplicate recipes", len(pairs))
+}
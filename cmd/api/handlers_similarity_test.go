@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/similarity"
+)
+
+// newCatalogue returns the patchable pancakes, a near copy of them and an unrelated recipe.
+func newCatalogue() []*models.Recipe {
+	copied := newPatchableRecipe()
+	copied.ID = 2
+	copied.Name = "The Best Pancakes!"
+
+	soup := &models.Recipe{
+		ID:           3,
+		Name:         "Tomato soup",
+		Instructions: "Roast and blend",
+		Ingredients: []*models.FullIngredient{
+			{Ingredient: &models.Ingredient{ID: 4, Name: "tomatoes"}, Quantity: 1, Unit: "kg"},
+		},
+	}
+
+	return []*models.Recipe{newPatchableRecipe(), copied, soup}
+}
+
+func TestGetSimilarRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		query          string
+		recipeErr      error
+		expectGet      bool
+		expectedIDs    []int
+		expectedStatus int
+	}{
+		{
+			name:           "Default Threshold",
+			expectGet:      true,
+			expectedIDs:    []int{2},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Any Similarity",
+			query:          "?threshold=0",
+			expectGet:      true,
+			expectedIDs:    []int{2, 3},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Limited",
+			query:          "?threshold=0&limit=1",
+			expectGet:      true,
+			expectedIDs:    []int{2},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Threshold",
+			query:          "?threshold=2",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Recipe Not Found",
+			recipeErr:      models.ErrNoRecord,
+			expectGet:      true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), tc.recipeErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockRecipes.EXPECT().GetAll().Return(newCatalogue(), nil)
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1/similar%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body struct {
+					Similar []*similarity.Match `json:"similar"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+				ids := make([]int, 0, len(body.Similar))
+				for _, match := range body.Similar {
+					ids = append(ids, match.RecipeID)
+				}
+				assert.Equal(t, tc.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestListDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.users = mockUsers
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	mockRecipes.EXPECT().GetAll().Return(newCatalogue(), nil)
+
+	req := adminRequest(t, mockUsers, http.MethodGet, fmt.Sprintf("%s/v1/admin/duplicates", ts.URL), nil)
+	res, err := ts.Client().Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Duplicates []*similarity.Pair `json:"duplicates"`
+	}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Len(t, body.Duplicates, 1)
+	assert.Equal(t, 1, body.Duplicates[0].First.RecipeID)
+	assert.Equal(t, 2, body.Duplicates[0].Second.RecipeID)
+}
+
+func TestCreateRecipeCheckDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	body := `{
+		"name": "Pancakes",
+		"instructions": "Mix and fry",
+		"portions": 4,
+		"ingredients": [{"name": "flour", "quantity": 200, "unit": "g"}, {"name": "milk", "quantity": 300, "unit": "ml"}]
+	}`
+
+	testCases := []struct {
+		name           string
+		query          string
+		catalogue      []*models.Recipe
+		expectedStatus int
+	}{
+		{
+			name:           "Likely Duplicate",
+			query:          "?check_duplicates=true",
+			catalogue:      newCatalogue(),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "No Duplicate",
+			query:          "?check_duplicates=true",
+			catalogue:      newCatalogue()[2:],
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Unchecked",
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid Flag",
+			query:          "?check_duplicates=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.catalogue != nil {
+				mockRecipes.EXPECT().GetAll().Return(tc.catalogue, nil)
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockRecipes.EXPECT().Insert(gomock.Any()).Return(4, nil)
+				mockRecipes.EXPECT().Get(4).Return(newPatchableRecipe(), nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes%s", ts.URL, tc.query), "application/json", strings.NewReader(body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusConflict {
+				var response struct {
+					Duplicates []*similarity.Match `json:"duplicates"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+				assert.Len(t, response.Duplicates, 2)
+				assert.Equal(t, 1, response.Duplicates[0].RecipeID)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestCreateRecipeWithSteps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		mockReturnErr  error
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name: "Valid Steps",
+			body: `{"name": "Pancakes", "ingredients": [{"id": 1, "name": "flour", "quantity": 200, "unit": "g"}],
+				"steps": [{"text": "Mix", "ingredient_ids": [1]}, {"text": "Fry", "duration": "5m"}]}`,
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Step Without Text",
+			body:           `{"name": "Pancakes", "steps": [{"text": " "}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Duration",
+			body:           `{"name": "Pancakes", "steps": [{"text": "Fry", "duration": "a while"}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Ingredient",
+			body:           `{"name": "Pancakes", "steps": [{"text": "Mix", "ingredient_ids": [42]}]}`,
+			mockReturnErr:  models.ErrInvalidStepIngredient,
+			expectInsert:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectInsert {
+				mockRecipes.EXPECT().Insert(gomock.Any()).DoAndReturn(func(recipe *models.Recipe) (int, error) {
+					assert.NotEmpty(t, recipe.Steps)
+					if tc.mockReturnErr != nil {
+						return 0, tc.mockReturnErr
+					}
+					assert.Equal(t, []int{1}, recipe.Steps[0].IngredientIDs)
+					assert.Equal(t, "5m", recipe.Steps[1].Duration)
+					return 1, nil
+				})
+				if tc.mockReturnErr == nil {
+					mockRecipes.EXPECT().Get(1).Return(newPatchableRecipe(), nil)
+				}
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes", ts.URL), "application/json", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestPatchRecipeSteps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name                 string
+		patch                string
+		expectedInstructions string
+		expectedSteps        []string
+	}{
+		{
+			name:                 "Patched Step Rewrites Instructions",
+			patch:                `[{"op": "replace", "path": "/steps/1/text", "value": "Fry on both sides"}]`,
+			expectedInstructions: "",
+			expectedSteps:        []string{"Mix", "Fry on both sides"},
+		},
+		{
+			name:                 "Patched Instructions Rewrite Steps",
+			patch:                `[{"op": "replace", "path": "/instructions", "value": "Whisk\nBake"}]`,
+			expectedInstructions: "Whisk\nBake",
+			expectedSteps:        nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recipe := newPatchableRecipe()
+			recipe.Instructions = "Mix\nFry"
+			recipe.Steps = []*models.Step{
+				{ID: 1, Position: 1, Text: "Mix", IngredientIDs: []int{1, 2}},
+				{ID: 2, Position: 2, Text: "Fry", Duration: "10m"},
+			}
+
+			mockRecipes.EXPECT().Get(1).Return(recipe, nil)
+			mockRecipes.EXPECT().Update(gomock.Any()).DoAndReturn(func(recipe *models.Recipe) error {
+				assert.Equal(t, tc.expectedInstructions, recipe.Instructions)
+
+				var steps []string
+				for _, step := range recipe.Steps {
+					steps = append(steps, step.Text)
+				}
+				assert.Equal(t, tc.expectedSteps, steps)
+				return nil
+			})
+
+			req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/v1/recipes/1", ts.URL), strings.NewReader(tc.patch))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", jsonPatchContentType)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			var body struct {
+				Recipe *models.Recipe `json:"recipe"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		})
+	}
+}
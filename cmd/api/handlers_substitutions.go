@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// substitutionResponse is a substitute for an ingredient, classified like the ingredient endpoints do.
+type substitutionResponse struct {
+	Substitute *ingredientResponse `json:"substitute"`
+	Ratio      float64             `json:"ratio"`
+	Note       string              `json:"note,omitempty"`
+}
+
+func newSubstitutionResponses(substitutions []*models.Substitution) []*substitutionResponse {
+	responses := make([]*substitutionResponse, 0, len(substitutions))
+	for _, substitution := range substitutions {
+		responses = append(responses, &substitutionResponse{
+			Substitute: newIngredientResponse(substitution.Substitute),
+			Ratio:      substitution.Ratio,
+			Note:       substitution.Note,
+		})
+	}
+	return responses
+}
+
+// freeOf tells whether the substitute is known to contain none of the allergens.
+func freeOf(substitution *models.Substitution, allergens []string) bool {
+	if len(allergens) == 0 {
+		return true
+	}
+	return substitution.Substitute.Classified && !slices.ContainsFunc(allergens, func(allergen string) bool {
+		return slices.Contains(substitution.Substitute.Allergens, allergen)
+	})
+}
+
+// listSubstitutes returns the ingredients that can replace the given one, leaving out those
+// containing any of the "?exclude_allergen=" allergens or not classified yet when given.
+func (app *application) listSubstitutes(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	excludedAllergens := app.readListParam(r, "exclude_allergen")
+	for _, allergen := range excludedAllergens {
+		if !models.ValidAllergen(allergen) {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if _, err := app.ingredientsFor(r).Get(id); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	substitutions, err := app.substitutionsFor(r).GetByIngredientID(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	substitutions = slices.DeleteFunc(substitutions, func(substitution *models.Substitution) bool {
+		return !freeOf(substitution, excludedAllergens)
+	})
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"substitutes": newSubstitutionResponses(substitutions)}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the substitutes of ingredient with id: %d", id)
+}
+
+// substituteIngredients previews a recipe with some of its ingredients replaced, without saving it.
+// Ingredients are either replaced with the substitute asked for, or, for those containing one of
+// the excluded allergens, with the first of their substitutes that is free of all of them.
+// Ingredients no such substitute is known for are listed as unresolved.
+func (app *application) substituteIngredients(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Substitutions []struct {
+			IngredientID int `json:"ingredient_id"`
+			SubstituteID int `json:"substitute_id"`
+		} `json:"substitutions"`
+		ExcludeAllergens []string `json:"exclude_allergens"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if len(input.Substitutions) == 0 && len(input.ExcludeAllergens) == 0 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+	for i, allergen := range input.ExcludeAllergens {
+		input.ExcludeAllergens[i] = strings.ToLower(strings.TrimSpace(allergen))
+		if !models.ValidAllergen(input.ExcludeAllergens[i]) {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	applied := []*models.Substitution{}
+	for _, requested := range input.Substitutions {
+		substitutions, err := app.substitutionsFor(r).GetByIngredientID(requested.IngredientID)
+		if err != nil {
+			app.serverError(w, err)
+			return
+		}
+
+		index := slices.IndexFunc(substitutions, func(substitution *models.Substitution) bool {
+			return substitution.Substitute.ID == requested.SubstituteID
+		})
+		if index < 0 {
+			app.clientError(w, http.StatusUnprocessableEntity)
+			return
+		}
+
+		if err := recipe.Substitute(substitutions[index]); err != nil {
+			switch {
+			case errors.Is(err, models.ErrInvalidSubstitution):
+				app.clientError(w, http.StatusUnprocessableEntity)
+			default:
+				app.serverError(w, err)
+			}
+			return
+		}
+		applied = append(applied, substitutions[index])
+	}
+
+	unresolved := []string{}
+	for _, ingredient := range slices.Clone(recipe.Ingredients) {
+		if ingredient.Ingredient == nil || !slices.ContainsFunc(input.ExcludeAllergens, func(allergen string) bool {
+			return slices.Contains(ingredient.Allergens, allergen)
+		}) {
+			continue
+		}
+
+		substitutions, err := app.substitutionsFor(r).GetByIngredientID(ingredient.ID)
+		if err != nil {
+			app.serverError(w, err)
+			return
+		}
+
+		index := slices.IndexFunc(substitutions, func(substitution *models.Substitution) bool {
+			return freeOf(substitution, input.ExcludeAllergens)
+		})
+		if index < 0 {
+			unresolved = append(unresolved, ingredient.Name)
+			continue
+		}
+
+		if err := recipe.Substitute(substitutions[index]); err != nil {
+			app.serverError(w, err)
+			return
+		}
+		applied = append(applied, substitutions[index])
+	}
+
+	data := envelope{
+		"recipe":        recipe,
+		"substitutions": applied,
+		"unresolved":    unresolved,
+	}
+	if err := app.writeJSON(w, http.StatusOK, data, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Previewed %d substitutions in recipe with id: %d", len(applied), id)
+}
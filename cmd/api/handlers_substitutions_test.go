@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// newMilkSubstitutions returns the substitutes of milk (ingredient 2 of the patchable recipe).
+func newMilkSubstitutions() []*models.Substitution {
+	return []*models.Substitution{
+		{IngredientID: 2, Substitute: &models.Ingredient{ID: 5, Name: "almond milk", Allergens: []string{"nuts"}, Classified: true}, Ratio: 1},
+		{IngredientID: 2, Substitute: &models.Ingredient{ID: 6, Name: "oat milk", Classified: true}, Ratio: 1, Note: "Slightly sweeter."},
+		{IngredientID: 2, Substitute: &models.Ingredient{ID: 7, Name: "water"}, Ratio: 1},
+	}
+}
+
+func TestListSubstitutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	mockSubstitutions := mocks.NewMockSubstitutionModelInterface(ctrl)
+	app.ingredients = mockIngredients
+	app.substitutions = mockSubstitutions
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		path           string
+		ingredientErr  error
+		expectGet      bool
+		expectedNames  []string
+		expectedStatus int
+	}{
+		{
+			name:           "All Substitutes",
+			path:           "/v1/ingredients/2/substitutes",
+			expectGet:      true,
+			expectedNames:  []string{"almond milk", "oat milk", "water"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Excluded Allergen",
+			path:           "/v1/ingredients/2/substitutes?exclude_allergen=nuts",
+			expectGet:      true,
+			expectedNames:  []string{"oat milk"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Allergen",
+			path:           "/v1/ingredients/2/substitutes?exclude_allergen=msg",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Ingredient Not Found",
+			path:           "/v1/ingredients/2/substitutes",
+			ingredientErr:  models.ErrNoRecord,
+			expectGet:      true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockIngredients.EXPECT().Get(2).Return(&models.Ingredient{ID: 2, Name: "milk"}, tc.ingredientErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockSubstitutions.EXPECT().GetByIngredientID(2).Return(newMilkSubstitutions(), nil)
+			}
+
+			res, err := ts.Client().Get(ts.URL + tc.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body struct {
+					Substitutes []*substitutionResponse `json:"substitutes"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+				names := make([]string, 0, len(body.Substitutes))
+				for _, substitute := range body.Substitutes {
+					names = append(names, substitute.Substitute.Name)
+				}
+				assert.Equal(t, tc.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestSubstituteIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockSubstitutions := mocks.NewMockSubstitutionModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.substitutions = mockSubstitutions
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	// The allergens of the patchable recipe, as they would come out of the database
+	newRecipe := func() *models.Recipe {
+		recipe := newPatchableRecipe()
+		recipe.Ingredients[0].Allergens = []string{"gluten"}
+		recipe.Ingredients[1].Allergens = []string{"dairy"}
+		return recipe
+	}
+
+	testCases := []struct {
+		name               string
+		body               string
+		expectGet          bool
+		expectedLookups    []int
+		expectedMilk       string
+		expectedUnresolved []string
+		expectedStatus     int
+	}{
+		{
+			name:            "Requested Substitute",
+			body:            `{"substitutions": [{"ingredient_id": 2, "substitute_id": 5}]}`,
+			expectGet:       true,
+			expectedLookups: []int{2},
+			expectedMilk:    "almond milk",
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:               "Excluded Allergens",
+			body:               `{"exclude_allergens": ["Dairy", "nuts", "gluten"]}`,
+			expectGet:          true,
+			expectedLookups:    []int{1, 2},
+			expectedMilk:       "oat milk",
+			expectedUnresolved: []string{"flour"},
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:            "Unknown Substitute",
+			body:            `{"substitutions": [{"ingredient_id": 2, "substitute_id": 9}]}`,
+			expectGet:       true,
+			expectedLookups: []int{2},
+			expectedStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Nothing To Substitute",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Allergen",
+			body:           `{"exclude_allergens": ["msg"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectGet {
+				mockRecipes.EXPECT().Get(1).Return(newRecipe(), nil)
+			}
+			for _, id := range tc.expectedLookups {
+				substitutions := []*models.Substitution{}
+				if id == 2 {
+					substitutions = newMilkSubstitutions()
+				}
+				mockSubstitutions.EXPECT().GetByIngredientID(id).Return(substitutions, nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/1/substitute", ts.URL), "application/json", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body struct {
+					Recipe     *models.Recipe `json:"recipe"`
+					Unresolved []string       `json:"unresolved"`
+				}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Equal(t, tc.expectedMilk, body.Recipe.Ingredients[1].Name)
+				assert.Equal(t, 300.0, body.Recipe.Ingredients[1].Quantity)
+				assert.NotContains(t, body.Recipe.Allergens, "dairy")
+				if tc.expectedUnresolved == nil {
+					tc.expectedUnresolved = []string{}
+				}
+				assert.Equal(t, tc.expectedUnresolved, body.Unresolved)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// tagGroups turns the named tags into the groups of tags recipes are filtered by, every tag with the
+// tags under it, as a recipe has a tag when it has the tag itself or any tag under it. A tag that
+// doesn't exist gets an empty group, which no recipe matches.
+func tagGroups(hierarchy *models.TagHierarchy, names []string) [][]int {
+	groups := make([][]int, 0, len(names))
+	for _, name := range names {
+		tag := hierarchy.Find(name)
+		if tag == nil {
+			groups = append(groups, []int{})
+			continue
+		}
+		groups = append(groups, hierarchy.Descendants(tag.ID))
+	}
+	return groups
+}
+
+// getTagTree returns the tags nested under their parents, optionally only the ones of "?category=".
+func (app *application) getTagTree(w http.ResponseWriter, r *http.Request) {
+	tags, err := app.tagsFor(r).GetAll()
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	category := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("category")))
+	tree := models.NewTagHierarchy(tags).Tree(category)
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"tags": tree}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved the tag tree")
+}
+
+// updateTag moves a tag under another one, or to the top with no parent, and sets its category.
+func (app *application) updateTag(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		ParentID int    `json:"parent_id"`
+		Category string `json:"category"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if input.ParentID < 0 || len(input.Category) > 50 {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	tag := &models.Tag{
+		ID:       id,
+		ParentID: input.ParentID,
+		Category: input.Category,
+	}
+
+	if err := app.tagsFor(r).Update(tag); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrInvalidTagParent):
+			app.clientError(w, http.StatusUnprocessableEntity)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	tag, err = app.tagsFor(r).Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"tag": tag}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Updated tag with id: %d", id)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newTagTree() []*models.Tag {
+	return []*models.Tag{
+		{ID: 1, Name: "cuisine", Category: "cuisine"},
+		{ID: 2, Name: "italian", ParentID: 1},
+		{ID: 3, Name: "sicilian", ParentID: 2},
+		{ID: 4, Name: "japanese", ParentID: 1},
+		{ID: 5, Name: "dessert", Category: "course"},
+	}
+}
+
+func TestGetTagTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockTags := mocks.NewMockTagModelInterface(ctrl)
+	app.tags = mockTags
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedRoots []string
+	}{
+		{
+			name:          "Every Category",
+			expectedRoots: []string{"cuisine", "dessert"},
+		},
+		{
+			name:          "One Category",
+			query:         "?category=Course",
+			expectedRoots: []string{"dessert"},
+		},
+		{
+			name:          "Unknown Category",
+			query:         "?category=occasion",
+			expectedRoots: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTags.EXPECT().GetAll().Return(newTagTree(), nil)
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/tags/tree%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			var body struct {
+				Tags []*models.Tag `json:"tags"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+			roots := []string{}
+			for _, tag := range body.Tags {
+				roots = append(roots, tag.Name)
+			}
+			assert.Equal(t, tc.expectedRoots, roots)
+
+			if len(body.Tags) == 2 {
+				italian := body.Tags[0].Children[0]
+				assert.Equal(t, "italian", italian.Name)
+				assert.Equal(t, "cuisine", italian.Category)
+				assert.Equal(t, "sicilian", italian.Children[0].Name)
+			}
+		})
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockTags := mocks.NewMockTagModelInterface(ctrl)
+	app.tags = mockTags
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		updateErr      error
+		expectUpdate   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid Parent",
+			body:           `{"parent_id": 2, "category": "cuisine"}`,
+			expectUpdate:   true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Parent Under The Tag",
+			body:           `{"parent_id": 4}`,
+			expectUpdate:   true,
+			updateErr:      models.ErrInvalidTagParent,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Tag Not Found",
+			body:           `{"parent_id": 0}`,
+			expectUpdate:   true,
+			updateErr:      models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Negative Parent",
+			body:           `{"parent_id": -1}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Malformed Body",
+			body:           `{"parent": "italian"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectUpdate {
+				mockTags.EXPECT().Update(gomock.Any()).DoAndReturn(func(tag *models.Tag) error {
+					assert.Equal(t, 3, tag.ID)
+					return tc.updateErr
+				})
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockTags.EXPECT().Get(3).Return(&models.Tag{ID: 3, Name: "sicilian", ParentID: 2, Category: "cuisine"}, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/v1/tags/3", ts.URL), strings.NewReader(tc.body))
+			assert.NoError(t, err)
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestListRecipesByTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockTags := mocks.NewMockTagModelInterface(ctrl)
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.tags = mockTags
+	app.facets = mockFacets
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedGroups [][]int
+	}{
+		{
+			name: "No Filter",
+		},
+		{
+			name:           "Parent Tag Includes Descendants",
+			query:          "?tag=italian",
+			expectedGroups: [][]int{{2, 3}},
+		},
+		{
+			name:           "Every Tag",
+			query:          "?tag=cuisine,dessert",
+			expectedGroups: [][]int{{1, 2, 4, 3}, {5}},
+		},
+		{
+			name:           "Unknown Tag",
+			query:          "?tag=french",
+			expectedGroups: [][]int{{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.query != "" {
+				mockTags.EXPECT().GetAll().Return(newTagTree(), nil)
+			}
+
+			var listed *models.RecipeFilter
+			mockRecipes.EXPECT().List(gomock.Any()).DoAndReturn(func(filter *models.RecipeFilter) ([]*models.Recipe, int, error) {
+				assert.Equal(t, tc.expectedGroups, filter.TagGroups)
+				listed = filter
+				return []*models.Recipe{}, 0, nil
+			})
+			// The facets are counted over the recipes matching the same filter, by tag unless asked otherwise
+			mockFacets.EXPECT().Count(gomock.Any(), []string{models.FacetTags}).DoAndReturn(func(filter *models.RecipeFilter, facets []string) (*models.Facets, error) {
+				assert.Same(t, listed, filter)
+				return &models.Facets{}, nil
+			})
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+}
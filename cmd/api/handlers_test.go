@@ -336,6 +336,9 @@ func TestListRecipes(t *testing.T) {
 	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
 
 	app.recipes = mockRecipes
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	mockFacets.EXPECT().Count(gomock.Any(), gomock.Any()).Return(&models.Facets{}, nil).AnyTimes()
+	app.facets = mockFacets
 
 	ts := newTestServer(app.routes())
 	defer ts.Close()
@@ -368,7 +371,7 @@ func TestListRecipes(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			mockRecipes.EXPECT().GetAll().Return(tc.mockReturn, tc.mockReturnErr)
+			mockRecipes.EXPECT().List(gomock.Any()).Return(tc.mockReturn, len(tc.mockReturn), tc.mockReturnErr)
 
 			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes", ts.URL))
 			assert.NoError(t, err)
@@ -377,6 +380,158 @@ func TestListRecipes(t *testing.T) {
 	}
 }
 
+func TestListRecipesPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	mockFacets.EXPECT().Count(gomock.Any(), gomock.Any()).Return(&models.Facets{}, nil).AnyTimes()
+	app.recipes = mockRecipes
+	app.facets = mockFacets
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedOffset int
+		expectedStatus int
+	}{
+		{
+			name:           "First Page",
+			expectedLimit:  defaultRecipeLimit,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Later Page",
+			query:          "?limit=2&offset=4",
+			expectedLimit:  2,
+			expectedOffset: 4,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Limit Too Large",
+			query:          fmt.Sprintf("?limit=%d", maxRecipeLimit+1),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Negative Offset",
+			query:          "?offset=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Offset",
+			query:          "?offset=second",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockRecipes.EXPECT().List(gomock.Any()).DoAndReturn(func(filter *models.RecipeFilter) ([]*models.Recipe, int, error) {
+					assert.Equal(t, tc.expectedLimit, filter.Limit)
+					assert.Equal(t, tc.expectedOffset, filter.Offset)
+					return testRecipes, 7, nil
+				})
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Metadata struct {
+					Total  int `json:"total"`
+					Limit  int `json:"limit"`
+					Offset int `json:"offset"`
+				} `json:"metadata"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, 7, body.Metadata.Total)
+			assert.Equal(t, tc.expectedLimit, body.Metadata.Limit)
+			assert.Equal(t, tc.expectedOffset, body.Metadata.Offset)
+		})
+	}
+}
+
+func TestListRecipesFacets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	app.recipes = mockRecipes
+	app.facets = mockFacets
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	facets := &models.Facets{
+		Ingredients: []*models.FacetCount{{ID: 4, Name: "flour", Count: 1}},
+		TotalTime:   []*models.FacetBucket{{Key: "16-30", Min: 16, Max: 30, Count: 1}},
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedFacets []string
+		expectedStatus int
+	}{
+		{
+			name:           "Requested Facets",
+			query:          "?facets=ingredients,total_time",
+			expectedFacets: []string{models.FacetIngredients, models.FacetTotalTime},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Repeated Param",
+			query:          "?facets=ingredients&facets=TOTAL_TIME",
+			expectedFacets: []string{models.FacetIngredients, models.FacetTotalTime},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Facet",
+			query:          "?facets=ingredients,calories",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockRecipes.EXPECT().List(gomock.Any()).Return(testRecipes, len(testRecipes), nil)
+				mockFacets.EXPECT().Count(gomock.Any(), tc.expectedFacets).Return(facets, nil)
+			}
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes%s", ts.URL, tc.query))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Facets *models.Facets `json:"facets"`
+			}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, facets, body.Facets)
+		})
+	}
+}
+
 func TestUpdateRecipe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestListTrashedRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		mockReturn     []*models.Recipe
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Trashed Recipes Found",
+			mockReturn:     testRecipes,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Server Error",
+			mockReturnErr:  fmt.Errorf("server error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRecipes.EXPECT().GetTrashed().Return(tc.mockReturn, tc.mockReturnErr)
+
+			res, err := ts.Client().Get(fmt.Sprintf("%s/v1/trash/recipes", ts.URL))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestRestoreRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	app.recipes = mockRecipes
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		id             int
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Successful Restore",
+			id:             1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Recipe Not In Trash",
+			id:             999,
+			mockReturnErr:  models.ErrNoRecord,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Server Error",
+			id:             1,
+			mockReturnErr:  fmt.Errorf("server error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Bad Request",
+			id:             0,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.id > 0 {
+				mockRecipes.EXPECT().Restore(tc.id).Return(tc.mockReturnErr)
+				if tc.mockReturnErr == nil {
+					mockRecipes.EXPECT().Get(tc.id).Return(testRecipe, nil)
+				}
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/recipes/%d/restore", ts.URL, tc.id), "application/json", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt only looks at the first 72 bytes of a password
+	maxPasswordBytes = 72
+)
+
+// registerUser creates an account that can then be used through HTTP Basic authentication.
+func (app *application) registerUser(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	input.Email = strings.ToLower(strings.TrimSpace(input.Email))
+
+	address, err := mail.ParseAddress(input.Email)
+	if input.Name == "" || utf8.RuneCountInString(input.Name) > 255 ||
+		err != nil || address.Address != input.Email ||
+		utf8.RuneCountInString(input.Password) < minPasswordLength || len(input.Password) > maxPasswordBytes {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	id, err := app.users.Insert(input.Name, input.Email, input.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrDuplicateEmail):
+			app.clientError(w, http.StatusConflict)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	user, err := app.users.Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"user": user}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Registered new user with id: %d", id)
+}
+
+// getCurrentUser returns the authenticated user.
+func (app *application) getCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, err := app.users.Get(app.contextGetUserID(r))
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"user": user}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func newTestUser() *models.User {
+	return &models.User{
+		ID:        1,
+		Name:      "Alice",
+		Email:     "alice@example.com",
+		CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestRegisterUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	app.users = mockUsers
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		insertErr      error
+		expectInsert   bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid User",
+			body:           `{"name": "Alice", "email": " Alice@Example.com", "password": "correct horse"}`,
+			expectInsert:   true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Duplicate Email",
+			body:           `{"name": "Alice", "email": "alice@example.com", "password": "correct horse"}`,
+			insertErr:      models.ErrDuplicateEmail,
+			expectInsert:   true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Invalid Email",
+			body:           `{"name": "Alice", "email": "alice", "password": "correct horse"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Short Password",
+			body:           `{"name": "Alice", "email": "alice@example.com", "password": "horse"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Name",
+			body:           `{"email": "alice@example.com", "password": "correct horse"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectInsert {
+				mockUsers.EXPECT().Insert("Alice", "alice@example.com", "correct horse").Return(1, tc.insertErr)
+			}
+			if tc.expectedStatus == http.StatusCreated {
+				mockUsers.EXPECT().Get(1).Return(newTestUser(), nil)
+			}
+
+			res, err := ts.Client().Post(fmt.Sprintf("%s/v1/users", ts.URL), "application/json", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	app.users = mockUsers
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		password       string
+		authErr        error
+		expectAuth     bool
+		expectedStatus int
+	}{
+		{
+			name:           "Authenticated",
+			password:       "correct horse",
+			expectAuth:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Wrong Password",
+			password:       "wrong horse",
+			authErr:        models.ErrInvalidCredentials,
+			expectAuth:     true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Anonymous",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/me", ts.URL), nil)
+			assert.NoError(t, err)
+
+			if tc.expectAuth {
+				req.SetBasicAuth("alice@example.com", tc.password)
+				mockUsers.EXPECT().Authenticate("alice@example.com", tc.password).Return(1, tc.authErr)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				mockUsers.EXPECT().Get(1).Return(newTestUser(), nil)
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, res.Header.Get("WWW-Authenticate"), "Basic")
+			}
+		})
+	}
+}
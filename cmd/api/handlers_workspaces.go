@@ -0,0 +1,229 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// readWorkspaceRole reads the workspace from the ":id" parameter along with the role the user has in it.
+// It writes the error response itself, a 404 when the user isn't a member, and returns false then.
+func (app *application) readWorkspaceRole(w http.ResponseWriter, r *http.Request) (int, string, bool) {
+	id, err := app.readIDParam(r, "id")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return 0, "", false
+	}
+
+	role, err := app.workspaces.GetRole(id, app.contextGetUserID(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return 0, "", false
+	}
+
+	return id, role, true
+}
+
+// createWorkspace creates a workspace owned by the user.
+func (app *application) createWorkspace(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string `json:"name"`
+	}
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	id, err := app.workspaces.Insert(name, app.contextGetUserID(r))
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	workspace, err := app.workspaces.Get(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+	workspace.Role = models.RoleOwner
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("v1/workspaces/%d", id))
+
+	if err := app.writeJSON(w, http.StatusCreated, envelope{"workspace": workspace}, headers); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Created new workspace with id: %d", id)
+}
+
+// listWorkspaces returns the workspaces the user is a member of, with their role in each.
+func (app *application) listWorkspaces(w http.ResponseWriter, r *http.Request) {
+	workspaces, err := app.workspaces.GetByUserID(app.contextGetUserID(r))
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"workspaces": workspaces}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved workspaces")
+}
+
+func (app *application) getWorkspace(w http.ResponseWriter, r *http.Request) {
+	id, role, ok := app.readWorkspaceRole(w, r)
+	if !ok {
+		return
+	}
+
+	workspace, err := app.workspaces.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+	workspace.Role = role
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"workspace": workspace}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved workspace with id: %d", id)
+}
+
+// listWorkspaceMembers returns the members of the workspace to any of its members.
+func (app *application) listWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := app.readWorkspaceRole(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := app.workspaces.GetMembers(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"members": members}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Retrieved members of workspace with id: %d", id)
+}
+
+// setWorkspaceMember adds a user to the workspace, or changes their role, which only owners can do.
+func (app *application) setWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	id, role, ok := app.readWorkspaceRole(w, r)
+	if !ok {
+		return
+	}
+	if !models.RoleAllows(role, models.RoleOwner) {
+		app.clientError(w, http.StatusForbidden)
+		return
+	}
+
+	userID, err := app.readIDParam(r, "user")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Role string `json:"role"`
+	}
+	if err := app.readJSON(w, r, &input); err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := app.workspaces.SetMember(id, userID, strings.ToLower(input.Role)); err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidRole):
+			app.clientError(w, http.StatusBadRequest)
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrLastOwner):
+			app.clientError(w, http.StatusConflict)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	members, err := app.workspaces.GetMembers(id)
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"members": members}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Set the role of user %d in workspace with id: %d", userID, id)
+}
+
+// removeWorkspaceMember takes a user out of the workspace, which owners can do for anyone and members
+// for themselves, as long as the workspace keeps an owner.
+func (app *application) removeWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	id, role, ok := app.readWorkspaceRole(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := app.readIDParam(r, "user")
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if userID != app.contextGetUserID(r) && !models.RoleAllows(role, models.RoleOwner) {
+		app.clientError(w, http.StatusForbidden)
+		return
+	}
+
+	if err := app.workspaces.RemoveMember(id, userID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		case errors.Is(err, models.ErrLastOwner):
+			app.clientError(w, http.StatusConflict)
+		default:
+			app.serverError(w, err)
+		}
+		return
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, envelope{"message": "member successfully removed"}, nil); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.infoLog.Printf("Removed user %d from workspace with id: %d", userID, id)
+}
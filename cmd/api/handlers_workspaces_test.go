@@ -0,0 +1,597 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestWorkspaceIsolation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	// The default catalogue and the one of workspace 2 are separate mocks, so that a query reaching
+	// the wrong one fails the test
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceModelInterface(ctrl)
+	mockRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockTeamRecipes := mocks.NewMockRecipeModelInterface(ctrl)
+	mockFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	mockTeamFavorites := mocks.NewMockFavoriteModelInterface(ctrl)
+	mockMealPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	mockTeamMealPlans := mocks.NewMockMealPlanModelInterface(ctrl)
+	mockShoppingLists := mocks.NewMockShoppingListModelInterface(ctrl)
+	mockTeamShoppingLists := mocks.NewMockShoppingListModelInterface(ctrl)
+	mockIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	mockTeamIngredients := mocks.NewMockIngredientModelInterface(ctrl)
+	mockSubstitutions := mocks.NewMockSubstitutionModelInterface(ctrl)
+	mockTeamSubstitutions := mocks.NewMockSubstitutionModelInterface(ctrl)
+	mockPantry := mocks.NewMockPantryModelInterface(ctrl)
+	mockFacets := mocks.NewMockFacetModelInterface(ctrl)
+	mockTeamFacets := mocks.NewMockFacetModelInterface(ctrl)
+	app.users = mockUsers
+	app.workspaces = mockWorkspaces
+	app.recipes = mockRecipes
+	app.favorites = mockFavorites
+	app.mealPlans = mockMealPlans
+	app.shoppingLists = mockShoppingLists
+	app.ingredients = mockIngredients
+	app.substitutions = mockSubstitutions
+	app.pantry = mockPantry
+	app.facets = mockFacets
+
+	// What hangs off recipes is only reached through recipes of the workspace, so these have no expectations
+	app.revisions = mocks.NewMockRecipeRevisionModelInterface(ctrl)
+	app.images = mocks.NewMockRecipeImageModelInterface(ctrl)
+	app.reviews = mocks.NewMockReviewModelInterface(ctrl)
+
+	mockRecipes.EXPECT().InWorkspace(2).Return(mockTeamRecipes).AnyTimes()
+	mockFavorites.EXPECT().InWorkspace(2).Return(mockTeamFavorites).AnyTimes()
+	mockMealPlans.EXPECT().InWorkspace(2).Return(mockTeamMealPlans).AnyTimes()
+	mockShoppingLists.EXPECT().InWorkspace(2).Return(mockTeamShoppingLists).AnyTimes()
+	mockIngredients.EXPECT().InWorkspace(2).Return(mockTeamIngredients).AnyTimes()
+	mockSubstitutions.EXPECT().InWorkspace(2).Return(mockTeamSubstitutions).AnyTimes()
+	mockFacets.EXPECT().InWorkspace(2).Return(mockTeamFacets).AnyTimes()
+
+	mockFavorites.EXPECT().Exists(1, 1).Return(false, nil).AnyTimes()
+	mockFacets.EXPECT().Count(gomock.Any(), gomock.Any()).Return(&models.Facets{}, nil).AnyTimes()
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	teamRecipe := &models.Recipe{ID: 2, Name: "Team pancakes", WorkspaceID: 2}
+	teamPlan := &models.MealPlan{ID: 2, Date: "2024-03-04", Slot: "dinner", RecipeID: 2, RecipeName: teamRecipe.Name, Portions: 4}
+	teamList := &models.ShoppingList{ID: 2, Name: "Team groceries"}
+	teamButter := &models.Ingredient{ID: 7, Name: "butter"}
+	teamSubstitutions := []*models.Substitution{
+		{IngredientID: 7, Substitute: &models.Ingredient{ID: 8, Name: "olive oil"}, Ratio: 0.8},
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		workspace      string
+		anonymous      bool
+		role           string
+		setup          func()
+		expectedStatus int
+		expectedBody   string
+		unexpectedBody string
+	}{
+		{
+			name:   "No Workspace",
+			method: http.MethodGet,
+			path:   "/v1/recipes/1",
+			setup: func() {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   testRecipe.Name,
+		},
+		{
+			name:      "Default Workspace",
+			method:    http.MethodGet,
+			path:      "/v1/recipes/1",
+			workspace: "1",
+			setup: func() {
+				mockRecipes.EXPECT().Get(1).Return(testRecipe, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "Recipe Of Another Workspace By Header",
+			method:    http.MethodGet,
+			path:      "/v1/recipes/1",
+			workspace: "2",
+			role:      models.RoleViewer,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Recipe Of Another Workspace By Path",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/recipes/1",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Recipe Of The Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/recipes/2",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(2).Return(teamRecipe, nil)
+				mockFavorites.EXPECT().Exists(1, 2).Return(false, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   teamRecipe.Name,
+		},
+		{
+			name:   "Listing Only Has The Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/recipes",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamRecipes.EXPECT().List(gomock.Any()).Return([]*models.Recipe{teamRecipe}, 1, nil)
+				mockTeamFacets.EXPECT().Count(gomock.Any(), gomock.Any()).Return(&models.Facets{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   teamRecipe.Name,
+			unexpectedBody: testRecipe.Name,
+		},
+		{
+			name:           "Not A Member",
+			method:         http.MethodGet,
+			path:           "/v1/recipes",
+			workspace:      "2",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Anonymous",
+			method:         http.MethodGet,
+			path:           "/v1/workspaces/2/recipes",
+			anonymous:      true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid Workspace",
+			method:         http.MethodGet,
+			path:           "/v1/recipes",
+			workspace:      "team",
+			anonymous:      true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Viewer Can't Write",
+			method:         http.MethodDelete,
+			path:           "/v1/workspaces/2/recipes/2",
+			role:           models.RoleViewer,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Editor Writes To The Workspace",
+			method: http.MethodDelete,
+			path:   "/v1/workspaces/2/recipes/2",
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Delete(2).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Revisions Of A Recipe Of Another Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/recipes/1/revisions/1",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Restoring A Revision Of A Recipe Of Another Workspace",
+			method: http.MethodPost,
+			path:   "/v1/workspaces/2/recipes/1/revisions/1/restore",
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Favoriting A Recipe Of Another Workspace",
+			method: http.MethodPut,
+			path:   "/v1/workspaces/2/me/favorites/1",
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Favorites Only Have The Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/me/favorites",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamFavorites.EXPECT().GetByUserID(1).Return([]*models.Favorite{{RecipeID: 2, Name: teamRecipe.Name}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   teamRecipe.Name,
+		},
+		{
+			name:   "Meal Plan Of Another Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/meal-plans/1",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamMealPlans.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Meal Plans Only Have The Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/meal-plans?from=2024-03-04&to=2024-03-10",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamMealPlans.EXPECT().GetRange(gomock.Any(), gomock.Any()).Return([]*models.MealPlan{teamPlan}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   teamRecipe.Name,
+		},
+		{
+			name:   "Shopping List Of Another Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/shopping-lists/1",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamShoppingLists.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Shopping Lists Only Have The Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/shopping-lists",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamShoppingLists.EXPECT().GetAll().Return([]*models.ShoppingList{teamList}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   teamList.Name,
+		},
+		{
+			name:   "Image Of A Recipe Of Another Workspace",
+			method: http.MethodDelete,
+			path:   "/v1/workspaces/2/recipes/1/images/1",
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Reviewing A Recipe Of Another Workspace",
+			method: http.MethodPost,
+			path:   "/v1/workspaces/2/recipes/1/reviews",
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(1).Return(nil, models.ErrNoRecord)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Substitutes Of The Workspace",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/ingredients/7/substitutes",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockTeamIngredients.EXPECT().Get(7).Return(teamButter, nil)
+				mockTeamSubstitutions.EXPECT().GetByIngredientID(7).Return(teamSubstitutions, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "olive oil",
+		},
+		{
+			name:   "Substituting In A Recipe Of The Workspace",
+			method: http.MethodPost,
+			path:   "/v1/workspaces/2/recipes/2/substitute",
+			body:   `{"substitutions": [{"ingredient_id": 7, "substitute_id": 8}]}`,
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(2).Return(&models.Recipe{
+					ID:          2,
+					Name:        teamRecipe.Name,
+					WorkspaceID: 2,
+					Ingredients: []*models.FullIngredient{{Ingredient: teamButter, Quantity: 100, Unit: "g"}},
+				}, nil)
+				mockTeamSubstitutions.EXPECT().GetByIngredientID(7).Return(teamSubstitutions, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "olive oil",
+		},
+		{
+			// The pantry is the same in every workspace, the ingredients of the recipe being found in it by name
+			name:   "Cooking A Recipe Of The Workspace",
+			method: http.MethodPost,
+			path:   "/v1/workspaces/2/recipes/2/cooked",
+			role:   models.RoleEditor,
+			setup: func() {
+				mockTeamRecipes.EXPECT().Get(2).Return(&models.Recipe{
+					ID:          2,
+					Name:        teamRecipe.Name,
+					WorkspaceID: 2,
+					Ingredients: []*models.FullIngredient{{Ingredient: teamButter, Quantity: 100, Unit: "g"}},
+				}, nil)
+				mockPantry.EXPECT().Cook(1, 2, gomock.Any()).DoAndReturn(func(userID, recipeID int, ingredients []*models.FullIngredient) error {
+					assert.Len(t, ingredients, 1)
+					assert.Equal(t, teamButter.Name, ingredients[0].Name)
+					return nil
+				})
+				mockPantry.EXPECT().GetByUserID(1).Return([]*models.PantryItem{{IngredientID: 3, Name: "butter", Quantity: 150, Unit: "g"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "butter",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req *http.Request
+			if tc.anonymous {
+				var err error
+				req, err = http.NewRequest(tc.method, ts.URL+tc.path, strings.NewReader(tc.body))
+				assert.NoError(t, err)
+			} else {
+				req = authenticatedRequest(t, mockUsers, tc.method, ts.URL+tc.path, strings.NewReader(tc.body))
+			}
+			if tc.workspace != "" {
+				req.Header.Set("X-Workspace-ID", tc.workspace)
+			}
+
+			if !tc.anonymous && (tc.workspace == "2" || strings.HasPrefix(tc.path, "/v1/workspaces/2/")) {
+				if tc.role == "" {
+					mockWorkspaces.EXPECT().GetRole(2, 1).Return("", models.ErrNoRecord)
+				} else {
+					mockWorkspaces.EXPECT().GetRole(2, 1).Return(tc.role, nil)
+				}
+			}
+			if tc.setup != nil {
+				tc.setup()
+			}
+
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), tc.expectedBody)
+			if tc.unexpectedBody != "" {
+				assert.NotContains(t, string(body), tc.unexpectedBody)
+			}
+		})
+	}
+}
+
+func TestWorkspaceRecommendations(t *testing.T) {
+	app := newTestApplication()
+	app.recommender = newRecommender()
+
+	// A copy of the pancakes in another workspace, which would be the best match for the original
+	app.recommender.Put(&models.Recipe{
+		ID:          10,
+		Name:        "Team pancakes",
+		WorkspaceID: 2,
+		Ingredients: newPatchableRecipe().Ingredients,
+		Tags:        newPatchableRecipe().Tags,
+	})
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/v1/recipes/1/recommendations", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotContains(t, readRecommendedIDs(t, res), 10)
+
+	// Recipes of another workspace can't be looked up from the default one either
+	res, err = ts.Client().Get(fmt.Sprintf("%s/v1/recipes/10/recommendations", ts.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestCreateWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceModelInterface(ctrl)
+	app.users = mockUsers
+	app.workspaces = mockWorkspaces
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "Valid",
+			body:           `{"name": " Test kitchen "}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Empty Name",
+			body:           `{"name": " "}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Name Too Long",
+			body:           fmt.Sprintf(`{"name": %q}`, strings.Repeat("a", 101)),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusCreated {
+				mockWorkspaces.EXPECT().Insert("Test kitchen", 1).Return(2, nil)
+				mockWorkspaces.EXPECT().Get(2).Return(&models.Workspace{ID: 2, Name: "Test kitchen"}, nil)
+			}
+
+			req := authenticatedRequest(t, mockUsers, http.MethodPost, ts.URL+"/v1/workspaces", strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedStatus == http.StatusCreated {
+				body, err := io.ReadAll(res.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), `"role": "owner"`)
+			}
+		})
+	}
+}
+
+func TestWorkspaceMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := newTestApplication()
+
+	mockUsers := mocks.NewMockUserModelInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceModelInterface(ctrl)
+	app.users = mockUsers
+	app.workspaces = mockWorkspaces
+
+	ts := newTestServer(app.routes())
+	defer ts.Close()
+
+	members := []*models.WorkspaceMember{{UserID: 1, Name: "Alice", Email: "alice@example.com", Role: models.RoleOwner}}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		role           string
+		setup          func()
+		expectedStatus int
+	}{
+		{
+			name:   "List",
+			method: http.MethodGet,
+			path:   "/v1/workspaces/2/members",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockWorkspaces.EXPECT().GetMembers(2).Return(members, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "List Without Being A Member",
+			method:         http.MethodGet,
+			path:           "/v1/workspaces/2/members",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Owner Adds A Member",
+			method: http.MethodPut,
+			path:   "/v1/workspaces/2/members/3",
+			body:   `{"role": "Editor"}`,
+			role:   models.RoleOwner,
+			setup: func() {
+				mockWorkspaces.EXPECT().SetMember(2, 3, models.RoleEditor).Return(nil)
+				mockWorkspaces.EXPECT().GetMembers(2).Return(members, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Editor Adds A Member",
+			method:         http.MethodPut,
+			path:           "/v1/workspaces/2/members/3",
+			body:           `{"role": "editor"}`,
+			role:           models.RoleEditor,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Unknown Role",
+			method: http.MethodPut,
+			path:   "/v1/workspaces/2/members/3",
+			body:   `{"role": "admin"}`,
+			role:   models.RoleOwner,
+			setup: func() {
+				mockWorkspaces.EXPECT().SetMember(2, 3, "admin").Return(models.ErrInvalidRole)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Last Owner Steps Down",
+			method: http.MethodPut,
+			path:   "/v1/workspaces/2/members/1",
+			body:   `{"role": "viewer"}`,
+			role:   models.RoleOwner,
+			setup: func() {
+				mockWorkspaces.EXPECT().SetMember(2, 1, models.RoleViewer).Return(models.ErrLastOwner)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Member Leaves",
+			method: http.MethodDelete,
+			path:   "/v1/workspaces/2/members/1",
+			role:   models.RoleViewer,
+			setup: func() {
+				mockWorkspaces.EXPECT().RemoveMember(2, 1).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Member Removes Someone Else",
+			method:         http.MethodDelete,
+			path:           "/v1/workspaces/2/members/3",
+			role:           models.RoleEditor,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.role == "" {
+				mockWorkspaces.EXPECT().GetRole(2, 1).Return("", models.ErrNoRecord)
+			} else {
+				mockWorkspaces.EXPECT().GetRole(2, 1).Return(tc.role, nil)
+			}
+			if tc.setup != nil {
+				tc.setup()
+			}
+
+			req := authenticatedRequest(t, mockUsers, tc.method, ts.URL+tc.path, strings.NewReader(tc.body))
+			res, err := ts.Client().Do(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
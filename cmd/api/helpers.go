@@ -5,12 +5,43 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"runtime/debug"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/schemaorg"
 )
 
 type envelope map[string]any
 
+const (
+	formatJSON     = "json"
+	formatJSONLD   = "jsonld"
+	formatMarkdown = "markdown"
+	formatText     = "text"
+	formatPDF      = "pdf"
+	formatICS      = "ics"
+)
+
+var formatMediaTypes = map[string]string{
+	formatJSON:     "application/json",
+	formatJSONLD:   "application/ld+json",
+	formatMarkdown: "text/markdown",
+	formatText:     "text/plain",
+	formatPDF:      "application/pdf",
+	formatICS:      "text/calendar",
+}
+
+var (
+	errUnknownFormat = errors.New("unknown format")
+	errNotAcceptable = errors.New("none of the acceptable media types can be produced")
+)
+
 func (app *application) clientError(w http.ResponseWriter, status int) {
 	http.Error(w, http.StatusText(status), status)
 }
@@ -24,6 +55,204 @@ func (app *application) serverError(w http.ResponseWriter, err error) {
 	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 }
 
+// authenticationError asks the client to authenticate, with a password or an API key, or to do it
+// again with the right credentials.
+func (app *application) authenticationError(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="tasty-byte", charset="UTF-8"`)
+	w.Header().Add("WWW-Authenticate", `Bearer realm="tasty-byte"`)
+	app.clientError(w, http.StatusUnauthorized)
+}
+
+// readIDParam reads a positive integer identifier from the named URL parameter.
+func (app *application) readIDParam(r *http.Request, name string) (int, error) {
+	params := httprouter.ParamsFromContext(r.Context())
+	id, err := strconv.Atoi(params.ByName(name))
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid %s parameter", name)
+	}
+	return id, nil
+}
+
+// readListParam reads the values of a query parameter that can be repeated or comma separated,
+// e.g. "?diet=vegan,halal" or "?diet=vegan&diet=halal".
+func (app *application) readListParam(r *http.Request, name string) []string {
+	var values []string
+	for _, param := range r.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// readOffset reads the "?offset=" number of results to skip, zero when it isn't given.
+func (app *application) readOffset(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("offset")
+	if value == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid offset parameter")
+	}
+	return offset, nil
+}
+
+// readLimit reads the "?limit=" number of results, between 1 and max, or returns def when it isn't given.
+func (app *application) readLimit(r *http.Request, def, max int) (int, error) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return def, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > max {
+		return 0, errors.New("invalid limit parameter")
+	}
+	return limit, nil
+}
+
+// recipesFor returns the recipe model of the workspace the request is in.
+func (app *application) recipesFor(r *http.Request) models.RecipeModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.recipes.InWorkspace(workspaceID)
+	}
+	return app.recipes
+}
+
+// readRecipe reads the recipe whose ID is in the named URL parameter from the workspace the request is in,
+// so that nothing hanging off a recipe can be reached from another workspace. It writes the error
+// response itself, a 404 when the recipe isn't in the workspace, and returns false then.
+func (app *application) readRecipe(w http.ResponseWriter, r *http.Request, name string) (*models.Recipe, bool) {
+	id, err := app.readIDParam(r, name)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return nil, false
+	}
+
+	recipe, err := app.recipesFor(r).Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			app.clientError(w, http.StatusNotFound)
+		default:
+			app.serverError(w, err)
+		}
+		return nil, false
+	}
+
+	return recipe, true
+}
+
+// tagsFor returns the tag model of the workspace the request is in.
+func (app *application) tagsFor(r *http.Request) models.TagModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.tags.InWorkspace(workspaceID)
+	}
+	return app.tags
+}
+
+// ingredientsFor returns the ingredient model of the workspace the request is in.
+func (app *application) ingredientsFor(r *http.Request) models.IngredientModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.ingredients.InWorkspace(workspaceID)
+	}
+	return app.ingredients
+}
+
+// favoritesFor returns the favorite model of the workspace the request is in.
+func (app *application) favoritesFor(r *http.Request) models.FavoriteModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.favorites.InWorkspace(workspaceID)
+	}
+	return app.favorites
+}
+
+// mealPlansFor returns the meal plan model of the workspace the request is in.
+func (app *application) mealPlansFor(r *http.Request) models.MealPlanModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.mealPlans.InWorkspace(workspaceID)
+	}
+	return app.mealPlans
+}
+
+// shoppingListsFor returns the shopping list model of the workspace the request is in.
+func (app *application) shoppingListsFor(r *http.Request) models.ShoppingListModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.shoppingLists.InWorkspace(workspaceID)
+	}
+	return app.shoppingLists
+}
+
+// substitutionsFor returns the substitution model of the workspace the request is in.
+func (app *application) substitutionsFor(r *http.Request) models.SubstitutionModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.substitutions.InWorkspace(workspaceID)
+	}
+	return app.substitutions
+}
+
+// facetsFor returns the facet model of the workspace the request is in.
+func (app *application) facetsFor(r *http.Request) models.FacetModelInterface {
+	if workspaceID := app.contextGetWorkspaceID(r); workspaceID != models.DefaultWorkspaceID {
+		return app.facets.InWorkspace(workspaceID)
+	}
+	return app.facets
+}
+
+// validSteps checks that every step has a text and, when set, a duration in the short form
+// recipes use for their times, e.g. "10m" or "1h 30m".
+func validSteps(steps []*models.Step) bool {
+	for _, step := range steps {
+		if step == nil || strings.TrimSpace(step.Text) == "" {
+			return false
+		}
+		if _, ok := schemaorg.ParseDuration(step.Duration); step.Duration != "" && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validRecipe checks a recipe before it is saved: that it has a name, that its ingredients and tags
+// have names and that nothing is longer, or larger, than the database stores, so that recipes from
+// any source fail the same way rather than with a database error.
+func validRecipe(recipe *models.Recipe) bool {
+	name := strings.TrimSpace(recipe.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return false
+	}
+	if utf8.RuneCountInString(recipe.PreparationTime) > 10 || utf8.RuneCountInString(recipe.CookingTime) > 10 {
+		return false
+	}
+	if recipe.Portions < 0 {
+		return false
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil || ingredient.Name == "" {
+			return false
+		}
+		if utf8.RuneCountInString(ingredient.Name) > 255 || utf8.RuneCountInString(ingredient.Unit) > 50 {
+			return false
+		}
+		if ingredient.Quantity < 0 || ingredient.Quantity > models.MaxQuantity {
+			return false
+		}
+	}
+
+	for _, tag := range recipe.Tags {
+		if tag == nil || tag.Name == "" || utf8.RuneCountInString(tag.Name) > 255 {
+			return false
+		}
+	}
+
+	return validSteps(recipe.Steps)
+}
+
 func (app *application) readJSON(w http.ResponseWriter, r *http.Request, dst any) error {
 	const maxBytes = 1_048_576
 	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes))
@@ -51,8 +280,9 @@ func (app *application) writeJSON(w http.ResponseWriter, status int, data envelo
 	}
 	js = append(js, '\n')
 
+	// Added rather than set, so that e.g. a "Vary" from the middleware is kept
 	for key, value := range headers {
-		w.Header()[key] = value
+		w.Header()[key] = append(w.Header()[key], value...)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -65,3 +295,56 @@ func (app *application) writeJSON(w http.ResponseWriter, status int, data envelo
 
 	return nil
 }
+
+// negotiateFormat picks one of the offered formats, in order of preference, for the response.
+// An explicit "?format=" query parameter wins over the Accept header.
+func (app *application) negotiateFormat(r *http.Request, offers ...string) (string, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		for _, offer := range offers {
+			if offer == format {
+				return offer, nil
+			}
+		}
+		return "", errUnknownFormat
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return offers[0], nil
+	}
+
+	var (
+		best        string
+		bestQuality float64
+	)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, exists := params["q"]; exists {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= bestQuality {
+			continue
+		}
+
+		for _, offer := range offers {
+			offered := formatMediaTypes[offer]
+			if mediaType == offered || mediaType == "*/*" || (strings.HasSuffix(mediaType, "/*") && strings.HasPrefix(offered, strings.TrimSuffix(mediaType, "*"))) {
+				best, bestQuality = offer, quality
+				break
+			}
+		}
+	}
+
+	if best == "" {
+		return "", errNotAcceptable
+	}
+
+	return best, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"crypto/tls"
 	"database/sql"
+	"errors"
 	"github.com/spf13/viper"
 	"log"
 	"net/http"
@@ -11,22 +12,50 @@ import (
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/vladComan0/tasty-byte/internal/blobstore"
+	"github.com/vladComan0/tasty-byte/internal/dietary"
 	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/nutrition"
+	"github.com/vladComan0/tasty-byte/internal/recommend"
+	"github.com/vladComan0/tasty-byte/internal/substitutions"
 )
 
 type config struct {
-	Addr           string   `mapstructure:"addr"`
-	Environment    string   `mapstructure:"environment"`
-	DSN            string   `mapstructure:"dsn"`
-	DebugEnabled   bool     `mapstructure:"debug_enabled"`
-	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	Addr               string        `mapstructure:"addr"`
+	Environment        string        `mapstructure:"environment"`
+	DSN                string        `mapstructure:"dsn"`
+	DebugEnabled       bool          `mapstructure:"debug_enabled"`
+	AllowedOrigins     []string      `mapstructure:"allowed_origins"`
+	TrashRetention     time.Duration `mapstructure:"trash_retention"`
+	TrashPurgeInterval time.Duration `mapstructure:"trash_purge_interval"`
+	ImagesDir          string        `mapstructure:"images_dir"`
+	MaxImageSize       int64         `mapstructure:"max_image_size"`
 }
 
 type application struct {
-	config   config
-	infoLog  *log.Logger
-	errorLog *log.Logger
-	recipes  models.RecipeModelInterface
+	config        config
+	infoLog       *log.Logger
+	errorLog      *log.Logger
+	recipes       models.RecipeModelInterface
+	revisions     models.RecipeRevisionModelInterface
+	images        models.RecipeImageModelInterface
+	blobs         blobstore.Store
+	nutrition     models.NutritionModelInterface
+	ingredients   models.IngredientModelInterface
+	shoppingLists models.ShoppingListModelInterface
+	mealPlans     models.MealPlanModelInterface
+	users         models.UserModelInterface
+	pantry        models.PantryModelInterface
+	substitutions models.SubstitutionModelInterface
+	reviews       models.ReviewModelInterface
+	favorites     models.FavoriteModelInterface
+	collections   models.CollectionModelInterface
+	history       models.CookingHistoryModelInterface
+	tags          models.TagModelInterface
+	facets        models.FacetModelInterface
+	workspaces    models.WorkspaceModelInterface
+	apiKeys       models.APIKeyModelInterface
+	recommender   *recommend.Index
 }
 
 func main() {
@@ -61,20 +90,149 @@ func main() {
 		DB: db,
 	}
 
+	recipeRevisionModel := &models.RecipeRevisionModel{
+		DB: db,
+	}
+
+	recipeStepModel := &models.RecipeStepModel{
+		DB: db,
+	}
+
+	blobs, err := blobstore.NewLocal(config.ImagesDir)
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+
+	recipeImageModel := &models.RecipeImageModel{
+		DB:    db,
+		Store: blobs,
+	}
+
+	nutritionModel := &models.NutritionModel{
+		DB: db,
+	}
+
+	facts, err := nutrition.Bundled()
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	if err := nutritionModel.Upsert(facts); err != nil {
+		errorLog.Fatal(err)
+	}
+
+	classifications, err := dietary.Bundled()
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	if err := ingredientModel.Seed(classifications); err != nil {
+		errorLog.Fatal(err)
+	}
+
+	// Ingredients are resolved through their aliases, which the ones added by name don't have yet
+	aliased, err := ingredientModel.BackfillAliases()
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	if aliased > 0 {
+		infoLog.Printf("Added aliases for %d ingredients", aliased)
+	}
+
+	substitutionModel := &models.SubstitutionModel{
+		DB:              db,
+		IngredientModel: ingredientModel,
+	}
+
+	rules, err := substitutions.Bundled()
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	if err := substitutionModel.Seed(rules); err != nil {
+		errorLog.Fatal(err)
+	}
+
+	recipeModel := &models.RecipeModel{
+		DB:                    db,
+		IngredientModel:       ingredientModel,
+		RecipeIngredientModel: recipeIngredientModel,
+		TagModel:              tagModel,
+		RecipeTagModel:        recipeTagModel,
+		RecipeRevisionModel:   recipeRevisionModel,
+		RecipeStepModel:       recipeStepModel,
+		RecipeImageModel:      recipeImageModel,
+	}
+
+	workspaceModel := &models.WorkspaceModel{
+		DB: db,
+	}
+
+	// The recommendations are worked out from an index of the recipes of every workspace, built once
+	// here and then refreshed by the recipe model as recipes change
+	workspaces, err := workspaceModel.GetAll()
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	recommender := recommend.NewIndex()
+	for _, workspace := range workspaces {
+		recipes, err := recipeModel.InWorkspace(workspace.ID).GetAll()
+		if err != nil && !errors.Is(err, models.ErrNoRecord) {
+			errorLog.Fatal(err)
+		}
+		for _, recipe := range recipes {
+			recommender.Put(recipe)
+		}
+	}
+	recipeModel.Index = recommender
+
 	// dependency injection
 	app := &application{
-		config:   config,
-		infoLog:  infoLog,
-		errorLog: errorLog,
-		recipes: &models.RecipeModel{
-			DB:                    db,
-			IngredientModel:       ingredientModel,
-			RecipeIngredientModel: recipeIngredientModel,
-			TagModel:              tagModel,
-			RecipeTagModel:        recipeTagModel,
+		config:      config,
+		infoLog:     infoLog,
+		errorLog:    errorLog,
+		recipes:     recipeModel,
+		revisions:   recipeRevisionModel,
+		images:      recipeImageModel,
+		blobs:       blobs,
+		nutrition:   nutritionModel,
+		ingredients: ingredientModel,
+		shoppingLists: &models.ShoppingListModel{
+			DB: db,
+		},
+		mealPlans: &models.MealPlanModel{
+			DB: db,
+		},
+		users: &models.UserModel{
+			DB: db,
+		},
+		pantry: &models.PantryModel{
+			DB:              db,
+			IngredientModel: ingredientModel,
+		},
+		substitutions: substitutionModel,
+		reviews: &models.ReviewModel{
+			DB: db,
+		},
+		favorites: &models.FavoriteModel{
+			DB: db,
+		},
+		collections: &models.CollectionModel{
+			DB: db,
+		},
+		history: &models.CookingHistoryModel{
+			DB: db,
+		},
+		recommender: recommender,
+		tags:        tagModel,
+		facets: &models.FacetModel{
+			DB: db,
+		},
+		workspaces: workspaceModel,
+		apiKeys: &models.APIKeyModel{
+			DB: db,
 		},
 	}
 
+	go app.purgeTrash(config.TrashPurgeInterval, config.TrashRetention)
+
 	tlsConfig := &tls.Config{
 		CurvePreferences: []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
 		CipherSuites: []uint16{
@@ -105,6 +263,10 @@ func getConfig(errorLog *log.Logger, config *config) {
 	viper.SetConfigName("config")
 	viper.AddConfigPath(".")
 	viper.SetConfigType("yaml")
+	viper.SetDefault("trash_retention", "720h")
+	viper.SetDefault("trash_purge_interval", "1h")
+	viper.SetDefault("images_dir", "data/images")
+	viper.SetDefault("max_image_size", 10*1_048_576)
 	if err := viper.ReadInConfig(); err != nil {
 		errorLog.Fatalf("Error reading config file, %s", err)
 	}
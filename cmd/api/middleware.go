@@ -1,10 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/rs/cors"
+	"github.com/vladComan0/tasty-byte/internal/models"
 )
 
 func (app *application) logRequests(next http.Handler) http.Handler {
@@ -33,11 +37,242 @@ func (app *application) recoverPanic(next http.Handler) http.Handler {
 func (app *application) enableCORS(next http.Handler) http.Handler {
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   app.config.AllowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", workspaceHeader},
 		AllowCredentials: true,
 		Debug:            false,
 	})
 
 	return corsHandler.Handler(next)
 }
+
+// authenticate identifies the user making the request, either from its HTTP Basic credentials or from
+// the API key it carries as a bearer token. Requests without credentials go through anonymously, ones
+// with wrong credentials are rejected.
+func (app *application) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Authorization")
+
+		authorization := r.Header.Get("Authorization")
+		if authorization == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if scheme, token, _ := strings.Cut(authorization, " "); strings.EqualFold(scheme, "Bearer") {
+			key, err := app.apiKeys.Authenticate(strings.TrimSpace(token))
+			if err != nil {
+				switch {
+				case errors.Is(err, models.ErrInvalidCredentials):
+					app.authenticationError(w)
+				default:
+					app.serverError(w, err)
+				}
+				return
+			}
+
+			r = app.contextSetAPIKey(r, key)
+			next.ServeHTTP(w, app.contextSetUserID(r, key.UserID))
+			return
+		}
+
+		email, password, ok := r.BasicAuth()
+		if !ok {
+			app.authenticationError(w)
+			return
+		}
+
+		userID, err := app.users.Authenticate(email, password)
+		if err != nil {
+			switch {
+			case errors.Is(err, models.ErrInvalidCredentials):
+				app.authenticationError(w)
+			default:
+				app.serverError(w, err)
+			}
+			return
+		}
+
+		next.ServeHTTP(w, app.contextSetUserID(r, userID))
+	})
+}
+
+// requireAuthentication only lets authenticated users through to the handler.
+func (app *application) requireAuthentication(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if app.contextGetUserID(r) == 0 {
+			app.authenticationError(w)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// requireAdmin only lets administrators through to the handler, whether they authenticated with
+// their password or an API key.
+func (app *application) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return app.requireAuthentication(func(w http.ResponseWriter, r *http.Request) {
+		user, err := app.users.Get(app.contextGetUserID(r))
+		if err != nil {
+			switch {
+			case errors.Is(err, models.ErrNoRecord):
+				app.authenticationError(w)
+			default:
+				app.serverError(w, err)
+			}
+			return
+		}
+
+		if !user.Admin {
+			app.clientError(w, http.StatusForbidden)
+			return
+		}
+
+		next(w, r)
+	})
+}
+
+// workspaceHeader selects the workspace of a request by its ID.
+const workspaceHeader = "X-Workspace-ID"
+
+// selectWorkspace puts the request in the workspace it selects, either with the X-Workspace-ID header or
+// by prefixing its path, e.g. "/v1/workspaces/2/recipes" standing for "/v1/recipes" in workspace 2.
+// Only members get into a workspace, and viewers only to read it. Requests that select no workspace,
+// or the default one, work on the shared default catalogue as before.
+func (app *application) selectWorkspace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", workspaceHeader)
+
+		value, path, inPath := workspacePath(r.URL.Path)
+		if inPath {
+			r = r.Clone(r.Context())
+			r.URL.Path, r.URL.RawPath = path, ""
+		} else {
+			value = r.Header.Get(workspaceHeader)
+		}
+
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		workspaceID, err := strconv.Atoi(value)
+		if err != nil || workspaceID < 1 {
+			app.clientError(w, http.StatusBadRequest)
+			return
+		}
+		if workspaceID == models.DefaultWorkspaceID {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID := app.contextGetUserID(r)
+		if userID == 0 {
+			app.authenticationError(w)
+			return
+		}
+
+		// Workspaces the user isn't a member of might as well not exist
+		role, err := app.workspaces.GetRole(workspaceID, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, models.ErrNoRecord):
+				app.clientError(w, http.StatusNotFound)
+			default:
+				app.serverError(w, err)
+			}
+			return
+		}
+
+		if !safeMethod(r.Method) && !models.RoleAllows(role, models.RoleEditor) {
+			app.clientError(w, http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, app.contextSetWorkspaceID(r, workspaceID))
+	})
+}
+
+// workspacePath splits a path within a workspace, such as "/v1/workspaces/2/recipes", into the workspace
+// and the path it stands for, "/v1/recipes". The paths of the workspaces themselves, such as the one of
+// their members, aren't within a workspace.
+func workspacePath(path string) (workspace, rest string, ok bool) {
+	after, found := strings.CutPrefix(path, "/v1/workspaces/")
+	if !found {
+		return "", "", false
+	}
+
+	workspace, rest, found = strings.Cut(after, "/")
+	if !found || rest == "" || rest == "members" || strings.HasPrefix(rest, "members/") {
+		return "", "", false
+	}
+	return workspace, "/v1/" + rest, true
+}
+
+// safeMethod tells whether requests with the method only read.
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// enforceScopes only lets requests authenticated with an API key through when the key has the scope
+// of the route, see routeScope. API keys can't manage API keys, whatever their scopes. Requests
+// authenticated otherwise, or not at all, aren't limited by scopes; the administration endpoints
+// check that the user is an administrator on top of that.
+func (app *application) enforceScopes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := app.contextGetAPIKey(r)
+		if key == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		path := r.URL.Path
+		if path == "/v1/api-keys" || strings.HasPrefix(path, "/v1/api-keys/") {
+			app.clientError(w, http.StatusForbidden)
+			return
+		}
+
+		if !key.Allows(routeScope(r.Method, path)) {
+			app.clientError(w, http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeScope returns the scope an API key needs for a route: the admin scope for the administration
+// endpoints, the read or write scope, depending on the method, of the collections, the pantry or the
+// workspaces for their routes, so that keys given to recipe tools can't reach them, and the recipes
+// read or write scope for the rest. Listing the recipes cookable from the pantry reads it, and
+// marking a recipe as cooked changes it.
+func routeScope(method, path string) string {
+	read, write := models.ScopeRecipesRead, models.ScopeRecipesWrite
+	switch {
+	case strings.HasPrefix(path, "/v1/admin/"):
+		return models.ScopeAdmin
+	case path == "/v1/collections" || strings.HasPrefix(path, "/v1/collections/"):
+		read, write = models.ScopeCollectionsRead, models.ScopeCollectionsWrite
+	case path == "/v1/me/pantry" || strings.HasPrefix(path, "/v1/me/pantry/"),
+		path == "/v1/recipes/cookable", cookedPath(path):
+		read, write = models.ScopePantryRead, models.ScopePantryWrite
+	case path == "/v1/workspaces" || strings.HasPrefix(path, "/v1/workspaces/"):
+		// The paths within a workspace have been rewritten by selectWorkspace by now
+		read, write = models.ScopeWorkspacesRead, models.ScopeWorkspacesWrite
+	}
+
+	if safeMethod(method) {
+		return read
+	}
+	return write
+}
+
+// cookedPath tells whether the path is the one marking a recipe as cooked, "/v1/recipes/:id/cooked".
+func cookedPath(path string) bool {
+	id, found := strings.CutPrefix(path, "/v1/recipes/")
+	if !found {
+		return false
+	}
+	id, found = strings.CutSuffix(id, "/cooked")
+	return found && id != "" && !strings.Contains(id, "/")
+}
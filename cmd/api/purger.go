@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// purgeTrash periodically hard-deletes the recipes that have been in the trash
+// for longer than the configured retention period. It is meant to be run in its own goroutine.
+func (app *application) purgeTrash(interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		app.purgeTrashOnce(retention)
+	}
+}
+
+func (app *application) purgeTrashOnce(retention time.Duration) {
+	defer func() {
+		if err := recover(); err != nil {
+			app.errorLog.Printf("Recovered from panic while purging the trash: %s", fmt.Sprint(err))
+		}
+	}()
+
+	purged, err := app.recipes.Purge(retention)
+	if err != nil {
+		app.errorLog.Printf("Unable to purge the trash: %v", err)
+		return
+	}
+
+	if purged > 0 {
+		app.infoLog.Printf("Purged %d recipes from the trash", purged)
+	}
+}
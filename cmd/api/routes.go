@@ -14,12 +14,149 @@ func (app *application) routes() http.Handler {
 
 	// CRUD
 	router.Handler(http.MethodPost, "/v1/recipes", http.HandlerFunc(app.createRecipe))
-	router.Handler(http.MethodGet, "/v1/recipes/:id", http.HandlerFunc(app.getRecipe))
+	router.Handler(http.MethodGet, "/v1/recipes/:id", app.dispatchStatic("id", map[string]http.HandlerFunc{
+		"cookable": app.requireAuthentication(app.listCookableRecipes),
+	}, app.getRecipe))
 	router.Handler(http.MethodPut, "/v1/recipes/:id", http.HandlerFunc(app.updateRecipe))
+	router.Handler(http.MethodPatch, "/v1/recipes/:id", http.HandlerFunc(app.patchRecipe))
 	router.Handler(http.MethodDelete, "/v1/recipes/:id", http.HandlerFunc(app.deleteRecipe))
 	router.Handler(http.MethodGet, "/v1/recipes", http.HandlerFunc(app.listRecipes))
 
-	standardChain := alice.New(app.recoverPanic, app.logRequests, app.enableCORS)
+	// Import
+	// httprouter doesn't allow a static segment next to the ":id" wildcard, so it is dispatched from there
+	router.Handler(http.MethodPost, "/v1/recipes/:id", app.dispatchStatic("id", map[string]http.HandlerFunc{
+		"import": app.importRecipe,
+	}, nil))
+
+	// Bulk import/export
+	router.Handler(http.MethodGet, "/v1/export", http.HandlerFunc(app.exportRecipes))
+	router.Handler(http.MethodPost, "/v1/import", http.HandlerFunc(app.importRecipes))
+
+	// Images
+	router.Handler(http.MethodPost, "/v1/recipes/:id/images", http.HandlerFunc(app.uploadRecipeImage))
+	router.Handler(http.MethodDelete, "/v1/recipes/:id/images/:image", http.HandlerFunc(app.deleteRecipeImage))
+	router.Handler(http.MethodGet, "/v1/images/*key", http.HandlerFunc(app.serveImage))
+
+	// Nutrition
+	router.Handler(http.MethodGet, "/v1/recipes/:id/nutrition", http.HandlerFunc(app.getRecipeNutrition))
+
+	// Ingredients
+	router.Handler(http.MethodGet, "/v1/ingredients/:id", http.HandlerFunc(app.getIngredient))
+	router.Handler(http.MethodPut, "/v1/ingredients/:id/classification", http.HandlerFunc(app.classifyIngredient))
+	router.Handler(http.MethodPost, "/v1/admin/ingredients/:id/aliases", app.requireAdmin(app.addIngredientAlias))
+	router.Handler(http.MethodDelete, "/v1/admin/ingredients/:id/aliases/:alias", app.requireAdmin(app.removeIngredientAlias))
+	router.Handler(http.MethodPost, "/v1/admin/ingredients/:id/merge", app.requireAdmin(app.mergeIngredients))
+
+	// Tags
+	router.Handler(http.MethodGet, "/v1/tags/tree", http.HandlerFunc(app.getTagTree))
+	router.Handler(http.MethodPut, "/v1/tags/:id", http.HandlerFunc(app.updateTag))
+
+	// Substitutions
+	router.Handler(http.MethodGet, "/v1/ingredients/:id/substitutes", http.HandlerFunc(app.listSubstitutes))
+	router.Handler(http.MethodPost, "/v1/recipes/:id/substitute", http.HandlerFunc(app.substituteIngredients))
+
+	// Forks
+	router.Handler(http.MethodPost, "/v1/recipes/:id/fork", http.HandlerFunc(app.forkRecipe))
+	router.Handler(http.MethodGet, "/v1/recipes/:id/variants", http.HandlerFunc(app.listRecipeVariants))
+	router.Handler(http.MethodGet, "/v1/recipes/:id/diff", http.HandlerFunc(app.diffRecipeFork))
+
+	// Similarity
+	router.Handler(http.MethodGet, "/v1/recipes/:id/similar", http.HandlerFunc(app.getSimilarRecipes))
+	router.Handler(http.MethodGet, "/v1/admin/duplicates", app.requireAdmin(app.listDuplicates))
+
+	// Recommendations
+	router.Handler(http.MethodGet, "/v1/recipes/:id/recommendations", http.HandlerFunc(app.getRecipeRecommendations))
+	router.Handler(http.MethodGet, "/v1/me/recommendations", app.requireAuthentication(app.getUserRecommendations))
+
+	// Reviews
+	router.Handler(http.MethodGet, "/v1/recipes/:id/reviews", http.HandlerFunc(app.listReviews))
+	router.Handler(http.MethodPost, "/v1/recipes/:id/reviews", app.requireAuthentication(app.createReview))
+	router.Handler(http.MethodPut, "/v1/recipes/:id/reviews/:review", app.requireAuthentication(app.updateReview))
+	router.Handler(http.MethodDelete, "/v1/recipes/:id/reviews/:review", app.requireAuthentication(app.deleteReview))
+
+	// Favorites
+	router.Handler(http.MethodGet, "/v1/me/favorites", app.requireAuthentication(app.listFavorites))
+	router.Handler(http.MethodPut, "/v1/me/favorites/:recipe", app.requireAuthentication(app.addFavorite))
+	router.Handler(http.MethodDelete, "/v1/me/favorites/:recipe", app.requireAuthentication(app.removeFavorite))
+
+	// Collections
+	router.Handler(http.MethodPost, "/v1/collections", app.requireAuthentication(app.createCollection))
+	router.Handler(http.MethodGet, "/v1/collections", app.requireAuthentication(app.listCollections))
+	router.Handler(http.MethodGet, "/v1/collections/:id", app.requireAuthentication(app.getCollection))
+	router.Handler(http.MethodPut, "/v1/collections/:id", app.requireAuthentication(app.updateCollection))
+	router.Handler(http.MethodDelete, "/v1/collections/:id", app.requireAuthentication(app.deleteCollection))
+	router.Handler(http.MethodPost, "/v1/collections/:id/recipes", app.requireAuthentication(app.addCollectionRecipe))
+	router.Handler(http.MethodDelete, "/v1/collections/:id/recipes/:recipe", app.requireAuthentication(app.removeCollectionRecipe))
+	router.Handler(http.MethodGet, "/v1/shared/collections/:token", http.HandlerFunc(app.getSharedCollection))
+
+	// Shopping lists
+	router.Handler(http.MethodPost, "/v1/shopping-lists", http.HandlerFunc(app.createShoppingList))
+	router.Handler(http.MethodGet, "/v1/shopping-lists", http.HandlerFunc(app.listShoppingLists))
+	router.Handler(http.MethodGet, "/v1/shopping-lists/:id", http.HandlerFunc(app.getShoppingList))
+	router.Handler(http.MethodDelete, "/v1/shopping-lists/:id", http.HandlerFunc(app.deleteShoppingList))
+	router.Handler(http.MethodPatch, "/v1/shopping-lists/:id/items/:item", http.HandlerFunc(app.checkShoppingItem))
+
+	// Meal plans
+	router.Handler(http.MethodPost, "/v1/meal-plans", http.HandlerFunc(app.createMealPlan))
+	router.Handler(http.MethodGet, "/v1/meal-plans", http.HandlerFunc(app.listMealPlans))
+	router.Handler(http.MethodGet, "/v1/meal-plans.ics", http.HandlerFunc(app.exportMealPlans))
+	router.Handler(http.MethodGet, "/v1/meal-plans/:id", http.HandlerFunc(app.getMealPlan))
+	router.Handler(http.MethodPut, "/v1/meal-plans/:id", http.HandlerFunc(app.updateMealPlan))
+	router.Handler(http.MethodDelete, "/v1/meal-plans/:id", http.HandlerFunc(app.deleteMealPlan))
+	router.Handler(http.MethodPost, "/v1/meal-plans/shopping-list", http.HandlerFunc(app.createMealPlanShoppingList))
+
+	// Users
+	router.Handler(http.MethodPost, "/v1/users", http.HandlerFunc(app.registerUser))
+	router.Handler(http.MethodGet, "/v1/me", app.requireAuthentication(app.getCurrentUser))
+
+	// API keys
+	router.Handler(http.MethodPost, "/v1/api-keys", app.requireAuthentication(app.createAPIKey))
+	router.Handler(http.MethodGet, "/v1/api-keys", app.requireAuthentication(app.listAPIKeys))
+	router.Handler(http.MethodDelete, "/v1/api-keys/:id", app.requireAuthentication(app.revokeAPIKey))
+
+	// Pantry
+	router.Handler(http.MethodGet, "/v1/me/pantry", app.requireAuthentication(app.getPantry))
+	router.Handler(http.MethodPost, "/v1/me/pantry", app.requireAuthentication(app.setPantryItem))
+	router.Handler(http.MethodDelete, "/v1/me/pantry/:ingredient", app.requireAuthentication(app.deletePantryItem))
+	router.Handler(http.MethodPost, "/v1/recipes/:id/cooked", app.requireAuthentication(app.cookRecipe))
+
+	// Workspaces
+	router.Handler(http.MethodPost, "/v1/workspaces", app.requireAuthentication(app.createWorkspace))
+	router.Handler(http.MethodGet, "/v1/workspaces", app.requireAuthentication(app.listWorkspaces))
+	router.Handler(http.MethodGet, "/v1/workspaces/:id", app.requireAuthentication(app.getWorkspace))
+	router.Handler(http.MethodGet, "/v1/workspaces/:id/members", app.requireAuthentication(app.listWorkspaceMembers))
+	router.Handler(http.MethodPut, "/v1/workspaces/:id/members/:user", app.requireAuthentication(app.setWorkspaceMember))
+	router.Handler(http.MethodDelete, "/v1/workspaces/:id/members/:user", app.requireAuthentication(app.removeWorkspaceMember))
+
+	// Trash
+	router.Handler(http.MethodGet, "/v1/trash/recipes", http.HandlerFunc(app.listTrashedRecipes))
+	router.Handler(http.MethodPost, "/v1/recipes/:id/restore", http.HandlerFunc(app.restoreRecipe))
+
+	// Revisions
+	router.Handler(http.MethodGet, "/v1/recipes/:id/revisions", http.HandlerFunc(app.listRecipeRevisions))
+	router.Handler(http.MethodGet, "/v1/recipes/:id/revisions/:rev", http.HandlerFunc(app.getRecipeRevision))
+	router.Handler(http.MethodGet, "/v1/recipes/:id/revisions/:rev/diff", http.HandlerFunc(app.diffRecipeRevision))
+	router.Handler(http.MethodPost, "/v1/recipes/:id/revisions/:rev/restore", http.HandlerFunc(app.restoreRecipeRevision))
+
+	standardChain := alice.New(app.recoverPanic, app.logRequests, app.enableCORS, app.authenticate, app.selectWorkspace, app.enforceScopes)
 
 	return standardChain.Then(router)
 }
+
+// dispatchStatic routes requests whose wildcard parameter matches one of the static names to the
+// associated handler, and everything else to the fallback (or a 404 if there is none).
+func (app *application) dispatchStatic(param string, static map[string]http.HandlerFunc, fallback http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		if handler, exists := static[params.ByName(param)]; exists {
+			handler(w, r)
+			return
+		}
+
+		if fallback == nil {
+			app.clientError(w, http.StatusNotFound)
+			return
+		}
+		fallback(w, r)
+	})
+}
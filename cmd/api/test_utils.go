@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/vladComan0/tasty-byte/internal/mocks"
+	"github.com/vladComan0/tasty-byte/internal/recommend"
 	"io"
 	"log"
 	"net/http"
@@ -21,7 +22,28 @@ func newTestApplication() *application {
 			TagModel:              &mocks.MockTagModelInterface{},
 			RecipeIngredientModel: &mocks.MockRecipeIngredientModelInterface{},
 			RecipeTagModel:        &mocks.MockRecipeTagModelInterface{},
+			RecipeRevisionModel:   &mocks.MockRecipeRevisionModelInterface{},
+			RecipeStepModel:       &mocks.MockRecipeStepModelInterface{},
+			RecipeImageModel:      &mocks.MockRecipeImageModelInterface{},
 		},
+		revisions:     &mocks.MockRecipeRevisionModelInterface{},
+		images:        &mocks.MockRecipeImageModelInterface{},
+		nutrition:     &mocks.MockNutritionModelInterface{},
+		ingredients:   &mocks.MockIngredientModelInterface{},
+		shoppingLists: &mocks.MockShoppingListModelInterface{},
+		mealPlans:     &mocks.MockMealPlanModelInterface{},
+		users:         &mocks.MockUserModelInterface{},
+		pantry:        &mocks.MockPantryModelInterface{},
+		substitutions: &mocks.MockSubstitutionModelInterface{},
+		reviews:       &mocks.MockReviewModelInterface{},
+		favorites:     &mocks.MockFavoriteModelInterface{},
+		collections:   &mocks.MockCollectionModelInterface{},
+		history:       &mocks.MockCookingHistoryModelInterface{},
+		tags:          &mocks.MockTagModelInterface{},
+		facets:        &mocks.MockFacetModelInterface{},
+		workspaces:    &mocks.MockWorkspaceModelInterface{},
+		apiKeys:       &mocks.MockAPIKeyModelInterface{},
+		recommender:   recommend.NewIndex(),
 	}
 }
 
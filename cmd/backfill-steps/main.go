@@ -0,0 +1,48 @@
+// Command backfill-steps splits the instructions of the recipes created before structured steps existed
+// into steps. It is run once, after upgrading a database that has such recipes, with the configuration
+// of the API.
+package main
+
+import (
+	"database/sql"
+	"github.com/spf13/viper"
+	"log"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func main() {
+	infoLog := log.New(os.Stdout, "INFO\t", log.Ldate|log.Ltime)
+	errorLog := log.New(os.Stderr, "ERROR\t", log.Ldate|log.Ltime|log.Lshortfile)
+
+	viper.SetConfigName("config")
+	viper.AddConfigPath(".")
+	viper.SetConfigType("yaml")
+	if err := viper.ReadInConfig(); err != nil {
+		errorLog.Fatalf("Error reading config file, %s", err)
+	}
+
+	db, err := sql.Open("mysql", viper.GetString("dsn"))
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if err := db.Ping(); err != nil {
+		errorLog.Fatal(err)
+	}
+
+	recipeStepModel := &models.RecipeStepModel{
+		DB: db,
+	}
+
+	migrated, err := recipeStepModel.Backfill()
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+
+	infoLog.Printf("Split the instructions of %d recipes into steps", migrated)
+}
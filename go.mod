@@ -9,10 +9,13 @@ require github.com/go-sql-driver/mysql v1.7.1
 require github.com/justinas/alice v1.2.0
 
 require (
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/golang/mock v1.6.0
 	github.com/rs/cors v1.10.1
 	github.com/spf13/viper v1.18.2
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.16.0
+	golang.org/x/image v0.12.0
 )
 
 require (
@@ -0,0 +1,28 @@
+// Package blobstore stores binary files, such as recipe images, under opaque keys.
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blobstore: blob not found")
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+)
+
+// Store is implemented by every storage backend. Keys are slash-separated relative paths,
+// e.g. "recipes/12/3f2a.jpg".
+type Store interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (*Blob, error)
+	Delete(key string) error
+}
+
+// Blob is a stored file opened for reading. The caller must close it.
+type Blob struct {
+	io.ReadSeekCloser
+	Size    int64
+	ModTime time.Time
+}
@@ -0,0 +1,35 @@
+package blobstore
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Put("recipes/1/image.jpg", strings.NewReader("content")))
+
+	blob, err := store.Get("recipes/1/image.jpg")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(blob)
+	assert.NoError(t, err)
+	assert.NoError(t, blob.Close())
+	assert.Equal(t, "content", string(data))
+	assert.Equal(t, int64(7), blob.Size)
+
+	assert.NoError(t, store.Delete("recipes/1/image.jpg"))
+	assert.NoError(t, store.Delete("recipes/1/image.jpg"))
+
+	_, err = store.Get("recipes/1/image.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "recipes/../../outside", "recipes//1"} {
+		_, err = store.Get(key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
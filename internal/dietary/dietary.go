@@ -0,0 +1,94 @@
+// Package dietary ships the allergens and diets of common ingredients, loaded from classifications.csv.
+package dietary
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+//go:embed classifications.csv
+var bundled []byte
+
+var csvHeader = []string{"name", "allergens", "diets"}
+
+// Bundled returns the ingredient classifications shipped with the application.
+func Bundled() ([]*models.Classification, error) {
+	return ParseCSV(bytes.NewReader(bundled))
+}
+
+// ParseCSV reads ingredient classifications from a CSV file with the columns of classifications.csv,
+// the allergens and diets of an ingredient being separated by semicolons.
+func ParseCSV(r io.Reader) ([]*models.Classification, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("dietary: unexpected column %q, expected %q", header[i], column)
+		}
+	}
+
+	var results []*models.Classification
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		classification, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("dietary: line %d: %w", line, err)
+		}
+		results = append(results, classification)
+	}
+}
+
+func parseRecord(record []string) (*models.Classification, error) {
+	classification := &models.Classification{
+		Name:      strings.ToLower(strings.TrimSpace(record[0])),
+		Allergens: splitList(record[1]),
+		Diets:     splitList(record[2]),
+	}
+	if classification.Name == "" {
+		return nil, errors.New("missing name")
+	}
+
+	for _, allergen := range classification.Allergens {
+		if !models.ValidAllergen(allergen) {
+			return nil, fmt.Errorf("unknown allergen %q", allergen)
+		}
+	}
+	for _, diet := range classification.Diets {
+		if !models.ValidDiet(diet) {
+			return nil, fmt.Errorf("unknown diet %q", diet)
+		}
+	}
+
+	return classification, nil
+}
+
+func splitList(field string) []string {
+	values := []string{}
+	for _, value := range strings.Split(field, ";") {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,63 @@
+package dietary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundled(t *testing.T) {
+	classifications, err := Bundled()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, classifications)
+
+	seen := make(map[string]bool)
+	for _, c := range classifications {
+		assert.False(t, seen[c.Name], "duplicate entry %q", c.Name)
+		seen[c.Name] = true
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			data: "name,allergens,diets\nSoy Sauce, gluten;soy ,vegan; vegetarian\n",
+		},
+		{
+			name:    "Wrong Header",
+			data:    "name,contains,diets\n",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Allergen",
+			data:    "name,allergens,diets\nsoy sauce,msg,vegan\n",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Diet",
+			data:    "name,allergens,diets\nsoy sauce,soy,keto\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			classifications, err := ParseCSV(strings.NewReader(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, classifications, 1)
+			assert.Equal(t, "soy sauce", classifications[0].Name)
+			assert.Equal(t, []string{"gluten", "soy"}, classifications[0].Allergens)
+			assert.Equal(t, []string{"vegan", "vegetarian"}, classifications[0].Diets)
+		})
+	}
+}
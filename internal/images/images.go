@@ -0,0 +1,139 @@
+// Package images prepares uploaded pictures for serving: it validates them, re-encodes them
+// without their metadata and generates thumbnails, using nothing but pure-Go codecs.
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// maxPixels bounds the dimensions of accepted images, so that a small file can't
+// decompress into an image that exhausts the memory.
+const maxPixels = 50_000_000
+
+var (
+	ErrUnsupportedFormat = errors.New("images: unsupported image format")
+	ErrTooLarge          = errors.New("images: image dimensions are too large")
+)
+
+// Encoded is an image ready to be stored.
+type Encoded struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+type decoder struct {
+	decode       func(r *bytes.Reader) (image.Image, error)
+	decodeConfig func(r *bytes.Reader) (image.Config, error)
+}
+
+var decoders = map[string]decoder{
+	"image/jpeg": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return jpeg.DecodeConfig(r) },
+	},
+	"image/png": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return png.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return png.DecodeConfig(r) },
+	},
+	"image/gif": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return gif.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return gif.DecodeConfig(r) },
+	},
+	"image/webp": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return webp.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return webp.DecodeConfig(r) },
+	},
+}
+
+// Process sniffs the format of an uploaded image and returns it re-encoded, scaled down to fit
+// within maxSize pixels, together with a thumbnail that fits within thumbSize pixels.
+// Re-encoding drops every metadata block, EXIF included, after its orientation has been applied.
+// Photos are stored as JPEG and everything else as PNG, which keeps transparency.
+func Process(data []byte, maxSize, thumbSize int) (*Encoded, *Encoded, error) {
+	contentType := http.DetectContentType(data)
+	dec, ok := decoders[contentType]
+	if !ok {
+		return nil, nil, ErrUnsupportedFormat
+	}
+
+	cfg, err := dec.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, ErrUnsupportedFormat
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, nil, ErrTooLarge
+	}
+
+	img, err := dec.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, ErrUnsupportedFormat
+	}
+
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	full, err := encode(fit(img, maxSize), contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	thumbnail, err := encode(fit(img, thumbSize), contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return full, thumbnail, nil
+}
+
+// fit scales the image down so that neither side exceeds size, keeping its aspect ratio.
+func fit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		width, height = size, max(1, height*size/width)
+	} else {
+		width, height = max(1, width*size/height), size
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+func encode(img image.Image, sourceType string) (*Encoded, error) {
+	var buf bytes.Buffer
+	encoded := &Encoded{Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+
+	switch sourceType {
+	case "image/jpeg":
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		encoded.ContentType, encoded.Extension = "image/jpeg", ".jpg"
+	default:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		encoded.ContentType, encoded.Extension = "image/png", ".png"
+	}
+
+	encoded.Data = buf.Bytes()
+	return encoded, nil
+}
@@ -0,0 +1,117 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an APP1 Exif segment holding the given orientation right after the SOI marker.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	return append(result, data[2:]...)
+}
+
+func TestProcess(t *testing.T) {
+	var pngData, jpegData bytes.Buffer
+	assert.NoError(t, png.Encode(&pngData, newTestImage(400, 200)))
+	assert.NoError(t, jpeg.Encode(&jpegData, newTestImage(300, 100), nil))
+
+	testCases := []struct {
+		name                string
+		data                []byte
+		expectedErr         error
+		expectedContentType string
+		expectedFull        image.Point
+		expectedThumbnail   image.Point
+	}{
+		{
+			name:                "PNG Is Scaled Down",
+			data:                pngData.Bytes(),
+			expectedContentType: "image/png",
+			expectedFull:        image.Pt(400, 200),
+			expectedThumbnail:   image.Pt(100, 50),
+		},
+		{
+			name:                "Rotated JPEG Is Turned Upright",
+			data:                withOrientation(jpegData.Bytes(), 6),
+			expectedContentType: "image/jpeg",
+			expectedFull:        image.Pt(100, 300),
+			expectedThumbnail:   image.Pt(33, 100),
+		},
+		{
+			name:        "Not An Image",
+			data:        []byte("<html>definitely not an image</html>"),
+			expectedErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			full, thumbnail, err := Process(tc.data, 1000, 100)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedContentType, full.ContentType)
+			assert.Equal(t, tc.expectedFull, image.Pt(full.Width, full.Height))
+			assert.Equal(t, tc.expectedThumbnail, image.Pt(thumbnail.Width, thumbnail.Height))
+
+			// Re-encoded files carry no EXIF block anymore
+			assert.False(t, bytes.Contains(full.Data, []byte("Exif\x00\x00")))
+			assert.Equal(t, 1, jpegOrientation(full.Data))
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	src := newTestImage(3, 2)
+
+	testCases := []struct {
+		orientation int
+		expectedX   int
+		expectedY   int
+	}{
+		{orientation: 1, expectedX: 0, expectedY: 0},
+		{orientation: 3, expectedX: 2, expectedY: 1},
+		{orientation: 6, expectedX: 0, expectedY: 1},
+		{orientation: 8, expectedX: 2, expectedY: 0},
+	}
+
+	for _, tc := range testCases {
+		dst := orient(src, tc.orientation)
+		// The pixel that ends up in the top-left corner
+		assert.Equal(t, src.At(tc.expectedX, tc.expectedY), dst.At(0, 0), "orientation %d", tc.orientation)
+	}
+}
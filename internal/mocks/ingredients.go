@@ -35,6 +35,50 @@ func (m *MockIngredientModelInterface) EXPECT() *MockIngredientModelInterfaceMoc
 	return m.recorder
 }
 
+// AddAlias mocks base method.
+func (m *MockIngredientModelInterface) AddAlias(id int, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlias", id, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAlias indicates an expected call of AddAlias.
+func (mr *MockIngredientModelInterfaceMockRecorder) AddAlias(id, alias interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlias", reflect.TypeOf((*MockIngredientModelInterface)(nil).AddAlias), id, alias)
+}
+
+// Get mocks base method.
+func (m *MockIngredientModelInterface) Get(id int) (*models.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngredientModelInterfaceMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngredientModelInterface)(nil).Get), id)
+}
+
+// GetAliases mocks base method.
+func (m *MockIngredientModelInterface) GetAliases(id int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAliases", id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAliases indicates an expected call of GetAliases.
+func (mr *MockIngredientModelInterfaceMockRecorder) GetAliases(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAliases", reflect.TypeOf((*MockIngredientModelInterface)(nil).GetAliases), id)
+}
+
 // GetByRecipeID mocks base method.
 func (m *MockIngredientModelInterface) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*models.FullIngredient, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +94,20 @@ func (mr *MockIngredientModelInterfaceMockRecorder) GetByRecipeID(tx, recipeID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRecipeID", reflect.TypeOf((*MockIngredientModelInterface)(nil).GetByRecipeID), tx, recipeID)
 }
 
+// InWorkspace mocks base method.
+func (m *MockIngredientModelInterface) InWorkspace(workspaceID int) models.IngredientModelInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InWorkspace", workspaceID)
+	ret0, _ := ret[0].(models.IngredientModelInterface)
+	return ret0
+}
+
+// InWorkspace indicates an expected call of InWorkspace.
+func (mr *MockIngredientModelInterfaceMockRecorder) InWorkspace(workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InWorkspace", reflect.TypeOf((*MockIngredientModelInterface)(nil).InWorkspace), workspaceID)
+}
+
 // InsertIfNotExists mocks base method.
 func (m *MockIngredientModelInterface) InsertIfNotExists(tx transactions.Transaction, name string) (int, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +122,60 @@ func (mr *MockIngredientModelInterfaceMockRecorder) InsertIfNotExists(tx, name i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIfNotExists", reflect.TypeOf((*MockIngredientModelInterface)(nil).InsertIfNotExists), tx, name)
 }
+
+// Merge mocks base method.
+func (m *MockIngredientModelInterface) Merge(targetID int, sourceIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", targetID, sourceIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockIngredientModelInterfaceMockRecorder) Merge(targetID, sourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockIngredientModelInterface)(nil).Merge), targetID, sourceIDs)
+}
+
+// RemoveAlias mocks base method.
+func (m *MockIngredientModelInterface) RemoveAlias(id int, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlias", id, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlias indicates an expected call of RemoveAlias.
+func (mr *MockIngredientModelInterfaceMockRecorder) RemoveAlias(id, alias interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlias", reflect.TypeOf((*MockIngredientModelInterface)(nil).RemoveAlias), id, alias)
+}
+
+// Seed mocks base method.
+func (m *MockIngredientModelInterface) Seed(classifications []*models.Classification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", classifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockIngredientModelInterfaceMockRecorder) Seed(classifications interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockIngredientModelInterface)(nil).Seed), classifications)
+}
+
+// SetClassification mocks base method.
+func (m *MockIngredientModelInterface) SetClassification(id int, allergens, diets []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClassification", id, allergens, diets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClassification indicates an expected call of SetClassification.
+func (mr *MockIngredientModelInterfaceMockRecorder) SetClassification(id, allergens, diets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClassification", reflect.TypeOf((*MockIngredientModelInterface)(nil).SetClassification), id, allergens, diets)
+}
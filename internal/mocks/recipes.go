@@ -6,6 +6,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/vladComan0/tasty-byte/internal/models"
@@ -20,6 +21,9 @@ type MockRecipeModelInterface struct {
 	RecipeIngredientModel *MockRecipeIngredientModelInterface
 	TagModel              *MockTagModelInterface
 	RecipeTagModel        *MockRecipeTagModelInterface
+	RecipeRevisionModel   *MockRecipeRevisionModelInterface
+	RecipeStepModel       *MockRecipeStepModelInterface
+	RecipeImageModel      *MockRecipeImageModelInterface
 }
 
 // MockRecipeModelInterfaceMockRecorder is the mock recorder for MockRecipeModelInterface.
@@ -35,6 +39,9 @@ func NewMockRecipeModelInterface(ctrl *gomock.Controller) *MockRecipeModelInterf
 	mock.RecipeIngredientModel = NewMockRecipeIngredientModelInterface(ctrl)
 	mock.TagModel = NewMockTagModelInterface(ctrl)
 	mock.RecipeTagModel = NewMockRecipeTagModelInterface(ctrl)
+	mock.RecipeRevisionModel = NewMockRecipeRevisionModelInterface(ctrl)
+	mock.RecipeStepModel = NewMockRecipeStepModelInterface(ctrl)
+	mock.RecipeImageModel = NewMockRecipeImageModelInterface(ctrl)
 
 	return mock
 }
@@ -58,6 +65,21 @@ func (mr *MockRecipeModelInterfaceMockRecorder) Delete(id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecipeModelInterface)(nil).Delete), id)
 }
 
+// Fork mocks base method.
+func (m *MockRecipeModelInterface) Fork(id int, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fork", id, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fork indicates an expected call of Fork.
+func (mr *MockRecipeModelInterfaceMockRecorder) Fork(id, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fork", reflect.TypeOf((*MockRecipeModelInterface)(nil).Fork), id, name)
+}
+
 // Get mocks base method.
 func (m *MockRecipeModelInterface) Get(id int) (*models.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +110,21 @@ func (mr *MockRecipeModelInterfaceMockRecorder) GetAll() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRecipeModelInterface)(nil).GetAll))
 }
 
+// GetTrashed mocks base method.
+func (m *MockRecipeModelInterface) GetTrashed() ([]*models.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashed")
+	ret0, _ := ret[0].([]*models.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashed indicates an expected call of GetTrashed.
+func (mr *MockRecipeModelInterfaceMockRecorder) GetTrashed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashed", reflect.TypeOf((*MockRecipeModelInterface)(nil).GetTrashed))
+}
+
 // GetWithTx mocks base method.
 func (m *MockRecipeModelInterface) GetWithTx(tx transactions.Transaction, id int) (*models.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +140,35 @@ func (mr *MockRecipeModelInterfaceMockRecorder) GetWithTx(tx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithTx", reflect.TypeOf((*MockRecipeModelInterface)(nil).GetWithTx), tx, id)
 }
 
+// ImportBatch mocks base method.
+func (m *MockRecipeModelInterface) ImportBatch(recipes []*models.Recipe, upsert, dryRun bool) ([]*models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBatch", recipes, upsert, dryRun)
+	ret0, _ := ret[0].([]*models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBatch indicates an expected call of ImportBatch.
+func (mr *MockRecipeModelInterfaceMockRecorder) ImportBatch(recipes, upsert, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBatch", reflect.TypeOf((*MockRecipeModelInterface)(nil).ImportBatch), recipes, upsert, dryRun)
+}
+
+// InWorkspace mocks base method.
+func (m *MockRecipeModelInterface) InWorkspace(workspaceID int) models.RecipeModelInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InWorkspace", workspaceID)
+	ret0, _ := ret[0].(models.RecipeModelInterface)
+	return ret0
+}
+
+// InWorkspace indicates an expected call of InWorkspace.
+func (mr *MockRecipeModelInterfaceMockRecorder) InWorkspace(workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InWorkspace", reflect.TypeOf((*MockRecipeModelInterface)(nil).InWorkspace), workspaceID)
+}
+
 // Insert mocks base method.
 func (m *MockRecipeModelInterface) Insert(recipe *models.Recipe) (int, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +184,37 @@ func (mr *MockRecipeModelInterfaceMockRecorder) Insert(recipe interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRecipeModelInterface)(nil).Insert), recipe)
 }
 
+// InsertWithTx mocks base method.
+func (m *MockRecipeModelInterface) InsertWithTx(tx transactions.Transaction, recipe *models.Recipe) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWithTx", tx, recipe)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWithTx indicates an expected call of InsertWithTx.
+func (mr *MockRecipeModelInterfaceMockRecorder) InsertWithTx(tx, recipe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWithTx", reflect.TypeOf((*MockRecipeModelInterface)(nil).InsertWithTx), tx, recipe)
+}
+
+// List mocks base method.
+func (m *MockRecipeModelInterface) List(filter *models.RecipeFilter) ([]*models.Recipe, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", filter)
+	ret0, _ := ret[0].([]*models.Recipe)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockRecipeModelInterfaceMockRecorder) List(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRecipeModelInterface)(nil).List), filter)
+}
+
 // Ping mocks base method.
 func (m *MockRecipeModelInterface) Ping() error {
 	m.ctrl.T.Helper()
@@ -132,6 +229,49 @@ func (mr *MockRecipeModelInterfaceMockRecorder) Ping() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRecipeModelInterface)(nil).Ping))
 }
 
+// Purge mocks base method.
+func (m *MockRecipeModelInterface) Purge(retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRecipeModelInterfaceMockRecorder) Purge(retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRecipeModelInterface)(nil).Purge), retention)
+}
+
+// Restore mocks base method.
+func (m *MockRecipeModelInterface) Restore(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRecipeModelInterfaceMockRecorder) Restore(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRecipeModelInterface)(nil).Restore), id)
+}
+
+// Stream mocks base method.
+func (m *MockRecipeModelInterface) Stream(batchSize int, fn func(*models.Recipe) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockRecipeModelInterfaceMockRecorder) Stream(batchSize, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockRecipeModelInterface)(nil).Stream), batchSize, fn)
+}
+
 // Update mocks base method.
 func (m *MockRecipeModelInterface) Update(recipe *models.Recipe) error {
 	m.ctrl.T.Helper()
@@ -145,3 +285,64 @@ func (mr *MockRecipeModelInterfaceMockRecorder) Update(recipe interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRecipeModelInterface)(nil).Update), recipe)
 }
+
+// UpdateWithTx mocks base method.
+func (m *MockRecipeModelInterface) UpdateWithTx(tx transactions.Transaction, recipe *models.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithTx", tx, recipe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithTx indicates an expected call of UpdateWithTx.
+func (mr *MockRecipeModelInterfaceMockRecorder) UpdateWithTx(tx, recipe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithTx", reflect.TypeOf((*MockRecipeModelInterface)(nil).UpdateWithTx), tx, recipe)
+}
+
+// MockRecipeIndex is a mock of RecipeIndex interface.
+type MockRecipeIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeIndexMockRecorder
+}
+
+// MockRecipeIndexMockRecorder is the mock recorder for MockRecipeIndex.
+type MockRecipeIndexMockRecorder struct {
+	mock *MockRecipeIndex
+}
+
+// NewMockRecipeIndex creates a new mock instance.
+func NewMockRecipeIndex(ctrl *gomock.Controller) *MockRecipeIndex {
+	mock := &MockRecipeIndex{ctrl: ctrl}
+	mock.recorder = &MockRecipeIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeIndex) EXPECT() *MockRecipeIndexMockRecorder {
+	return m.recorder
+}
+
+// Put mocks base method.
+func (m *MockRecipeIndex) Put(recipe *models.Recipe) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Put", recipe)
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockRecipeIndexMockRecorder) Put(recipe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockRecipeIndex)(nil).Put), recipe)
+}
+
+// Remove mocks base method.
+func (m *MockRecipeIndex) Remove(id int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", id)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRecipeIndexMockRecorder) Remove(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRecipeIndex)(nil).Remove), id)
+}
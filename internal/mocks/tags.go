@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./internal/models/tags.go
+// Source: internal/models/tags.go
 
 // Package mock_models is a generated GoMock package.
 package mocks
@@ -35,6 +35,36 @@ func (m *MockTagModelInterface) EXPECT() *MockTagModelInterfaceMockRecorder {
 	return m.recorder
 }
 
+// Get mocks base method.
+func (m *MockTagModelInterface) Get(id int) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTagModelInterfaceMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTagModelInterface)(nil).Get), id)
+}
+
+// GetAll mocks base method.
+func (m *MockTagModelInterface) GetAll() ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagModelInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTagModelInterface)(nil).GetAll))
+}
+
 // GetByRecipeID mocks base method.
 func (m *MockTagModelInterface) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*models.Tag, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +80,20 @@ func (mr *MockTagModelInterfaceMockRecorder) GetByRecipeID(tx, recipeID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRecipeID", reflect.TypeOf((*MockTagModelInterface)(nil).GetByRecipeID), tx, recipeID)
 }
 
+// InWorkspace mocks base method.
+func (m *MockTagModelInterface) InWorkspace(workspaceID int) models.TagModelInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InWorkspace", workspaceID)
+	ret0, _ := ret[0].(models.TagModelInterface)
+	return ret0
+}
+
+// InWorkspace indicates an expected call of InWorkspace.
+func (mr *MockTagModelInterfaceMockRecorder) InWorkspace(workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InWorkspace", reflect.TypeOf((*MockTagModelInterface)(nil).InWorkspace), workspaceID)
+}
+
 // InsertIfNotExists mocks base method.
 func (m *MockTagModelInterface) InsertIfNotExists(tx transactions.Transaction, name string) (int, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +108,17 @@ func (mr *MockTagModelInterfaceMockRecorder) InsertIfNotExists(tx, name interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIfNotExists", reflect.TypeOf((*MockTagModelInterface)(nil).InsertIfNotExists), tx, name)
 }
+
+// Update mocks base method.
+func (m *MockTagModelInterface) Update(tag *models.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagModelInterfaceMockRecorder) Update(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTagModelInterface)(nil).Update), tag)
+}
@@ -0,0 +1,233 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+type APIKeyModelInterface interface {
+	Insert(key *APIKey) (string, error)
+	GetByUserID(userID int) ([]*APIKey, error)
+	Authenticate(token string) (*APIKey, error)
+	Delete(id, userID int) error
+}
+
+// The scopes API keys can be given.
+const (
+	ScopeRecipesRead      = "recipes:read"
+	ScopeRecipesWrite     = "recipes:write"
+	ScopeCollectionsRead  = "collections:read"
+	ScopeCollectionsWrite = "collections:write"
+	ScopePantryRead       = "pantry:read"
+	ScopePantryWrite      = "pantry:write"
+	ScopeWorkspacesRead   = "workspaces:read"
+	ScopeWorkspacesWrite  = "workspaces:write"
+	// ScopeAdmin allows everything, the administration endpoints included
+	ScopeAdmin = "admin"
+)
+
+// ValidScope tells whether API keys can be given the scope.
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeRecipesRead, ScopeRecipesWrite, ScopeCollectionsRead, ScopeCollectionsWrite,
+		ScopePantryRead, ScopePantryWrite, ScopeWorkspacesRead, ScopeWorkspacesWrite, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// apiKeyPrefix starts every API key, so that keys are easy to recognize, e.g. by secret scanners.
+const apiKeyPrefix = "tb_"
+
+// APIKey lets services act on behalf of the user who created it, limited to its scopes and until it
+// expires. Only a hash of the key is stored, the key itself being shown once when it is created.
+type APIKey struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"-"`
+	Name   string `json:"name"`
+	// Prefix is the start of the key, which tells keys apart without revealing them
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Allows tells whether the key has the scope, which admin keys have all of.
+func (k *APIKey) Allows(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// hashAPIKey returns the hash API keys are stored and looked up by. Keys are random enough that
+// a fast hash is as good as a slow one, which matters when every request is checked.
+func hashAPIKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type APIKeyModel struct {
+	DB *sql.DB
+}
+
+// Insert generates a new key for the user, storing its hash, and returns the key itself.
+func (m *APIKeyModel) Insert(key *APIKey) (string, error) {
+	for _, scope := range key.Scopes {
+		if !ValidScope(scope) {
+			return "", ErrInvalidScope
+		}
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	token := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+	key.Prefix = token[:len(apiKeyPrefix)+8]
+
+	var expires sql.NullTime
+	if key.ExpiresAt != nil {
+		expires = sql.NullTime{Time: key.ExpiresAt.UTC(), Valid: true}
+	}
+
+	stmt := `
+		INSERT INTO api_keys (user_id, name, prefix, hashed_key, scopes, expires, created)
+		VALUES (?, ?, ?, ?, ?, ?, UTC_TIMESTAMP())
+		`
+
+	result, err := m.DB.Exec(stmt, key.UserID, key.Name, key.Prefix, hashAPIKey(token), strings.Join(key.Scopes, ","), expires)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", err
+	}
+	key.ID = int(id)
+
+	return token, nil
+}
+
+// GetByUserID returns the keys of the user, the most recent first, including the ones that expired.
+func (m *APIKeyModel) GetByUserID(userID int) ([]*APIKey, error) {
+	keys := []*APIKey{}
+
+	stmt := `
+		SELECT id, user_id, name, prefix, scopes, expires, last_used, created
+		FROM api_keys
+		WHERE user_id = ?
+		ORDER BY created DESC, id DESC
+		`
+
+	rows, err := m.DB.Query(stmt, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Authenticate returns the key matching the token, recording, to the minute, when it was last used. Keys that don't
+// exist, were revoked or have expired fail with ErrInvalidCredentials.
+func (m *APIKeyModel) Authenticate(token string) (*APIKey, error) {
+	if !strings.HasPrefix(token, apiKeyPrefix) {
+		return nil, ErrInvalidCredentials
+	}
+	hash := hashAPIKey(token)
+
+	stmt := `
+		SELECT id, user_id, name, prefix, scopes, expires, last_used, created
+		FROM api_keys
+		WHERE hashed_key = ? AND (expires IS NULL OR expires > UTC_TIMESTAMP())
+		`
+
+	key, err := scanAPIKey(m.DB.QueryRow(stmt, hash))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrInvalidCredentials
+		default:
+			return nil, err
+		}
+	}
+
+	// The last use is only kept to the minute, so that a busy key doesn't write on every request
+	stmt = `
+		UPDATE api_keys SET last_used = UTC_TIMESTAMP()
+		WHERE id = ? AND (last_used IS NULL OR last_used < UTC_TIMESTAMP() - INTERVAL 1 MINUTE)
+		`
+	if _, err := m.DB.Exec(stmt, key.ID); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Delete revokes the key of the user.
+func (m *APIKeyModel) Delete(id, userID int) error {
+	result, err := m.DB.Exec("DELETE FROM api_keys WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
+
+// scanAPIKey reads a key from the columns selected by GetByUserID and Authenticate.
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (*APIKey, error) {
+	var (
+		key      = &APIKey{}
+		scopes   string
+		expires  sql.NullTime
+		lastUsed sql.NullTime
+	)
+
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &scopes, &expires, &lastUsed, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = splitSet(scopes)
+	if expires.Valid {
+		key.ExpiresAt = &expires.Time
+	}
+	if lastUsed.Valid {
+		key.LastUsedAt = &lastUsed.Time
+	}
+
+	return key, nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyAllows(t *testing.T) {
+	read := &APIKey{Scopes: []string{ScopeRecipesRead}}
+	assert.True(t, read.Allows(ScopeRecipesRead))
+	assert.False(t, read.Allows(ScopeRecipesWrite))
+	assert.False(t, read.Allows(ScopeAdmin))
+
+	// Admin keys have every scope
+	admin := &APIKey{Scopes: []string{ScopeAdmin}}
+	assert.True(t, admin.Allows(ScopeRecipesRead))
+	assert.True(t, admin.Allows(ScopeRecipesWrite))
+	assert.True(t, admin.Allows(ScopeAdmin))
+
+	assert.False(t, (&APIKey{}).Allows(ScopeRecipesRead))
+}
+
+func TestHashAPIKey(t *testing.T) {
+	hash := hashAPIKey("tb_secret")
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, hashAPIKey("tb_secret"))
+	assert.NotEqual(t, hash, hashAPIKey("tb_secreT"))
+}
@@ -0,0 +1,332 @@
+package models
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
+	"time"
+)
+
+type CollectionModelInterface interface {
+	Insert(collection *Collection) (int, error)
+	Get(id int) (*Collection, error)
+	GetByUserID(userID int) ([]*Collection, error)
+	GetByShareToken(token string) (*Collection, error)
+	Update(collection *Collection) error
+	Delete(id int) error
+}
+
+// Collection is a named, ordered selection of recipes a user put together, such as "Christmas menu".
+// A shared collection can be read by anyone knowing its share token, which is generated when the
+// collection gets shared and dropped when it stops being shared.
+type Collection struct {
+	ID         int                 `json:"id"`
+	UserID     int                 `json:"-"`
+	Name       string              `json:"name"`
+	Shared     bool                `json:"shared"`
+	ShareToken string              `json:"share_token,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	Recipes    []*CollectionRecipe `json:"recipes"`
+}
+
+// CollectionRecipe is a recipe of a collection, in the order the user put it in.
+type CollectionRecipe struct {
+	RecipeID int    `json:"recipe_id"`
+	Name     string `json:"name"`
+}
+
+type CollectionModel struct {
+	DB *sql.DB
+}
+
+func (m *CollectionModel) Insert(collection *Collection) (int, error) {
+	var id int
+
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		if err := collection.syncShareToken(); err != nil {
+			return err
+		}
+
+		stmt := `
+		INSERT INTO collections (user_id, name, share_token, created)
+		VALUES (?, ?, ?, UTC_TIMESTAMP())
+		`
+
+		result, err := tx.Exec(stmt, collection.UserID, collection.Name, nullableToken(collection.ShareToken))
+		if err != nil {
+			return err
+		}
+		id64, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		id = int(id64)
+
+		return insertCollectionRecipes(tx, id, collection.Recipes)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	collection.ID = id
+	return id, nil
+}
+
+func (m *CollectionModel) Get(id int) (*Collection, error) {
+	return m.getBy("id", id)
+}
+
+// GetByShareToken returns the shared collection with the given token.
+func (m *CollectionModel) GetByShareToken(token string) (*Collection, error) {
+	return m.getBy("share_token", token)
+}
+
+func (m *CollectionModel) getBy(column string, value any) (*Collection, error) {
+	var (
+		collection = &Collection{}
+		shareToken sql.NullString
+	)
+
+	stmt := `
+		SELECT id, user_id, name, share_token, created
+		FROM collections
+		WHERE ` + column + ` = ?
+		`
+
+	err := m.DB.QueryRow(stmt, value).Scan(
+		&collection.ID,
+		&collection.UserID,
+		&collection.Name,
+		&shareToken,
+		&collection.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+	collection.Shared = shareToken.Valid
+	collection.ShareToken = shareToken.String
+
+	recipes, err := m.getRecipes("cr.collection_id = ?", collection.ID)
+	if err != nil {
+		return nil, err
+	}
+	collection.Recipes = recipes[collection.ID]
+	if collection.Recipes == nil {
+		collection.Recipes = []*CollectionRecipe{}
+	}
+
+	return collection, nil
+}
+
+// GetByUserID returns the collections of the user, in alphabetical order.
+func (m *CollectionModel) GetByUserID(userID int) ([]*Collection, error) {
+	collections := []*Collection{}
+
+	stmt := `
+		SELECT id, user_id, name, share_token, created
+		FROM collections
+		WHERE user_id = ?
+		ORDER BY name, id
+		`
+
+	rows, err := m.DB.Query(stmt, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			collection = &Collection{}
+			shareToken sql.NullString
+		)
+		err := rows.Scan(
+			&collection.ID,
+			&collection.UserID,
+			&collection.Name,
+			&shareToken,
+			&collection.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		collection.Shared = shareToken.Valid
+		collection.ShareToken = shareToken.String
+		collections = append(collections, collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	recipes, err := m.getRecipes("c.user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, collection := range collections {
+		collection.Recipes = recipes[collection.ID]
+		if collection.Recipes == nil {
+			collection.Recipes = []*CollectionRecipe{}
+		}
+	}
+
+	return collections, nil
+}
+
+// getRecipes returns the recipes of the collections matching the condition, keyed by collection ID.
+// Trashed recipes are left out.
+func (m *CollectionModel) getRecipes(condition string, args ...any) (map[int][]*CollectionRecipe, error) {
+	recipes := make(map[int][]*CollectionRecipe)
+
+	stmt := `
+		SELECT cr.collection_id, cr.recipe_id, r.name
+		FROM collection_recipes cr
+			INNER JOIN collections c ON c.id = cr.collection_id
+			INNER JOIN recipes r ON r.id = cr.recipe_id
+		WHERE ` + condition + ` AND r.deleted_at IS NULL
+		ORDER BY cr.collection_id, cr.position
+		`
+
+	rows, err := m.DB.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			collectionID int
+			recipe       = &CollectionRecipe{}
+		)
+		if err := rows.Scan(&collectionID, &recipe.RecipeID, &recipe.Name); err != nil {
+			return nil, err
+		}
+		recipes[collectionID] = append(recipes[collectionID], recipe)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+// Update replaces the name, the sharing and the recipes of the collection.
+func (m *CollectionModel) Update(collection *Collection) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		if err := collection.syncShareToken(); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(
+			"UPDATE collections SET name = ?, share_token = ? WHERE id = ?",
+			collection.Name, nullableToken(collection.ShareToken), collection.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Setting the same values again doesn't count as affecting the row, so it is looked up instead
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			var id int
+			if err := tx.QueryRow("SELECT id FROM collections WHERE id = ?", collection.ID).Scan(&id); err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					return ErrNoRecord
+				default:
+					return err
+				}
+			}
+		}
+
+		if _, err := tx.Exec("DELETE FROM collection_recipes WHERE collection_id = ?", collection.ID); err != nil {
+			return err
+		}
+
+		return insertCollectionRecipes(tx, collection.ID, collection.Recipes)
+	})
+}
+
+func (m *CollectionModel) Delete(id int) error {
+	result, err := m.DB.Exec("DELETE FROM collections WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
+
+// insertCollectionRecipes adds the recipes to the collection in order, failing with ErrInvalidCollectionRecipe
+// when one of them doesn't exist or appears twice.
+func insertCollectionRecipes(tx transactions.Transaction, collectionID int, recipes []*CollectionRecipe) error {
+	stmt := `
+		INSERT INTO collection_recipes (collection_id, recipe_id, position)
+		SELECT ?, id, ? FROM recipes WHERE id = ? AND deleted_at IS NULL
+		`
+
+	for position, recipe := range recipes {
+		result, err := tx.Exec(stmt, collectionID, position, recipe.RecipeID)
+		if err != nil {
+			var mySQLError *mysql.MySQLError
+			if errors.As(err, &mySQLError) && mySQLError.Number == 1062 {
+				return ErrInvalidCollectionRecipe
+			}
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrInvalidCollectionRecipe
+		}
+	}
+
+	return nil
+}
+
+// syncShareToken generates a share token for a collection that just got shared and drops the one
+// of a collection that isn't shared anymore.
+func (c *Collection) syncShareToken() error {
+	switch {
+	case !c.Shared:
+		c.ShareToken = ""
+	case c.ShareToken == "":
+		token := make([]byte, 32)
+		if _, err := rand.Read(token); err != nil {
+			return err
+		}
+		c.ShareToken = strings.TrimRight(base64.URLEncoding.EncodeToString(token), "=")
+	}
+	return nil
+}
+
+func nullableToken(token string) sql.NullString {
+	return sql.NullString{String: token, Valid: token != ""}
+}
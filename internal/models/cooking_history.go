@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type CookingHistoryModelInterface interface {
+	GetByUserID(userID int) ([]*CookedRecipe, error)
+}
+
+// CookedRecipe is a recipe a user cooked, with how many times they did and when they last did.
+type CookedRecipe struct {
+	RecipeID     int       `json:"recipe_id"`
+	Name         string    `json:"name"`
+	Times        int       `json:"times"`
+	LastCookedAt time.Time `json:"last_cooked_at"`
+}
+
+type CookingHistoryModel struct {
+	DB *sql.DB
+}
+
+// GetByUserID returns the recipes the user cooked, the most recently cooked first, leaving out trashed recipes.
+func (m *CookingHistoryModel) GetByUserID(userID int) ([]*CookedRecipe, error) {
+	cooked := []*CookedRecipe{}
+
+	stmt := `
+		SELECT h.recipe_id, r.name, COUNT(*), MAX(h.cooked)
+		FROM cooking_history h INNER JOIN recipes r ON r.id = h.recipe_id
+		WHERE h.user_id = ? AND r.deleted_at IS NULL
+		GROUP BY h.recipe_id, r.name
+		ORDER BY MAX(h.cooked) DESC, h.recipe_id DESC
+		`
+
+	rows, err := m.DB.Query(stmt, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		recipe := &CookedRecipe{}
+		if err := rows.Scan(&recipe.RecipeID, &recipe.Name, &recipe.Times, &recipe.LastCookedAt); err != nil {
+			return nil, err
+		}
+		cooked = append(cooked, recipe)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cooked, nil
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Allergens ingredients can be flagged with, the ones that must be declared in the EU and the US.
+var Allergens = []string{
+	"celery",
+	"crustaceans",
+	"dairy",
+	"eggs",
+	"fish",
+	"gluten",
+	"lupin",
+	"molluscs",
+	"mustard",
+	"nuts",
+	"peanuts",
+	"sesame",
+	"soy",
+	"sulphites",
+}
+
+// Diets ingredients can be suitable for.
+var Diets = []string{
+	"halal",
+	"vegan",
+	"vegetarian",
+}
+
+// ValidAllergen reports whether the allergen is one of Allergens.
+func ValidAllergen(allergen string) bool {
+	return slices.Contains(Allergens, allergen)
+}
+
+// ValidDiet reports whether the diet is one of Diets.
+func ValidDiet(diet string) bool {
+	return slices.Contains(Diets, diet)
+}
+
+// Classification holds the allergens an ingredient contains and the diets it is suitable for.
+type Classification struct {
+	Name      string   `json:"name,omitempty"`
+	Allergens []string `json:"allergens"`
+	Diets     []string `json:"diets"`
+}
+
+// classify derives the allergens and diets of the recipe from its ingredients: it contains the
+// allergens of any of them and suits the diets all of them suit. An ingredient that hasn't been
+// classified yet could be anything, so a recipe using one isn't labelled with any diet.
+func (r *Recipe) classify() {
+	allergens := make(map[string]bool)
+	diets := []string{}
+	first := true
+	for _, ingredient := range r.Ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil {
+			continue
+		}
+		for _, allergen := range ingredient.Allergens {
+			allergens[allergen] = true
+		}
+
+		switch {
+		case !ingredient.Classified:
+			diets = diets[:0]
+		case first:
+			diets = append(diets, ingredient.Diets...)
+		default:
+			diets = slices.DeleteFunc(diets, func(diet string) bool {
+				return !slices.Contains(ingredient.Diets, diet)
+			})
+		}
+		first = false
+	}
+
+	r.Allergens = make([]string, 0, len(allergens))
+	for allergen := range allergens {
+		r.Allergens = append(r.Allergens, allergen)
+	}
+	sort.Strings(r.Allergens)
+
+	sort.Strings(diets)
+	r.Diets = diets
+}
+
+// HasAllergen reports whether any ingredient of the recipe contains the allergen.
+func (r *Recipe) HasAllergen(allergen string) bool {
+	return slices.Contains(r.Allergens, allergen)
+}
+
+// SuitsDiet reports whether every ingredient of the recipe is suitable for the diet.
+func (r *Recipe) SuitsDiet(diet string) bool {
+	return slices.Contains(r.Diets, diet)
+}
+
+// splitSet turns the value of a MySQL SET column into its members.
+func splitSet(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipeClassify(t *testing.T) {
+	ingredient := func(name string, classified bool, allergens, diets []string) *FullIngredient {
+		return &FullIngredient{
+			Ingredient: &Ingredient{Name: name, Allergens: allergens, Diets: diets, Classified: classified},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		ingredients       []*FullIngredient
+		expectedAllergens []string
+		expectedDiets     []string
+	}{
+		{
+			name: "Common Diets",
+			ingredients: []*FullIngredient{
+				ingredient("flour", true, []string{"gluten"}, []string{"halal", "vegan", "vegetarian"}),
+				ingredient("butter", true, []string{"dairy"}, []string{"halal", "vegetarian"}),
+				ingredient("vanilla extract", true, nil, []string{"vegan", "vegetarian"}),
+			},
+			expectedAllergens: []string{"dairy", "gluten"},
+			expectedDiets:     []string{"vegetarian"},
+		},
+		{
+			name: "Unclassified Ingredient",
+			ingredients: []*FullIngredient{
+				ingredient("flour", true, []string{"gluten"}, []string{"vegan"}),
+				ingredient("mystery spice", false, nil, nil),
+				ingredient("sugar", true, nil, []string{"vegan"}),
+			},
+			expectedAllergens: []string{"gluten"},
+			expectedDiets:     []string{},
+		},
+		{
+			name:              "No Ingredients",
+			expectedAllergens: []string{},
+			expectedDiets:     []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recipe := &Recipe{Ingredients: tc.ingredients}
+			recipe.classify()
+
+			assert.Equal(t, tc.expectedAllergens, recipe.Allergens)
+			assert.Equal(t, tc.expectedDiets, recipe.Diets)
+		})
+	}
+}
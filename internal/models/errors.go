@@ -2,4 +2,25 @@ package models
 
 import "errors"
 
-var ErrNoRecord = errors.New("models: no matching record found")
+var (
+	ErrNoRecord   = errors.New("models: no matching record found")
+	ErrNoPortions = errors.New("models: recipe has no portions to scale from")
+
+	ErrInvalidCredentials = errors.New("models: invalid credentials")
+	ErrDuplicateEmail     = errors.New("models: duplicate email")
+	ErrDuplicateReview    = errors.New("models: user already reviewed the recipe")
+	ErrDuplicateAlias     = errors.New("models: alias belongs to another ingredient")
+	ErrLastOwner          = errors.New("models: workspace would be left without an owner")
+
+	ErrInvalidStepIngredient   = errors.New("models: step references an ingredient that is not part of the recipe")
+	ErrInvalidClassification   = errors.New("models: unknown allergen or diet")
+	ErrInvalidSubstitution     = errors.New("models: substituted ingredient is not part of the recipe")
+	ErrInvalidCollectionRecipe = errors.New("models: collection recipe does not exist or appears twice")
+	ErrInvalidAlias            = errors.New("models: alias is empty")
+	ErrInvalidTagParent        = errors.New("models: parent tag does not exist or is a descendant of the tag")
+	ErrInvalidMerge            = errors.New("models: ingredients to merge are missing, repeated or include the target")
+	ErrIncompatibleUnits       = errors.New("models: quantities have units that can't be converted into each other")
+	ErrQuantityOverflow        = errors.New("models: combined quantity is larger than can be stored")
+	ErrInvalidRole             = errors.New("models: unknown workspace role")
+	ErrInvalidScope            = errors.New("models: unknown API key scope")
+)
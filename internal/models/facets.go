@@ -0,0 +1,279 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type FacetModelInterface interface {
+	Count(filter *RecipeFilter, facets []string) (*Facets, error)
+	InWorkspace(workspaceID int) FacetModelInterface
+}
+
+// The facets recipes can be counted by.
+const (
+	FacetTags        = "tags"
+	FacetIngredients = "ingredients"
+	FacetTotalTime   = "total_time"
+	FacetPortions    = "portions"
+)
+
+// ValidFacet tells whether recipes can be counted by the facet.
+func ValidFacet(facet string) bool {
+	switch facet {
+	case FacetTags, FacetIngredients, FacetTotalTime, FacetPortions:
+		return true
+	}
+	return false
+}
+
+// maxIngredientFacets is the number of ingredients counted, the most used first.
+const maxIngredientFacets = 50
+
+// Facets are the counts of a set of recipes per tag, ingredient, total time and number of portions.
+// Only the facets that were asked for are set.
+type Facets struct {
+	Tags        []*FacetCount  `json:"tags,omitempty"`
+	Ingredients []*FacetCount  `json:"ingredients,omitempty"`
+	TotalTime   []*FacetBucket `json:"total_time,omitempty"`
+	Portions    []*FacetBucket `json:"portions,omitempty"`
+}
+
+// FacetCount is the number of recipes with a tag or an ingredient. A recipe counts towards its tags
+// and all the tags above them, which take their category from their closest ancestor with one.
+type FacetCount struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID int    `json:"parent_id,omitempty"`
+	Category string `json:"category,omitempty"`
+	Count    int    `json:"count"`
+}
+
+// FacetBucket is the number of recipes from Min up to and including Max, minutes or portions,
+// the last bucket having no Max. Recipes without a time or portions aren't in any bucket.
+type FacetBucket struct {
+	Key   string `json:"key"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max,omitempty"`
+	Count int    `json:"count"`
+}
+
+var (
+	totalTimeBuckets = []FacetBucket{
+		{Key: "0-15", Min: 0, Max: 15},
+		{Key: "16-30", Min: 16, Max: 30},
+		{Key: "31-60", Min: 31, Max: 60},
+		{Key: "61-120", Min: 61, Max: 120},
+		{Key: "121+", Min: 121},
+	}
+	portionBuckets = []FacetBucket{
+		{Key: "1-2", Min: 1, Max: 2},
+		{Key: "3-4", Min: 3, Max: 4},
+		{Key: "5-6", Min: 5, Max: 6},
+		{Key: "7+", Min: 7},
+	}
+)
+
+// minutesSQL returns the SQL converting a time column stored in the short form, e.g. "1h 30m", to minutes.
+// Like schemaorg.ParseDuration, it reads the hours and minutes wherever they are in the text.
+func minutesSQL(column string) string {
+	return fmt.Sprintf(`(
+		COALESCE(CAST(REGEXP_SUBSTR(REGEXP_SUBSTR(LOWER(%[1]s), '[0-9]+[[:space:]]*h'), '[0-9]+') AS UNSIGNED), 0) * 60 +
+		COALESCE(CAST(REGEXP_SUBSTR(REGEXP_SUBSTR(LOWER(%[1]s), '[0-9]+[[:space:]]*m'), '[0-9]+') AS UNSIGNED), 0)
+	)`, column)
+}
+
+type FacetModel struct {
+	DB *sql.DB
+	// WorkspaceID is the only workspace whose recipes the model counts, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only counts the recipes of the workspace.
+func (m *FacetModel) InWorkspace(workspaceID int) FacetModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	return &scoped
+}
+
+// Count counts the recipes matching the filter by each of the facets, over all of them rather than
+// over a page, so that the counts follow the filters the recipes were listed with.
+func (m *FacetModel) Count(filter *RecipeFilter, facets []string) (*Facets, error) {
+	result := &Facets{}
+
+	condition, args := filter.condition(m.WorkspaceID)
+	in := "SELECT recipes.id FROM recipes WHERE " + condition
+
+	for _, facet := range facets {
+		var err error
+		switch facet {
+		case FacetTags:
+			result.Tags, err = m.countTags(in, args)
+		case FacetIngredients:
+			result.Ingredients, err = m.countIngredients(in, args)
+		case FacetTotalTime:
+			expression := minutesSQL("preparation_time") + " + " + minutesSQL("cooking_time")
+			result.TotalTime, err = m.countBuckets(expression, "preparation_time <> '' OR cooking_time <> ''", totalTimeBuckets, in, args)
+		case FacetPortions:
+			result.Portions, err = m.countBuckets("portions", "portions > 0", portionBuckets, in, args)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (m *FacetModel) countTags(in string, args []any) ([]*FacetCount, error) {
+	counts := []*FacetCount{}
+
+	// ancestry pairs every tag with itself and every tag above it, the depth guarding against cycles
+	stmt := `
+		WITH RECURSIVE ancestry (tag_id, ancestor_id, depth) AS (
+			SELECT id, id, 0 FROM tags
+			UNION ALL
+			SELECT a.tag_id, t.parent_id, a.depth + 1
+			FROM ancestry a INNER JOIN tags t ON t.id = a.ancestor_id
+			WHERE t.parent_id IS NOT NULL AND a.depth < 32
+		)
+		SELECT
+			t.id,
+			t.name,
+			COALESCE(t.parent_id, 0),
+			COALESCE((
+				SELECT c.category
+				FROM ancestry ca INNER JOIN tags c ON c.id = ca.ancestor_id
+				WHERE ca.tag_id = t.id AND c.category <> ''
+				ORDER BY ca.depth
+				LIMIT 1
+			), '') AS effective_category,
+			COUNT(DISTINCT rt.recipe_id) AS recipes
+		FROM recipe_tags rt
+		INNER JOIN ancestry a ON a.tag_id = rt.tag_id
+		INNER JOIN tags t ON t.id = a.ancestor_id
+		WHERE rt.recipe_id IN (` + in + `)
+		GROUP BY t.id, t.name, t.parent_id
+		ORDER BY effective_category, recipes DESC, t.name
+		`
+
+	rows, err := m.DB.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		count := &FacetCount{}
+		if err := rows.Scan(&count.ID, &count.Name, &count.ParentID, &count.Category, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (m *FacetModel) countIngredients(in string, args []any) ([]*FacetCount, error) {
+	counts := []*FacetCount{}
+
+	stmt := `
+		SELECT i.id, i.name, COUNT(DISTINCT ri.recipe_id) AS recipes
+		FROM recipe_ingredients ri INNER JOIN ingredients i ON i.id = ri.ingredient_id
+		WHERE ri.recipe_id IN (` + in + `)
+		GROUP BY i.id, i.name
+		ORDER BY recipes DESC, i.name
+		LIMIT ?
+		`
+
+	rows, err := m.DB.Query(stmt, append(slices.Clip(args), maxIngredientFacets)...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		count := &FacetCount{}
+		if err := rows.Scan(&count.ID, &count.Name, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// countBuckets counts the recipes matching the condition by the bucket the value of the expression falls in.
+// The recipes are those of the "in" query, which the arguments are for.
+// Every bucket is returned, in order, including the empty ones.
+func (m *FacetModel) countBuckets(expression, condition string, definitions []FacetBucket, in string, args []any) ([]*FacetBucket, error) {
+	buckets := make([]*FacetBucket, len(definitions))
+	byKey := make(map[string]*FacetBucket, len(definitions))
+	for i := range definitions {
+		bucket := definitions[i]
+		buckets[i] = &bucket
+		byKey[bucket.Key] = &bucket
+	}
+
+	var (
+		cases     strings.Builder
+		queryArgs []any
+	)
+	for _, bucket := range definitions[:len(definitions)-1] {
+		cases.WriteString(" WHEN value <= ? THEN ?")
+		queryArgs = append(queryArgs, bucket.Max, bucket.Key)
+	}
+	queryArgs = append(queryArgs, definitions[len(definitions)-1].Key)
+	queryArgs = append(queryArgs, args...)
+
+	stmt := `
+		SELECT CASE` + cases.String() + ` ELSE ? END AS bucket, COUNT(*)
+		FROM (
+			SELECT ` + expression + ` AS value
+			FROM recipes
+			WHERE (` + condition + `) AND id IN (` + in + `)
+		) recipe_values
+		GROUP BY bucket
+		`
+
+	rows, err := m.DB.Query(stmt, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			key   string
+			count int
+		)
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		if bucket, exists := byKey[key]; exists {
+			bucket.Count = count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFacetBuckets(t *testing.T) {
+	for _, definitions := range [][]FacetBucket{totalTimeBuckets, portionBuckets} {
+		for i, bucket := range definitions {
+			// Buckets include their Max, so the next one starts right after it
+			if i > 0 {
+				assert.Equal(t, definitions[i-1].Max+1, bucket.Min)
+			}
+
+			if i == len(definitions)-1 {
+				assert.Zero(t, bucket.Max)
+				assert.Equal(t, fmt.Sprintf("%d+", bucket.Min), bucket.Key)
+			} else {
+				assert.Equal(t, fmt.Sprintf("%d-%d", bucket.Min, bucket.Max), bucket.Key)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"time"
+)
+
+type FavoriteModelInterface interface {
+	Insert(userID, recipeID int) error
+	Delete(userID, recipeID int) error
+	GetByUserID(userID int) ([]*Favorite, error)
+	Exists(userID, recipeID int) (bool, error)
+	InWorkspace(workspaceID int) FavoriteModelInterface
+}
+
+// Favorite is a recipe a user marked as one of their favorites.
+type Favorite struct {
+	RecipeID  int       `json:"recipe_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FavoriteModel struct {
+	DB *sql.DB
+	// WorkspaceID is the only workspace whose recipes the model lets users favorite and lists, the default
+	// one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only sees the favorite recipes of the workspace.
+func (m *FavoriteModel) InWorkspace(workspaceID int) FavoriteModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	return &scoped
+}
+
+// Insert marks the recipe as a favorite of the user, favoriting it again changing nothing.
+func (m *FavoriteModel) Insert(userID, recipeID int) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		var id int
+		stmt := "SELECT id FROM recipes WHERE id = ? AND workspace_id = ? AND deleted_at IS NULL"
+		err := tx.QueryRow(stmt, recipeID, workspaceOrDefault(m.WorkspaceID)).Scan(&id)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNoRecord
+			default:
+				return err
+			}
+		}
+
+		stmt = `
+		INSERT INTO favorites (user_id, recipe_id, created)
+		VALUES (?, ?, UTC_TIMESTAMP())
+		ON DUPLICATE KEY UPDATE created = created
+		`
+
+		_, err = tx.Exec(stmt, userID, recipeID)
+		return err
+	})
+}
+
+func (m *FavoriteModel) Delete(userID, recipeID int) error {
+	result, err := m.DB.Exec("DELETE FROM favorites WHERE user_id = ? AND recipe_id = ?", userID, recipeID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
+
+// GetByUserID returns the favorites of the user in the workspace, the most recent first, leaving out
+// trashed recipes.
+func (m *FavoriteModel) GetByUserID(userID int) ([]*Favorite, error) {
+	favorites := []*Favorite{}
+
+	stmt := `
+		SELECT f.recipe_id, r.name, f.created
+		FROM favorites f INNER JOIN recipes r ON r.id = f.recipe_id
+		WHERE f.user_id = ? AND r.workspace_id = ? AND r.deleted_at IS NULL
+		ORDER BY f.created DESC, f.recipe_id DESC
+		`
+
+	rows, err := m.DB.Query(stmt, userID, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		favorite := &Favorite{}
+		if err := rows.Scan(&favorite.RecipeID, &favorite.Name, &favorite.CreatedAt); err != nil {
+			return nil, err
+		}
+		favorites = append(favorites, favorite)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return favorites, nil
+}
+
+func (m *FavoriteModel) Exists(userID, recipeID int) (bool, error) {
+	var exists bool
+	err := m.DB.QueryRow("SELECT EXISTS(SELECT true FROM favorites WHERE user_id = ? AND recipe_id = ?)", userID, recipeID).Scan(&exists)
+	return exists, err
+}
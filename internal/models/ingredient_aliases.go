@@ -0,0 +1,424 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/vladComan0/tasty-byte/internal/units"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"sort"
+	"strings"
+)
+
+// uncountable ingredients end in "s" without being plurals.
+var uncountable = map[string]bool{
+	"asparagus": true, "couscous": true, "hummus": true, "molasses": true, "swiss": true, "schnapps": true,
+}
+
+// irregularPlurals don't follow the suffix rules of singular.
+var irregularPlurals = map[string]string{
+	"leaves": "leaf", "halves": "half", "loaves": "loaf", "knives": "knife", "geese": "goose", "mice": "mouse",
+}
+
+// NormalizeIngredientName reduces an ingredient name to the form its aliases are stored in: lower-cased,
+// with single spaces and the last word in the singular, so that "Tomatoes" and " tomato" are the same name.
+func NormalizeIngredientName(name string) string {
+	words := strings.Fields(strings.ToLower(name))
+	if len(words) == 0 {
+		return ""
+	}
+
+	words[len(words)-1] = singular(words[len(words)-1])
+	return strings.Join(words, " ")
+}
+
+// singular turns an English plural into its singular, leaving the words that don't look like plurals alone.
+func singular(word string) string {
+	if singular, exists := irregularPlurals[word]; exists {
+		return singular
+	}
+	if uncountable[word] || len(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"),
+		strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "sses"),
+		strings.HasSuffix(word, "xes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// GetAliases returns the aliases of the ingredient in alphabetical order.
+func (m *IngredientModel) GetAliases(id int) ([]string, error) {
+	aliases := []string{}
+
+	stmt := "SELECT alias FROM ingredient_aliases WHERE workspace_id = ? AND ingredient_id = ? ORDER BY alias"
+	rows, err := m.DB.Query(stmt, workspaceOrDefault(m.WorkspaceID), id)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// AddAlias makes the normalized alias resolve to the ingredient. Adding an alias the ingredient
+// already has changes nothing, while one that belongs to another ingredient is a duplicate.
+func (m *IngredientModel) AddAlias(id int, alias string) error {
+	alias = NormalizeIngredientName(alias)
+	if alias == "" {
+		return ErrInvalidAlias
+	}
+
+	workspaceID := workspaceOrDefault(m.WorkspaceID)
+
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		var exists bool
+		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM ingredients WHERE id = ? AND workspace_id = ?)", id, workspaceID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNoRecord
+		}
+
+		var ingredientID int
+		err := tx.QueryRow("SELECT ingredient_id FROM ingredient_aliases WHERE workspace_id = ? AND alias = ?", workspaceID, alias).Scan(&ingredientID)
+		switch {
+		case err == nil && ingredientID == id:
+			return nil
+		case err == nil:
+			return ErrDuplicateAlias
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		_, err = tx.Exec("INSERT INTO ingredient_aliases (workspace_id, alias, ingredient_id) VALUES (?, ?, ?)", workspaceID, alias, id)
+		if err != nil {
+			var mySQLError *mysql.MySQLError
+			if errors.As(err, &mySQLError) && mySQLError.Number == 1062 {
+				return ErrDuplicateAlias
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// RemoveAlias stops the alias from resolving to the ingredient.
+func (m *IngredientModel) RemoveAlias(id int, alias string) error {
+	stmt := "DELETE FROM ingredient_aliases WHERE workspace_id = ? AND alias = ? AND ingredient_id = ?"
+	result, err := m.DB.Exec(stmt, workspaceOrDefault(m.WorkspaceID), NormalizeIngredientName(alias), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
+
+// BackfillAliases adds the normalized name of every ingredient without aliases, such as the ones that
+// predate aliases or were seeded by name, as its alias, and returns how many ingredients it added one for.
+// When several ingredients normalize to the same name, the first one gets the alias and the others are
+// left to be merged into it. Ingredients that already have aliases are left alone, so it is safe to run
+// on every start. Being a maintenance task, it goes through the ingredients of every workspace.
+func (m *IngredientModel) BackfillAliases() (int, error) {
+	stmt := `
+	SELECT id, name, workspace_id
+	FROM ingredients i
+	WHERE NOT EXISTS (SELECT 1 FROM ingredient_aliases a WHERE a.ingredient_id = i.id)
+	ORDER BY id
+	`
+
+	rows, err := m.DB.Query(stmt)
+	if err != nil {
+		return 0, err
+	}
+
+	type ingredient struct {
+		id          int
+		name        string
+		workspaceID int
+	}
+	var missing []ingredient
+	for rows.Next() {
+		var i ingredient
+		if err := rows.Scan(&i.id, &i.name, &i.workspaceID); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		missing = append(missing, i)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, err
+	}
+	_ = rows.Close()
+
+	var added int
+	err = transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		for _, i := range missing {
+			alias := NormalizeIngredientName(i.name)
+			if alias == "" {
+				continue
+			}
+
+			stmt := "INSERT IGNORE INTO ingredient_aliases (workspace_id, alias, ingredient_id) VALUES (?, ?, ?)"
+			result, err := tx.Exec(stmt, i.workspaceID, alias, i.id)
+			if err != nil {
+				return err
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			added += int(affected)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// Merge folds the source ingredients into the target one and returns the IDs of the recipes that
+// changed. Everything that refers to a source ingredient is pointed at the target instead, and the
+// names and aliases of the sources become aliases of the target. When a recipe or a pantry has both
+// ingredients, their quantities are added up in the unit of the target, failing with
+// ErrIncompatibleUnits when one can't be converted to the other. Every ingredient has to be in the workspace.
+func (m *IngredientModel) Merge(targetID int, sourceIDs []int) ([]int, error) {
+	if len(sourceIDs) == 0 || len(uniqueInts(sourceIDs)) != len(sourceIDs) {
+		return nil, ErrInvalidMerge
+	}
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return nil, ErrInvalidMerge
+		}
+	}
+
+	workspaceID := workspaceOrDefault(m.WorkspaceID)
+
+	changed := make(map[int]bool)
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		ids := append([]int{targetID}, sourceIDs...)
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+		args := []any{workspaceID}
+		for _, id := range ids {
+			args = append(args, id)
+		}
+
+		rows, err := tx.Query("SELECT id, name FROM ingredients WHERE workspace_id = ? AND id IN ("+placeholders+") FOR UPDATE", args...)
+		if err != nil {
+			return err
+		}
+		names := make(map[int]string, len(ids))
+		for rows.Next() {
+			var (
+				id   int
+				name string
+			)
+			if err := rows.Scan(&id, &name); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			names[id] = name
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		_ = rows.Close()
+
+		if len(names) != len(ids) {
+			return ErrNoRecord
+		}
+
+		for _, sourceID := range sourceIDs {
+			recipeIDs, err := repointQuantities(tx, "recipe_ingredients", "recipe_id", sourceID, targetID, MaxQuantity, func(target, source float64) float64 {
+				return target + source
+			})
+			if err != nil {
+				return err
+			}
+			for _, id := range recipeIDs {
+				changed[id] = true
+			}
+
+			// A pantry quantity of zero is an amount nobody keeps track of, which adding to doesn't change
+			_, err = repointQuantities(tx, "pantry_items", "user_id", sourceID, targetID, maxPantryQuantity, func(target, source float64) float64 {
+				if target == 0 || source == 0 {
+					return 0
+				}
+				return target + source
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := repointReferences(tx, sourceID, targetID); err != nil {
+				return err
+			}
+
+			stmt := `
+			INSERT INTO ingredient_aliases (workspace_id, alias, ingredient_id)
+			VALUES (?, ?, ?)
+			ON DUPLICATE KEY UPDATE ingredient_id = VALUES(ingredient_id)
+			`
+			if alias := NormalizeIngredientName(names[sourceID]); alias != "" {
+				if _, err := tx.Exec(stmt, workspaceID, alias, targetID); err != nil {
+					return err
+				}
+			}
+
+			if _, err := tx.Exec("DELETE FROM ingredients WHERE id = ?", sourceID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recipeIDs := make([]int, 0, len(changed))
+	for id := range changed {
+		recipeIDs = append(recipeIDs, id)
+	}
+	sort.Ints(recipeIDs)
+
+	return recipeIDs, nil
+}
+
+// repointQuantities moves the rows of a table holding ingredient quantities, keyed by the owner column
+// and the ingredient, from the source ingredient to the target one. Rows whose owner already has the
+// target ingredient are combined into it, failing with ErrQuantityOverflow when the combined quantity is
+// larger than limit. It returns the owners whose rows were moved or combined.
+func repointQuantities(tx transactions.Transaction, table, owner string, sourceID, targetID int, limit float64, combine func(target, source float64) float64) ([]int, error) {
+	stmt := fmt.Sprintf(`
+		SELECT s.%[2]s, s.quantity, s.unit, t.quantity, t.unit
+		FROM %[1]s s LEFT JOIN %[1]s t ON t.%[2]s = s.%[2]s AND t.ingredient_id = ?
+		WHERE s.ingredient_id = ?
+		FOR UPDATE
+		`, table, owner)
+
+	type collision struct {
+		owner                  int
+		sourceQuantity         float64
+		sourceUnit, targetUnit string
+		targetQuantity         float64
+	}
+
+	rows, err := tx.Query(stmt, targetID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		owners     []int
+		collisions []collision
+	)
+	for rows.Next() {
+		var (
+			c              collision
+			targetQuantity sql.NullFloat64
+			targetUnit     sql.NullString
+		)
+		if err := rows.Scan(&c.owner, &c.sourceQuantity, &c.sourceUnit, &targetQuantity, &targetUnit); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		owners = append(owners, c.owner)
+		if targetQuantity.Valid {
+			c.targetQuantity, c.targetUnit = targetQuantity.Float64, targetUnit.String
+			collisions = append(collisions, c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	_ = rows.Close()
+
+	for _, c := range collisions {
+		quantity, ok := units.Convert(c.sourceQuantity, c.sourceUnit, c.targetUnit)
+		if !ok {
+			return nil, ErrIncompatibleUnits
+		}
+
+		combined := combine(c.targetQuantity, quantity)
+		if combined > limit {
+			return nil, ErrQuantityOverflow
+		}
+
+		stmt := fmt.Sprintf("UPDATE %s SET quantity = ? WHERE %s = ? AND ingredient_id = ?", table, owner)
+		if _, err := tx.Exec(stmt, combined, c.owner, targetID); err != nil {
+			return nil, err
+		}
+
+		stmt = fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND ingredient_id = ?", table, owner)
+		if _, err := tx.Exec(stmt, c.owner, sourceID); err != nil {
+			return nil, err
+		}
+	}
+
+	stmt = fmt.Sprintf("UPDATE %s SET ingredient_id = ? WHERE ingredient_id = ?", table)
+	if _, err := tx.Exec(stmt, targetID, sourceID); err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// repointReferences points the remaining references to the source ingredient at the target one.
+// References the target already has are left to be deleted along with the source ingredient.
+func repointReferences(tx transactions.Transaction, sourceID, targetID int) error {
+	statements := []string{
+		"UPDATE IGNORE recipe_step_ingredients SET ingredient_id = ? WHERE ingredient_id = ?",
+		"UPDATE shopping_list_items SET ingredient_id = ? WHERE ingredient_id = ?",
+		"UPDATE ingredient_aliases SET ingredient_id = ? WHERE ingredient_id = ?",
+		"UPDATE IGNORE ingredient_substitutions SET ingredient_id = ? WHERE ingredient_id = ?",
+		"UPDATE IGNORE ingredient_substitutions SET substitute_id = ? WHERE substitute_id = ?",
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt, targetID, sourceID); err != nil {
+			return err
+		}
+	}
+
+	// An ingredient can't substitute itself
+	_, err := tx.Exec("DELETE FROM ingredient_substitutions WHERE ingredient_id = substitute_id")
+	return err
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeIngredientName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Tomato", expected: "tomato"},
+		{name: "tomatoes", expected: "tomato"},
+		{name: "  Roma   Tomatoes ", expected: "roma tomato"},
+		{name: "berries", expected: "berry"},
+		{name: "peaches", expected: "peach"},
+		{name: "radishes", expected: "radish"},
+		{name: "eggs", expected: "egg"},
+		{name: "bay leaves", expected: "bay leaf"},
+		{name: "green onions", expected: "green onion"},
+		{name: "molasses", expected: "molasses"},
+		{name: "asparagus", expected: "asparagus"},
+		{name: "swiss cheese", expected: "swiss cheese"},
+		{name: "watercress", expected: "watercress"},
+		{name: "peas", expected: "pea"},
+		{name: "gas", expected: "gas"},
+		{name: "   ", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizeIngredientName(tc.name))
+		})
+	}
+}
@@ -3,12 +3,22 @@ package models
 import (
 	"database/sql"
 	"errors"
+	"github.com/vladComan0/tasty-byte/internal/units"
 	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
 )
 
 type IngredientModelInterface interface {
 	GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*FullIngredient, error)
 	InsertIfNotExists(tx transactions.Transaction, name string) (int, error)
+	Get(id int) (*Ingredient, error)
+	SetClassification(id int, allergens, diets []string) error
+	Seed(classifications []*Classification) error
+	GetAliases(id int) ([]string, error)
+	AddAlias(id int, alias string) error
+	RemoveAlias(id int, alias string) error
+	Merge(targetID int, sourceIDs []int) ([]int, error)
+	InWorkspace(workspaceID int) IngredientModelInterface
 }
 
 // FullIngredient abstracts away the two models for storing ingredients and their quantities/units
@@ -18,20 +28,49 @@ type FullIngredient struct {
 	Unit     string  `json:"unit"`
 }
 
+// String formats the ingredient as a recipe line, e.g. "1 1/2 cup flour".
+func (i *FullIngredient) String() string {
+	parts := make([]string, 0, 3)
+	if i.Quantity > 0 {
+		parts = append(parts, units.FormatQuantity(i.Quantity))
+	}
+	if i.Unit != "" {
+		parts = append(parts, i.Unit)
+	}
+	if i.Ingredient != nil {
+		parts = append(parts, i.Name)
+	}
+	return strings.Join(parts, " ")
+}
+
 type Ingredient struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Allergens []string `json:"allergens,omitempty"`
+	Diets     []string `json:"diets,omitempty"`
+	// Classified tells whether anyone went through the allergens and diets of the ingredient,
+	// no allergens meaning "none" rather than "unknown" only then.
+	Classified bool `json:"-"`
 }
 
 type IngredientModel struct {
 	DB *sql.DB
+	// WorkspaceID is the only workspace whose ingredients the model sees, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only sees the ingredients, and aliases, of the workspace.
+func (m *IngredientModel) InWorkspace(workspaceID int) IngredientModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	return &scoped
 }
 
 func (m *IngredientModel) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*FullIngredient, error) {
 	var ingredients []*FullIngredient
 
 	stmt := `
-		SELECT i.id, i.name, ri.quantity, ri.unit
+		SELECT i.id, i.name, i.allergens, i.diets, i.classified, ri.quantity, ri.unit
 		FROM ingredients i INNER JOIN recipe_ingredients ri ON ri.ingredient_id = i.id
 		WHERE ri.recipe_id = ?
 		`
@@ -50,19 +89,28 @@ func (m *IngredientModel) GetByRecipeID(tx transactions.Transaction, recipeID in
 	}(rows)
 
 	for rows.Next() {
-		ingredient := &FullIngredient{
-			Ingredient: &Ingredient{},
-		}
+		var (
+			ingredient = &FullIngredient{
+				Ingredient: &Ingredient{},
+			}
+			allergens string
+			diets     string
+		)
 
 		err := rows.Scan(
 			&ingredient.ID,
 			&ingredient.Name,
+			&allergens,
+			&diets,
+			&ingredient.Classified,
 			&ingredient.Quantity,
 			&ingredient.Unit,
 		)
 		if err != nil {
 			return nil, err
 		}
+		ingredient.Allergens = splitSet(allergens)
+		ingredient.Diets = splitSet(diets)
 		ingredients = append(ingredients, ingredient)
 	}
 
@@ -73,11 +121,25 @@ func (m *IngredientModel) GetByRecipeID(tx transactions.Transaction, recipeID in
 	return ingredients, nil
 }
 
+// InsertIfNotExists returns the ingredient the name resolves to through its normalized alias, or
+// adds an ingredient with that name, and the alias for it, when there is none.
 func (m *IngredientModel) InsertIfNotExists(tx transactions.Transaction, name string) (int, error) {
+	alias := NormalizeIngredientName(name)
+	workspaceID := workspaceOrDefault(m.WorkspaceID)
+
 	var id int
-	if err := tx.QueryRow("SELECT id FROM ingredients WHERE name = ?", name).Scan(&id); err != nil {
+	err := tx.QueryRow("SELECT ingredient_id FROM ingredient_aliases WHERE workspace_id = ? AND alias = ?", workspaceID, alias).Scan(&id)
+	switch {
+	case err == nil:
+		return id, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return 0, err
+	}
+
+	name = strings.Join(strings.Fields(name), " ")
+	if err := tx.QueryRow("SELECT id FROM ingredients WHERE workspace_id = ? AND name = ?", workspaceID, name).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			result, err := tx.Exec("INSERT INTO ingredients(name) VALUES (?)", name)
+			result, err := tx.Exec("INSERT INTO ingredients(workspace_id, name) VALUES (?, ?)", workspaceID, name)
 			if err != nil {
 				return 0, err
 			}
@@ -91,5 +153,105 @@ func (m *IngredientModel) InsertIfNotExists(tx transactions.Transaction, name st
 		}
 	}
 
+	if _, err := tx.Exec("INSERT IGNORE INTO ingredient_aliases (workspace_id, alias, ingredient_id) VALUES (?, ?, ?)", workspaceID, alias, id); err != nil {
+		return 0, err
+	}
+
 	return id, nil
 }
+
+func (m *IngredientModel) Get(id int) (*Ingredient, error) {
+	var (
+		ingredient = &Ingredient{}
+		allergens  string
+		diets      string
+	)
+
+	stmt := `
+		SELECT id, name, allergens, diets, classified
+		FROM ingredients
+		WHERE id = ? AND workspace_id = ?
+		`
+
+	err := m.DB.QueryRow(stmt, id, workspaceOrDefault(m.WorkspaceID)).Scan(
+		&ingredient.ID,
+		&ingredient.Name,
+		&allergens,
+		&diets,
+		&ingredient.Classified,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+	ingredient.Allergens = splitSet(allergens)
+	ingredient.Diets = splitSet(diets)
+
+	return ingredient, nil
+}
+
+// SetClassification replaces the allergens and diets of the ingredient and marks it as classified.
+func (m *IngredientModel) SetClassification(id int, allergens, diets []string) error {
+	for _, allergen := range allergens {
+		if !ValidAllergen(allergen) {
+			return ErrInvalidClassification
+		}
+	}
+	for _, diet := range diets {
+		if !ValidDiet(diet) {
+			return ErrInvalidClassification
+		}
+	}
+
+	stmt := `
+		UPDATE ingredients
+		SET allergens = ?, diets = ?, classified = TRUE
+		WHERE id = ? AND workspace_id = ?
+		`
+
+	result, err := m.DB.Exec(stmt, strings.Join(allergens, ","), strings.Join(diets, ","), id, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return err
+	}
+
+	// Setting the same values again doesn't count as affecting the row, so it is looked up instead
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		if _, err := m.Get(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Seed classifies the given ingredients of the workspace, adding the ones that don't exist yet.
+// Ingredients that are already classified are left as they are.
+func (m *IngredientModel) Seed(classifications []*Classification) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		stmt := `
+		INSERT INTO ingredients
+			(workspace_id, name, allergens, diets, classified)
+		VALUES
+			(?, ?, ?, ?, TRUE)
+		ON DUPLICATE KEY UPDATE
+			allergens = IF(classified, allergens, VALUES(allergens)),
+			diets = IF(classified, diets, VALUES(diets)),
+			classified = TRUE
+		`
+		for _, c := range classifications {
+			_, err := tx.Exec(stmt, workspaceOrDefault(m.WorkspaceID), c.Name, strings.Join(c.Allergens, ","), strings.Join(c.Diets, ","))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
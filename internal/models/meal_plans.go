@@ -0,0 +1,178 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+)
+
+type MealPlanModelInterface interface {
+	Insert(plan *MealPlan) (int, error)
+	Get(id int) (*MealPlan, error)
+	GetRange(from, to time.Time) ([]*MealPlan, error)
+	Update(plan *MealPlan) error
+	Delete(id int) error
+	InWorkspace(workspaceID int) MealPlanModelInterface
+}
+
+// DateLayout is the format of the dates meals are planned on.
+const DateLayout = "2006-01-02"
+
+// MealSlots are the meals of a day, in the order they are eaten.
+var MealSlots = []string{"breakfast", "lunch", "dinner"}
+
+// ValidMealSlot reports whether the slot is one of MealSlots.
+func ValidMealSlot(slot string) bool {
+	return slices.Contains(MealSlots, slot)
+}
+
+// MealPlan schedules a recipe, for a number of portions, on a meal of a given day.
+type MealPlan struct {
+	ID         int       `json:"id"`
+	Date       string    `json:"date"`
+	Slot       string    `json:"slot"`
+	RecipeID   int       `json:"recipe_id"`
+	RecipeName string    `json:"recipe_name"`
+	Portions   int       `json:"portions"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"-"`
+}
+
+type MealPlanModel struct {
+	DB *sql.DB
+	// WorkspaceID is the only workspace whose meal plans the model sees, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only sees the meal plans of the workspace.
+func (m *MealPlanModel) InWorkspace(workspaceID int) MealPlanModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	return &scoped
+}
+
+// mealPlanColumns are the columns every query scans with scanMealPlan. Meals of recipes in the trash are
+// hidden along with them, and come back once they are restored.
+const mealPlanColumns = `
+		SELECT mp.id, mp.date, mp.slot, mp.recipe_id, r.name, mp.portions, mp.note, mp.created
+		FROM meal_plans mp
+		INNER JOIN recipes r ON r.id = mp.recipe_id AND r.workspace_id = mp.workspace_id AND r.deleted_at IS NULL
+		`
+
+func scanMealPlan(row interface{ Scan(dest ...any) error }) (*MealPlan, error) {
+	var (
+		plan = &MealPlan{}
+		date time.Time
+	)
+
+	err := row.Scan(
+		&plan.ID,
+		&date,
+		&plan.Slot,
+		&plan.RecipeID,
+		&plan.RecipeName,
+		&plan.Portions,
+		&plan.Note,
+		&plan.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	plan.Date = date.Format(DateLayout)
+
+	return plan, nil
+}
+
+func (m *MealPlanModel) Insert(plan *MealPlan) (int, error) {
+	stmt := `
+		INSERT INTO meal_plans (date, slot, recipe_id, portions, note, workspace_id, created)
+		VALUES (?, ?, ?, ?, ?, ?, UTC_TIMESTAMP())
+		`
+
+	result, err := m.DB.Exec(stmt, plan.Date, plan.Slot, plan.RecipeID, plan.Portions, plan.Note, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (m *MealPlanModel) Get(id int) (*MealPlan, error) {
+	plan, err := scanMealPlan(m.DB.QueryRow(mealPlanColumns+"WHERE mp.id = ? AND mp.workspace_id = ?", id, workspaceOrDefault(m.WorkspaceID)))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// GetRange returns the meals planned from one date to another, both included, in the order they are eaten.
+func (m *MealPlanModel) GetRange(from, to time.Time) ([]*MealPlan, error) {
+	plans := []*MealPlan{}
+
+	stmt := mealPlanColumns + `
+		WHERE mp.workspace_id = ? AND mp.date BETWEEN ? AND ?
+		ORDER BY mp.date, FIELD(mp.slot, 'breakfast', 'lunch', 'dinner'), mp.id
+		`
+
+	rows, err := m.DB.Query(stmt, workspaceOrDefault(m.WorkspaceID), from.Format(DateLayout), to.Format(DateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		plan, err := scanMealPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (m *MealPlanModel) Update(plan *MealPlan) error {
+	stmt := `
+		UPDATE meal_plans
+		SET date = ?, slot = ?, recipe_id = ?, portions = ?, note = ?
+		WHERE id = ? AND workspace_id = ?
+		`
+
+	_, err := m.DB.Exec(stmt, plan.Date, plan.Slot, plan.RecipeID, plan.Portions, plan.Note, plan.ID, workspaceOrDefault(m.WorkspaceID))
+	return err
+}
+
+func (m *MealPlanModel) Delete(id int) error {
+	result, err := m.DB.Exec("DELETE FROM meal_plans WHERE id = ? AND workspace_id = ?", id, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
+)
+
+type NutritionModelInterface interface {
+	GetByNames(names []string) (map[string]*NutritionFacts, error)
+	Upsert(facts []*NutritionFacts) error
+}
+
+// Nutrients holds an energy value in kcal and macronutrients in grams.
+type Nutrients struct {
+	Calories      float64 `json:"calories"`
+	Protein       float64 `json:"protein"`
+	Carbohydrates float64 `json:"carbohydrates"`
+	Sugar         float64 `json:"sugar"`
+	Fat           float64 `json:"fat"`
+	Fiber         float64 `json:"fiber"`
+}
+
+// NutritionFacts describes an ingredient per 100 g. Density (g/ml) converts volumes to grams,
+// PieceWeight (g) converts counts, such as "2 eggs" or "1 clove", to grams.
+type NutritionFacts struct {
+	Name        string    `json:"name"`
+	Per100g     Nutrients `json:"per_100g"`
+	Density     *float64  `json:"density,omitempty"`
+	PieceWeight *float64  `json:"piece_weight,omitempty"`
+}
+
+type NutritionModel struct {
+	DB *sql.DB
+}
+
+// GetByNames returns the nutrition facts of the given ingredients, keyed by lower-cased name.
+// Names that have no facts are simply missing from the result.
+func (m *NutritionModel) GetByNames(names []string) (map[string]*NutritionFacts, error) {
+	results := make(map[string]*NutritionFacts, len(names))
+	if len(names) == 0 {
+		return results, nil
+	}
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+
+	stmt := `
+		SELECT name, calories, protein, carbohydrates, sugar, fat, fiber, density, piece_weight
+		FROM nutrition
+		WHERE name IN (?` + strings.Repeat(", ?", len(names)-1) + `)
+		`
+
+	rows, err := m.DB.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			facts       = &NutritionFacts{}
+			density     sql.NullFloat64
+			pieceWeight sql.NullFloat64
+		)
+
+		err := rows.Scan(
+			&facts.Name,
+			&facts.Per100g.Calories,
+			&facts.Per100g.Protein,
+			&facts.Per100g.Carbohydrates,
+			&facts.Per100g.Sugar,
+			&facts.Per100g.Fat,
+			&facts.Per100g.Fiber,
+			&density,
+			&pieceWeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if density.Valid {
+			facts.Density = &density.Float64
+		}
+		if pieceWeight.Valid {
+			facts.PieceWeight = &pieceWeight.Float64
+		}
+		results[strings.ToLower(facts.Name)] = facts
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Upsert inserts the given nutrition facts, replacing the values of ingredients that already have some.
+func (m *NutritionModel) Upsert(facts []*NutritionFacts) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		stmt := `
+		INSERT INTO nutrition
+			(name, calories, protein, carbohydrates, sugar, fat, fiber, density, piece_weight)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			calories = VALUES(calories),
+			protein = VALUES(protein),
+			carbohydrates = VALUES(carbohydrates),
+			sugar = VALUES(sugar),
+			fat = VALUES(fat),
+			fiber = VALUES(fiber),
+			density = VALUES(density),
+			piece_weight = VALUES(piece_weight)
+		`
+		for _, f := range facts {
+			_, err := tx.Exec(
+				stmt,
+				f.Name,
+				f.Per100g.Calories,
+				f.Per100g.Protein,
+				f.Per100g.Carbohydrates,
+				f.Per100g.Sugar,
+				f.Per100g.Fat,
+				f.Per100g.Fiber,
+				f.Density,
+				f.PieceWeight,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vladComan0/tasty-byte/internal/units"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"math"
+	"time"
+)
+
+type PantryModelInterface interface {
+	GetByUserID(userID int) ([]*PantryItem, error)
+	Set(userID int, item *PantryItem) error
+	Delete(userID, ingredientID int) error
+	Cook(userID, recipeID int, ingredients []*FullIngredient) error
+}
+
+// maxPantryQuantity is the largest quantity of an ingredient a pantry can hold.
+const maxPantryQuantity = 999999.99
+
+// PantryItem is an ingredient a user has at home. A quantity of zero stands for an amount nobody
+// keeps track of, such as salt, which is always enough and is never used up.
+type PantryItem struct {
+	IngredientID int       `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// PantryModel stores the pantry of every user, which is the same in every workspace: its ingredients
+// are those of the default workspace, IngredientModel being the one of that workspace.
+type PantryModel struct {
+	DB              *sql.DB
+	IngredientModel IngredientModelInterface
+}
+
+func (m *PantryModel) GetByUserID(userID int) ([]*PantryItem, error) {
+	items := []*PantryItem{}
+
+	stmt := `
+		SELECT p.ingredient_id, i.name, p.quantity, p.unit, p.updated
+		FROM pantry_items p INNER JOIN ingredients i ON i.id = p.ingredient_id
+		WHERE p.user_id = ?
+		ORDER BY i.name
+		`
+
+	rows, err := m.DB.Query(stmt, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		item := &PantryItem{}
+		if err := rows.Scan(&item.IngredientID, &item.Name, &item.Quantity, &item.Unit, &item.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Set stores how much of an ingredient, identified by name, the user has, replacing any previous amount.
+func (m *PantryModel) Set(userID int, item *PantryItem) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		ingredientID, err := m.IngredientModel.InsertIfNotExists(tx, item.Name)
+		if err != nil {
+			return err
+		}
+		item.IngredientID = ingredientID
+
+		stmt := `
+		INSERT INTO pantry_items (user_id, ingredient_id, quantity, unit, updated)
+		VALUES (?, ?, ?, ?, UTC_TIMESTAMP())
+		ON DUPLICATE KEY UPDATE
+			quantity = VALUES(quantity),
+			unit = VALUES(unit),
+			updated = VALUES(updated)
+		`
+		_, err = tx.Exec(stmt, userID, ingredientID, item.Quantity, item.Unit)
+		return err
+	})
+}
+
+func (m *PantryModel) Delete(userID, ingredientID int) error {
+	result, err := m.DB.Exec("DELETE FROM pantry_items WHERE user_id = ? AND ingredient_id = ?", userID, ingredientID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
+
+// Cook records that the user cooked the recipe just now and takes the amounts of its ingredients out
+// of the pantry of the user, removing the items that run out, both or neither. Ingredients are found in the pantry by ID or, for those of recipes of other workspaces than the
+// default one, by name, like the pantry matches recipes. Ingredients the user doesn't have, untracked
+// amounts and amounts in units that can't be converted to the one of the pantry are left alone.
+func (m *PantryModel) Cook(userID, recipeID int, ingredients []*FullIngredient) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		stmt := `
+		INSERT INTO cooking_history (user_id, recipe_id, cooked)
+		VALUES (?, ?, UTC_TIMESTAMP())
+		`
+		if _, err := tx.Exec(stmt, userID, recipeID); err != nil {
+			return err
+		}
+
+		for _, ingredient := range ingredients {
+			if ingredient == nil || ingredient.Ingredient == nil || ingredient.Quantity == 0 {
+				continue
+			}
+
+			var (
+				ingredientID int
+				quantity     float64
+				unit         string
+			)
+			stmt := `
+			SELECT p.ingredient_id, p.quantity, p.unit
+			FROM pantry_items p INNER JOIN ingredients i ON i.id = p.ingredient_id
+			WHERE p.user_id = ? AND (p.ingredient_id = ? OR i.name = ?)
+			ORDER BY p.ingredient_id = ? DESC
+			LIMIT 1
+			FOR UPDATE
+			`
+			err := tx.QueryRow(stmt, userID, ingredient.ID, ingredient.Name, ingredient.ID).Scan(&ingredientID, &quantity, &unit)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return err
+			}
+			if quantity == 0 {
+				continue
+			}
+
+			used, ok := units.Convert(ingredient.Quantity, ingredient.Unit, unit)
+			if !ok {
+				continue
+			}
+
+			remaining := math.Round((quantity-used)*100) / 100
+			if remaining <= 0 {
+				_, err = tx.Exec("DELETE FROM pantry_items WHERE user_id = ? AND ingredient_id = ?", userID, ingredientID)
+			} else {
+				_, err = tx.Exec(
+					"UPDATE pantry_items SET quantity = ?, updated = UTC_TIMESTAMP() WHERE user_id = ? AND ingredient_id = ?",
+					remaining, userID, ingredientID,
+				)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
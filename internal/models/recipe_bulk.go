@@ -0,0 +1,201 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+)
+
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportFailed  = "failed"
+)
+
+// errDryRun is used to roll back the transaction of a dry-run import once every recipe has been processed.
+var errDryRun = errors.New("models: dry run")
+
+// ImportResult is the outcome of importing a single recipe.
+type ImportResult struct {
+	ID     int
+	Status string
+	Err    error
+}
+
+// Stream calls fn for every recipe of the workspace, with its ingredients, tags, steps and images, in ascending ID order.
+// Recipes are read in batches of batchSize, so that the whole catalogue never has to be held in memory.
+// Iteration stops at the first error returned by fn.
+func (m *RecipeModel) Stream(batchSize int, fn func(recipe *Recipe) error) error {
+	stmt := `
+	SELECT
+		id,
+		name,
+		description,
+		instructions,
+		preparation_time,
+		cooking_time,
+		portions,
+		created
+	FROM
+		recipes
+	WHERE
+		id > ? AND workspace_id = ? AND deleted_at IS NULL
+	ORDER BY
+		id
+	LIMIT ?`
+
+	lastID := 0
+	for {
+		batch, err := m.streamBatch(stmt, lastID, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, recipe := range batch {
+			if recipe.Ingredients, err = m.IngredientModel.GetByRecipeID(m.DB, recipe.ID); err != nil {
+				return err
+			}
+			recipe.classify()
+
+			if recipe.Tags, err = m.TagModel.GetByRecipeID(m.DB, recipe.ID); err != nil {
+				return err
+			}
+
+			if recipe.Steps, err = m.RecipeStepModel.GetByRecipeID(m.DB, recipe.ID); err != nil {
+				return err
+			}
+
+			imagesByRecipe, err := m.RecipeImageModel.GetByRecipeIDs(m.DB, []int{recipe.ID})
+			if err != nil {
+				return err
+			}
+			recipe.Images = imagesByRecipe[recipe.ID]
+
+			if err := fn(recipe); err != nil {
+				return err
+			}
+			lastID = recipe.ID
+		}
+
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (m *RecipeModel) streamBatch(stmt string, lastID, batchSize int) ([]*Recipe, error) {
+	var recipes []*Recipe
+
+	rows, err := m.DB.Query(stmt, lastID, workspaceOrDefault(m.WorkspaceID), batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		recipe := &Recipe{}
+
+		err := rows.Scan(
+			&recipe.ID,
+			&recipe.Name,
+			&recipe.Description,
+			&recipe.Instructions,
+			&recipe.PreparationTime,
+			&recipe.CookingTime,
+			&recipe.Portions,
+			&recipe.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, recipe)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+// ImportBatch imports a batch of recipes in a single transaction. Every recipe runs inside its own
+// savepoint, so a failing recipe is reported in its result without aborting the rest of the batch.
+// With upsert, a recipe replaces the existing recipe with the same name instead of creating a new one.
+// With dryRun, the transaction is rolled back once the batch has been processed.
+func (m *RecipeModel) ImportBatch(recipes []*Recipe, upsert, dryRun bool) ([]*ImportResult, error) {
+	results := make([]*ImportResult, len(recipes))
+
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		for i, recipe := range recipes {
+			savepoint := fmt.Sprintf("import_%d", i)
+			if _, err := tx.Exec("SAVEPOINT " + savepoint); err != nil {
+				return err
+			}
+
+			result := m.importRecipe(tx, recipe, upsert)
+			if result.Err != nil {
+				if _, err := tx.Exec("ROLLBACK TO SAVEPOINT " + savepoint); err != nil {
+					return err
+				}
+			}
+
+			if _, err := tx.Exec("RELEASE SAVEPOINT " + savepoint); err != nil {
+				return err
+			}
+			results[i] = result
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	if !dryRun {
+		for i, result := range results {
+			if result.Err == nil {
+				recipes[i].ID = result.ID
+				m.putIndex(recipes[i])
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (m *RecipeModel) importRecipe(tx transactions.Transaction, recipe *Recipe, upsert bool) *ImportResult {
+	if upsert {
+		var id int
+		stmt := `
+		SELECT id
+		FROM recipes
+		WHERE name = ? AND workspace_id = ? AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT 1
+		`
+		err := tx.QueryRow(stmt, recipe.Name, workspaceOrDefault(m.WorkspaceID)).Scan(&id)
+		switch {
+		case err == nil:
+			recipe.ID = id
+			if err := m.UpdateWithTx(tx, recipe); err != nil {
+				return &ImportResult{Status: ImportFailed, Err: err}
+			}
+			return &ImportResult{ID: id, Status: ImportUpdated}
+		case !errors.Is(err, sql.ErrNoRows):
+			return &ImportResult{Status: ImportFailed, Err: err}
+		}
+	}
+
+	id, err := m.InsertWithTx(tx, recipe)
+	if err != nil {
+		return &ImportResult{Status: ImportFailed, Err: err}
+	}
+
+	return &ImportResult{ID: id, Status: ImportCreated}
+}
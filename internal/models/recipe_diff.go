@@ -0,0 +1,148 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// FieldChange describes a single field that differs between two versions of a recipe.
+// Ingredients and tags are reported per item, e.g. "ingredients.flour" or "tags.vegan", and steps
+// per position, e.g. "steps.2", with a nil From/To when the item was added/removed.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type stepContent struct {
+	Text          string `json:"text"`
+	Duration      string `json:"duration,omitempty"`
+	IngredientIDs []int  `json:"ingredient_ids,omitempty"`
+}
+
+type ingredientAmount struct {
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+}
+
+// DiffRecipes returns the field-level changes needed to go from one version of a recipe to another.
+func DiffRecipes(from, to *Recipe) []*FieldChange {
+	changes := []*FieldChange{}
+
+	scalars := []struct {
+		field    string
+		from, to any
+	}{
+		{"name", from.Name, to.Name},
+		{"description", from.Description, to.Description},
+		{"instructions", from.Instructions, to.Instructions},
+		{"preparation_time", from.PreparationTime, to.PreparationTime},
+		{"cooking_time", from.CookingTime, to.CookingTime},
+		{"portions", from.Portions, to.Portions},
+	}
+	for _, scalar := range scalars {
+		if scalar.from != scalar.to {
+			changes = append(changes, &FieldChange{Field: scalar.field, From: scalar.from, To: scalar.to})
+		}
+	}
+
+	fromIngredients := ingredientAmountsByName(from.Ingredients)
+	toIngredients := ingredientAmountsByName(to.Ingredients)
+	for _, name := range unionKeys(fromIngredients, toIngredients) {
+		before, inBefore := fromIngredients[name]
+		after, inAfter := toIngredients[name]
+		if inBefore && inAfter && *before == *after {
+			continue
+		}
+
+		change := &FieldChange{Field: "ingredients." + name}
+		if inBefore {
+			change.From = before
+		}
+		if inAfter {
+			change.To = after
+		}
+		changes = append(changes, change)
+	}
+
+	fromTags := tagNames(from.Tags)
+	toTags := tagNames(to.Tags)
+	for _, name := range unionKeys(fromTags, toTags) {
+		_, inBefore := fromTags[name]
+		_, inAfter := toTags[name]
+		if inBefore && inAfter {
+			continue
+		}
+
+		change := &FieldChange{Field: "tags." + name}
+		if inBefore {
+			change.From = name
+		}
+		if inAfter {
+			change.To = name
+		}
+		changes = append(changes, change)
+	}
+
+	for i := 0; i < max(len(from.Steps), len(to.Steps)); i++ {
+		before, after := stepContentAt(from.Steps, i), stepContentAt(to.Steps, i)
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+
+		change := &FieldChange{Field: "steps." + strconv.Itoa(i+1)}
+		if before != nil {
+			change.From = before
+		}
+		if after != nil {
+			change.To = after
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func stepContentAt(steps []*Step, i int) *stepContent {
+	if i >= len(steps) || steps[i] == nil {
+		return nil
+	}
+	return &stepContent{Text: steps[i].Text, Duration: steps[i].Duration, IngredientIDs: steps[i].IngredientIDs}
+}
+
+func ingredientAmountsByName(ingredients []*FullIngredient) map[string]*ingredientAmount {
+	amounts := make(map[string]*ingredientAmount, len(ingredients))
+	for _, ingredient := range ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil {
+			continue
+		}
+		amounts[ingredient.Name] = &ingredientAmount{Quantity: ingredient.Quantity, Unit: ingredient.Unit}
+	}
+	return amounts
+}
+
+func tagNames(tags []*Tag) map[string]struct{} {
+	names := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		names[tag.Name] = struct{}{}
+	}
+	return names
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"strings"
+)
+
+// The orders recipes can be listed in, a leading "-" meaning descending. Among equal ratings, the ones
+// based on more reviews come first in either direction.
+const (
+	SortRating     = "rating"
+	SortRatingDesc = "-rating"
+)
+
+// ValidSort tells whether recipes can be listed in the order.
+func ValidSort(order string) bool {
+	switch order {
+	case SortRating, SortRatingDesc:
+		return true
+	}
+	return false
+}
+
+// RecipeFilter narrows down a listing of recipes. The filters are applied by the database, so that
+// a page of recipes, and the facets of all those matching, can be worked out without loading the
+// whole catalogue.
+type RecipeFilter struct {
+	// ExcludedAllergens leaves out the recipes with an ingredient containing any of the allergens
+	ExcludedAllergens []string
+	// Diets keeps the recipes that suit every one of the diets, that is whose ingredients were all
+	// classified as suiting it
+	Diets []string
+	// TagGroups keeps the recipes that have one of the tags of every group, a group usually being a tag
+	// and all the tags under it. An empty group, such as the one of a tag that doesn't exist, matches
+	// no recipe.
+	TagGroups [][]int
+	// Sort is the order of the recipes, by ID when empty
+	Sort string
+	// Limit is the number of recipes of the page, all of them when zero, and Offset the number of
+	// recipes before it
+	Limit  int
+	Offset int
+}
+
+// condition returns the SQL condition, on the "recipes" table, of the recipes of the workspace that
+// haven't been trashed and match the filter, along with its arguments.
+func (f *RecipeFilter) condition(workspaceID int) (string, []any) {
+	conditions := []string{"recipes.workspace_id = ?", "recipes.deleted_at IS NULL"}
+	args := []any{workspaceOrDefault(workspaceID)}
+
+	for _, allergen := range f.ExcludedAllergens {
+		conditions = append(conditions, `NOT EXISTS (
+			SELECT 1
+			FROM recipe_ingredients ri INNER JOIN ingredients i ON i.id = ri.ingredient_id
+			WHERE ri.recipe_id = recipes.id AND FIND_IN_SET(?, i.allergens)
+		)`)
+		args = append(args, allergen)
+	}
+
+	// Like Recipe.classify, a recipe without ingredients or with one that wasn't classified suits no diet
+	for _, diet := range f.Diets {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM recipe_ingredients ri WHERE ri.recipe_id = recipes.id
+		) AND NOT EXISTS (
+			SELECT 1
+			FROM recipe_ingredients ri INNER JOIN ingredients i ON i.id = ri.ingredient_id
+			WHERE ri.recipe_id = recipes.id AND (NOT i.classified OR NOT FIND_IN_SET(?, i.diets))
+		)`)
+		args = append(args, diet)
+	}
+
+	for _, group := range f.TagGroups {
+		if len(group) == 0 {
+			conditions = append(conditions, "FALSE")
+			continue
+		}
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM recipe_tags rt WHERE rt.recipe_id = recipes.id AND rt.tag_id IN (?`+strings.Repeat(", ?", len(group)-1)+`)
+		)`)
+		for _, id := range group {
+			args = append(args, id)
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// orderBy returns the SQL ordering of the recipes by the filter, on the "recipes" table.
+func (f *RecipeFilter) orderBy() string {
+	switch f.Sort {
+	case SortRating:
+		return "recipes.rating_average, recipes.rating_count DESC, recipes.id"
+	case SortRatingDesc:
+		return "recipes.rating_average DESC, recipes.rating_count DESC, recipes.id"
+	default:
+		return "recipes.id"
+	}
+}
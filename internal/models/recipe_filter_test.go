@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipeFilterCondition(t *testing.T) {
+	filter := &RecipeFilter{
+		ExcludedAllergens: []string{"nuts"},
+		Diets:             []string{"vegan"},
+		TagGroups:         [][]int{{1, 2}, {5}},
+	}
+
+	condition, args := filter.condition(2)
+
+	// Every placeholder has its argument, in order
+	assert.Equal(t, strings.Count(condition, "?"), len(args))
+	assert.Equal(t, []any{2, "nuts", "vegan", 1, 2, 5}, args)
+	assert.Contains(t, condition, "recipes.deleted_at IS NULL")
+
+	// A tag that doesn't exist matches no recipe, and the default workspace is the one of unscoped models
+	condition, args = (&RecipeFilter{TagGroups: [][]int{{}}}).condition(0)
+	assert.Contains(t, condition, "FALSE")
+	assert.Equal(t, []any{DefaultWorkspaceID}, args)
+}
+
+func TestRecipeFilterOrderBy(t *testing.T) {
+	assert.Equal(t, "recipes.id", (&RecipeFilter{}).orderBy())
+	assert.True(t, strings.HasPrefix((&RecipeFilter{Sort: SortRating}).orderBy(), "recipes.rating_average,"))
+	assert.True(t, strings.HasPrefix((&RecipeFilter{Sort: SortRatingDesc}).orderBy(), "recipes.rating_average DESC,"))
+	assert.True(t, ValidSort(SortRatingDesc))
+	assert.False(t, ValidSort("-spiciness"))
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/vladComan0/tasty-byte/internal/blobstore"
+	"github.com/vladComan0/tasty-byte/internal/images"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"log"
+	"strings"
+	"time"
+)
+
+// ImagesPath is the path images are served from, see the "/v1/images/*key" route.
+const ImagesPath = "/v1/images/"
+
+type RecipeImageModelInterface interface {
+	Insert(recipeID int, full, thumbnail *images.Encoded) (*Image, error)
+	GetByRecipeIDs(tx transactions.Transaction, recipeIDs []int) (map[int][]*Image, error)
+	Delete(recipeID, imageID int) error
+	DeleteByRecipeID(tx transactions.Transaction, recipeID int) ([]string, error)
+	RemoveBlobs(keys []string)
+}
+
+// Image is a picture of a recipe. The full-size image and its thumbnail are kept in the blob store.
+type Image struct {
+	ID           int       `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	ContentType  string    `json:"content_type"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	CreatedAt    time.Time `json:"created_at"`
+	Key          string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+}
+
+type RecipeImageModel struct {
+	DB    *sql.DB
+	Store blobstore.Store
+}
+
+// Insert stores both versions of an image under new random keys and records them for the recipe.
+// Keys are never reused, which allows the files to be cached forever.
+func (m *RecipeImageModel) Insert(recipeID int, full, thumbnail *images.Encoded) (*Image, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	base := fmt.Sprintf("recipes/%d/%s", recipeID, hex.EncodeToString(token))
+
+	image := &Image{
+		ContentType:  full.ContentType,
+		Width:        full.Width,
+		Height:       full.Height,
+		Key:          base + full.Extension,
+		ThumbnailKey: base + "_thumb" + thumbnail.Extension,
+	}
+
+	if err := m.Store.Put(image.Key, bytes.NewReader(full.Data)); err != nil {
+		return nil, err
+	}
+	if err := m.Store.Put(image.ThumbnailKey, bytes.NewReader(thumbnail.Data)); err != nil {
+		m.RemoveBlobs([]string{image.Key})
+		return nil, err
+	}
+
+	stmt := `
+	INSERT INTO recipe_images
+		(recipe_id, image_key, thumbnail_key, content_type, width, height, created)
+	VALUES
+		(?, ?, ?, ?, ?, ?, UTC_TIMESTAMP())
+	`
+	result, err := m.DB.Exec(stmt, recipeID, image.Key, image.ThumbnailKey, image.ContentType, image.Width, image.Height)
+	if err != nil {
+		m.RemoveBlobs([]string{image.Key, image.ThumbnailKey})
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	image.ID = int(id)
+	image.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	image.setURLs()
+
+	return image, nil
+}
+
+// GetByRecipeIDs returns the images of the given recipes, keyed by recipe ID, oldest first.
+func (m *RecipeImageModel) GetByRecipeIDs(tx transactions.Transaction, recipeIDs []int) (map[int][]*Image, error) {
+	results := make(map[int][]*Image, len(recipeIDs))
+	if len(recipeIDs) == 0 {
+		return results, nil
+	}
+
+	args := make([]any, len(recipeIDs))
+	for i, id := range recipeIDs {
+		args[i] = id
+	}
+
+	stmt := `
+		SELECT id, recipe_id, image_key, thumbnail_key, content_type, width, height, created
+		FROM recipe_images
+		WHERE recipe_id IN (?` + strings.Repeat(", ?", len(recipeIDs)-1) + `)
+		ORDER BY id
+		`
+
+	rows, err := tx.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			recipeID int
+			image    = &Image{}
+		)
+
+		err := rows.Scan(
+			&image.ID,
+			&recipeID,
+			&image.Key,
+			&image.ThumbnailKey,
+			&image.ContentType,
+			&image.Width,
+			&image.Height,
+			&image.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		image.setURLs()
+		results[recipeID] = append(results[recipeID], image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Delete removes a single image of a recipe along with its files.
+func (m *RecipeImageModel) Delete(recipeID, imageID int) error {
+	var keys []string
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		var key, thumbnailKey string
+		stmt := `SELECT image_key, thumbnail_key FROM recipe_images WHERE id = ? AND recipe_id = ? FOR UPDATE`
+		if err := tx.QueryRow(stmt, imageID, recipeID).Scan(&key, &thumbnailKey); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNoRecord
+			}
+			return err
+		}
+
+		if _, err := tx.Exec("DELETE FROM recipe_images WHERE id = ?", imageID); err != nil {
+			return err
+		}
+
+		keys = []string{key, thumbnailKey}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.RemoveBlobs(keys)
+	return nil
+}
+
+// DeleteByRecipeID removes the image records of a recipe and returns the keys of their files,
+// which are to be removed with RemoveBlobs once the transaction has been committed.
+func (m *RecipeImageModel) DeleteByRecipeID(tx transactions.Transaction, recipeID int) ([]string, error) {
+	var keys []string
+
+	rows, err := tx.Query("SELECT image_key, thumbnail_key FROM recipe_images WHERE recipe_id = ?", recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var key, thumbnailKey string
+		if err := rows.Scan(&key, &thumbnailKey); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		keys = append(keys, key, thumbnailKey)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	_ = rows.Close()
+
+	if _, err := tx.Exec("DELETE FROM recipe_images WHERE recipe_id = ?", recipeID); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RemoveBlobs deletes files from the blob store. Failures only leave orphaned files behind,
+// so they are logged rather than returned.
+func (m *RecipeImageModel) RemoveBlobs(keys []string) {
+	for _, key := range keys {
+		if err := m.Store.Delete(key); err != nil {
+			log.Printf("could not delete blob %s: %v", key, err)
+		}
+	}
+}
+
+func (i *Image) setURLs() {
+	i.URL = ImagesPath + i.Key
+	i.ThumbnailURL = ImagesPath + i.ThumbnailKey
+}
@@ -14,6 +14,9 @@ type RecipeIngredientModelInterface interface {
 	deleteRecordsByRecipe(tx transactions.Transaction, recipeID int) error
 }
 
+// MaxQuantity is the largest quantity of an ingredient a recipe can hold.
+const MaxQuantity = 999.99
+
 type RecipeIngredient struct {
 	RecipeID     int     `json:"recipe_id"`
 	IngredientID int     `json:"ingredient_id"`
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"time"
+)
+
+type RecipeRevisionModelInterface interface {
+	Insert(tx transactions.Transaction, recipe *Recipe) (int, error)
+	GetAll(recipeID int) ([]*RecipeRevision, error)
+	Get(recipeID, revision int) (*RecipeRevision, error)
+}
+
+// RecipeRevision is a snapshot of a recipe, including its ingredients and tags,
+// as it was right before an update was applied to it.
+type RecipeRevision struct {
+	ID        int       `json:"id"`
+	RecipeID  int       `json:"recipe_id"`
+	Revision  int       `json:"revision"`
+	Recipe    *Recipe   `json:"recipe,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RecipeRevisionModel struct {
+	DB *sql.DB
+}
+
+// Insert stores a snapshot of the given recipe under the next revision number for that recipe.
+func (m *RecipeRevisionModel) Insert(tx transactions.Transaction, recipe *Recipe) (int, error) {
+	snapshot, err := json.Marshal(recipe)
+	if err != nil {
+		return 0, err
+	}
+
+	var revision int
+	stmt := `
+		SELECT COALESCE(MAX(revision), 0) + 1
+		FROM recipe_revisions
+		WHERE recipe_id = ?
+		FOR UPDATE
+		`
+	if err := tx.QueryRow(stmt, recipe.ID).Scan(&revision); err != nil {
+		return 0, err
+	}
+
+	stmt = `
+		INSERT INTO recipe_revisions
+			(recipe_id, revision, snapshot, created)
+		VALUES
+			(?, ?, ?, UTC_TIMESTAMP())
+		`
+	if _, err := tx.Exec(stmt, recipe.ID, revision, snapshot); err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+// GetAll returns the revisions of a recipe, newest first. The snapshots themselves are not loaded.
+func (m *RecipeRevisionModel) GetAll(recipeID int) ([]*RecipeRevision, error) {
+	var revisions []*RecipeRevision
+
+	stmt := `
+		SELECT id, recipe_id, revision, created
+		FROM recipe_revisions
+		WHERE recipe_id = ?
+		ORDER BY revision DESC
+		`
+
+	rows, err := m.DB.Query(stmt, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		revision := &RecipeRevision{}
+
+		err := rows.Scan(
+			&revision.ID,
+			&revision.RecipeID,
+			&revision.Revision,
+			&revision.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (m *RecipeRevisionModel) Get(recipeID, revision int) (*RecipeRevision, error) {
+	var (
+		snapshot       []byte
+		recipeRevision = &RecipeRevision{}
+	)
+
+	stmt := `
+		SELECT id, recipe_id, revision, snapshot, created
+		FROM recipe_revisions
+		WHERE recipe_id = ? AND revision = ?
+		`
+
+	err := m.DB.QueryRow(stmt, recipeID, revision).Scan(
+		&recipeRevision.ID,
+		&recipeRevision.RecipeID,
+		&recipeRevision.Revision,
+		&snapshot,
+		&recipeRevision.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	recipeRevision.Recipe = &Recipe{}
+	if err := json.Unmarshal(snapshot, recipeRevision.Recipe); err != nil {
+		return nil, err
+	}
+
+	return recipeRevision, nil
+}
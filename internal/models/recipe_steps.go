@@ -0,0 +1,207 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
+)
+
+type RecipeStepModelInterface interface {
+	GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*Step, error)
+	Replace(tx transactions.Transaction, recipeID int, steps []*Step) error
+	Backfill() (int, error)
+}
+
+// Step is a single instruction of a recipe. Duration uses the same short form as the
+// recipe times (e.g. "10m") and IngredientIDs reference ingredients of the same recipe.
+type Step struct {
+	ID            int    `json:"id"`
+	Position      int    `json:"position"`
+	Text          string `json:"text"`
+	Duration      string `json:"duration,omitempty"`
+	IngredientIDs []int  `json:"ingredient_ids,omitempty"`
+}
+
+// StepsFromText splits free-form instructions into steps, one per non-empty line.
+func StepsFromText(instructions string) []*Step {
+	var steps []*Step
+	for _, line := range strings.Split(instructions, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			steps = append(steps, &Step{Position: len(steps) + 1, Text: line})
+		}
+	}
+	return steps
+}
+
+type RecipeStepModel struct {
+	DB *sql.DB
+}
+
+// GetByRecipeID returns the steps of a recipe in order, with their linked ingredients.
+func (m *RecipeStepModel) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*Step, error) {
+	var steps []*Step
+
+	stmt := `
+		SELECT id, position, text, duration
+		FROM recipe_steps
+		WHERE recipe_id = ?
+		ORDER BY position
+		`
+
+	rows, err := tx.Query(stmt, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	byID := make(map[int]*Step)
+	for rows.Next() {
+		step := &Step{}
+		if err := rows.Scan(&step.ID, &step.Position, &step.Text, &step.Duration); err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+		byID[step.ID] = step
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(steps) == 0 {
+		return steps, nil
+	}
+
+	stmt = `
+		SELECT rsi.step_id, rsi.ingredient_id
+		FROM recipe_step_ingredients rsi INNER JOIN recipe_steps rs ON rs.id = rsi.step_id
+		WHERE rs.recipe_id = ?
+		ORDER BY rsi.step_id, rsi.ingredient_id
+		`
+
+	links, err := tx.Query(stmt, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(links)
+
+	for links.Next() {
+		var stepID, ingredientID int
+		if err := links.Scan(&stepID, &ingredientID); err != nil {
+			return nil, err
+		}
+		if step, exists := byID[stepID]; exists {
+			step.IngredientIDs = append(step.IngredientIDs, ingredientID)
+		}
+	}
+
+	if err = links.Err(); err != nil {
+		return nil, err
+	}
+
+	return steps, nil
+}
+
+// Replace swaps the steps of a recipe for the given ones, numbering them in order.
+// The IDs of the inserted steps are set on the structs.
+func (m *RecipeStepModel) Replace(tx transactions.Transaction, recipeID int, steps []*Step) error {
+	// Links to ingredients are removed along with the steps through the foreign key
+	if _, err := tx.Exec("DELETE FROM recipe_steps WHERE recipe_id = ?", recipeID); err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		step.Position = i + 1
+
+		stmt := `
+		INSERT INTO recipe_steps
+			(recipe_id, position, text, duration)
+		VALUES
+			(?, ?, ?, ?)
+		`
+		result, err := tx.Exec(stmt, recipeID, step.Position, step.Text, step.Duration)
+		if err != nil {
+			return err
+		}
+
+		stepID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		step.ID = int(stepID)
+
+		for _, ingredientID := range uniqueInts(step.IngredientIDs) {
+			stmt := `INSERT INTO recipe_step_ingredients (step_id, ingredient_id) VALUES (?, ?)`
+			if _, err := tx.Exec(stmt, step.ID, ingredientID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Backfill splits the instructions of the recipes that predate structured steps into steps,
+// and returns how many recipes were migrated. Recipes that already have steps are left alone,
+// so it can be run again if interrupted. It is run once, by the backfill-steps command, rather than
+// on every start of the API.
+func (m *RecipeStepModel) Backfill() (int, error) {
+	stmt := `
+	SELECT id, instructions
+	FROM recipes r
+	WHERE instructions <> '' AND NOT EXISTS (SELECT 1 FROM recipe_steps rs WHERE rs.recipe_id = r.id)
+	`
+
+	rows, err := m.DB.Query(stmt)
+	if err != nil {
+		return 0, err
+	}
+
+	legacy := make(map[int]string)
+	for rows.Next() {
+		var (
+			id           int
+			instructions string
+		)
+		if err := rows.Scan(&id, &instructions); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		legacy[id] = instructions
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, err
+	}
+	_ = rows.Close()
+
+	err = transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		for id, instructions := range legacy {
+			if err := m.Replace(tx, id, StepsFromText(instructions)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(legacy), nil
+}
+
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	unique := make([]int, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
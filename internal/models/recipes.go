@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/vladComan0/tasty-byte/pkg/transactions"
 	"log"
+	"math"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -13,10 +15,20 @@ type RecipeModelInterface interface {
 	Ping() error
 	Insert(recipe *Recipe) (int, error)
 	GetAll() ([]*Recipe, error)
+	List(filter *RecipeFilter) ([]*Recipe, int, error)
 	GetWithTx(tx transactions.Transaction, id int) (*Recipe, error)
 	Get(id int) (*Recipe, error)
+	InsertWithTx(tx transactions.Transaction, recipe *Recipe) (int, error)
 	Update(recipe *Recipe) error
+	UpdateWithTx(tx transactions.Transaction, recipe *Recipe) error
 	Delete(id int) error
+	GetTrashed() ([]*Recipe, error)
+	Restore(id int) error
+	Purge(retention time.Duration) (int, error)
+	Stream(batchSize int, fn func(recipe *Recipe) error) error
+	ImportBatch(recipes []*Recipe, upsert, dryRun bool) ([]*ImportResult, error)
+	Fork(id int, name string) (int, error)
+	InWorkspace(workspaceID int) RecipeModelInterface
 }
 
 type Recipe struct {
@@ -27,9 +39,87 @@ type Recipe struct {
 	PreparationTime string            `json:"preparation_time,omitempty"`
 	CookingTime     string            `json:"cooking_time,omitempty"`
 	Portions        int               `json:"portions,omitempty"`
+	ParentID        int               `json:"parent_id,omitempty"`
+	WorkspaceID     int               `json:"-"`
 	CreatedAt       time.Time         `json:"-"`
+	DeletedAt       *time.Time        `json:"deleted_at,omitempty"`
 	Ingredients     []*FullIngredient `json:"ingredients,omitempty"`
 	Tags            []*Tag            `json:"tags,omitempty"`
+	Steps           []*Step           `json:"steps,omitempty"`
+	Images          []*Image          `json:"images,omitempty"`
+	Allergens       []string          `json:"allergens,omitempty"`
+	Diets           []string          `json:"diets,omitempty"`
+	RatingAverage   float64           `json:"rating_average,omitempty"`
+	RatingCount     int               `json:"rating_count,omitempty"`
+	// Favorited tells whether the user asking for the recipe has favorited it, nil for anonymous requests
+	Favorited *bool `json:"favorited,omitempty"`
+}
+
+// StepTexts returns the text of every step, splitting the instructions into one step
+// per non-empty line when the recipe has no structured steps.
+func (r *Recipe) StepTexts() []string {
+	steps := r.Steps
+	if len(steps) == 0 {
+		steps = StepsFromText(r.Instructions)
+	}
+
+	texts := make([]string, 0, len(steps))
+	for _, step := range steps {
+		texts = append(texts, step.Text)
+	}
+	return texts
+}
+
+// syncSteps keeps the instructions and the structured steps in line with each other:
+// steps are derived from the instructions when none are given, and the instructions
+// are written out from the steps when they are left empty.
+func (r *Recipe) syncSteps() {
+	switch {
+	case len(r.Steps) == 0:
+		r.Steps = StepsFromText(r.Instructions)
+	case r.Instructions == "":
+		r.Instructions = strings.Join(r.StepTexts(), "\n")
+	}
+}
+
+// validateSteps checks that the steps only reference ingredients of the recipe.
+func (r *Recipe) validateSteps() error {
+	ingredientIDs := make(map[int]bool, len(r.Ingredients))
+	for _, ingredient := range r.Ingredients {
+		ingredientIDs[ingredient.ID] = true
+	}
+
+	for _, step := range r.Steps {
+		for _, id := range step.IngredientIDs {
+			if !ingredientIDs[id] {
+				return ErrInvalidStepIngredient
+			}
+		}
+	}
+	return nil
+}
+
+// Scale adjusts the ingredient quantities of the recipe to the given number of portions.
+// Quantities are rounded to two decimals, the precision they are stored with.
+func (r *Recipe) Scale(portions int) error {
+	if r.Portions < 1 {
+		return ErrNoPortions
+	}
+
+	factor := float64(portions) / float64(r.Portions)
+	for _, ingredient := range r.Ingredients {
+		ingredient.Quantity = math.Round(ingredient.Quantity*factor*100) / 100
+	}
+	r.Portions = portions
+
+	return nil
+}
+
+// RecipeIndex is kept up to date with the recipes as they are inserted, updated and deleted,
+// so that it can answer questions about them without going to the database.
+type RecipeIndex interface {
+	Put(recipe *Recipe)
+	Remove(id int)
 }
 
 type RecipeModel struct {
@@ -38,6 +128,23 @@ type RecipeModel struct {
 	RecipeIngredientModel RecipeIngredientModelInterface
 	TagModel              TagModelInterface
 	RecipeTagModel        RecipeTagModelInterface
+	RecipeRevisionModel   RecipeRevisionModelInterface
+	RecipeStepModel       RecipeStepModelInterface
+	RecipeImageModel      RecipeImageModelInterface
+	// Index, when set, is refreshed once the changes to a recipe are committed
+	Index RecipeIndex
+	// WorkspaceID is the only workspace whose recipes the model sees, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model, and of the ingredient and tag models it uses, that only
+// sees the recipes of the workspace.
+func (m *RecipeModel) InWorkspace(workspaceID int) RecipeModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	scoped.IngredientModel = m.IngredientModel.InWorkspace(workspaceID)
+	scoped.TagModel = m.TagModel.InWorkspace(workspaceID)
+	return &scoped
 }
 
 func (m *RecipeModel) Ping() error {
@@ -47,52 +154,154 @@ func (m *RecipeModel) Ping() error {
 func (m *RecipeModel) Insert(recipe *Recipe) (int, error) {
 	var recipeID int
 	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
-		stmt := `
-		INSERT INTO recipes 
-			(name, description, instructions, preparation_time, cooking_time, portions, created)
-		VALUES 
-			(?, ?, ?, ?, ?, ?, UTC_TIMESTAMP())
-		`
-		result, err := tx.Exec(stmt, recipe.Name, recipe.Description, recipe.Instructions, recipe.PreparationTime, recipe.CookingTime, recipe.Portions)
+		var err error
+		recipeID, err = m.InsertWithTx(tx, recipe)
+		return err
+	})
+	if err == nil {
+		indexed := *recipe
+		indexed.ID = recipeID
+		m.putIndex(&indexed)
+	}
+
+	return recipeID, err
+}
+
+// putIndex adds or replaces the recipe in the index, if there is one.
+func (m *RecipeModel) putIndex(recipe *Recipe) {
+	if m.Index != nil {
+		m.Index.Put(recipe)
+	}
+}
+
+// removeIndex takes the recipe out of the index, if there is one.
+func (m *RecipeModel) removeIndex(id int) {
+	if m.Index != nil {
+		m.Index.Remove(id)
+	}
+}
+
+func (m *RecipeModel) InsertWithTx(tx transactions.Transaction, recipe *Recipe) (int, error) {
+	recipe.syncSteps()
+	recipe.WorkspaceID = workspaceOrDefault(m.WorkspaceID)
+
+	stmt := `
+	INSERT INTO recipes 
+		(name, description, instructions, preparation_time, cooking_time, portions, parent_id, workspace_id, created)
+	VALUES 
+		(?, ?, ?, ?, ?, ?, ?, ?, UTC_TIMESTAMP())
+	`
+	var parentID sql.NullInt64
+	if recipe.ParentID > 0 {
+		parentID = sql.NullInt64{Int64: int64(recipe.ParentID), Valid: true}
+	}
+	result, err := tx.Exec(stmt, recipe.Name, recipe.Description, recipe.Instructions, recipe.PreparationTime, recipe.CookingTime, recipe.Portions, parentID, recipe.WorkspaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	recipeID64, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	recipeID := int(recipeID64)
+
+	// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
+	for _, ingredient := range recipe.Ingredients {
+		ingredientID, err := m.IngredientModel.InsertIfNotExists(tx, ingredient.Name)
 		if err != nil {
-			return err
+			return 0, err
 		}
+		ingredient.ID = ingredientID
 
-		recipeID64, err := result.LastInsertId()
-		if err != nil {
-			return err
+		if err := m.RecipeIngredientModel.Associate(tx, recipeID, ingredientID, ingredient.Quantity, ingredient.Unit); err != nil {
+			return 0, err
 		}
-		recipeID = int(recipeID64)
+	}
 
-		// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
-		for _, ingredient := range recipe.Ingredients {
-			ingredientID, err := m.IngredientModel.InsertIfNotExists(tx, ingredient.Name)
-			if err != nil {
-				return err
-			}
-			if err := m.RecipeIngredientModel.Associate(tx, recipeID, ingredientID, ingredient.Quantity, ingredient.Unit); err != nil {
-				return err
-			}
+	// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
+	for _, tag := range recipe.Tags {
+		tagID, err := m.TagModel.InsertIfNotExists(tx, tag.Name)
+		if err != nil {
+			return 0, err
 		}
+		if err := m.RecipeTagModel.Associate(tx, recipeID, tagID); err != nil {
+			return 0, err
+		}
+	}
 
-		// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
-		for _, tag := range recipe.Tags {
-			tagID, err := m.TagModel.InsertIfNotExists(tx, tag.Name)
-			if err != nil {
-				return err
-			}
-			if err := m.RecipeTagModel.Associate(tx, recipeID, tagID); err != nil {
-				return err
-			}
+	if err := recipe.validateSteps(); err != nil {
+		return 0, err
+	}
+
+	if err := m.RecipeStepModel.Replace(tx, recipeID, recipe.Steps); err != nil {
+		return 0, err
+	}
+
+	return recipeID, nil
+}
+
+func (m *RecipeModel) GetAll() ([]*Recipe, error) {
+	return m.getWhere("recipes.workspace_id = ? AND recipes.deleted_at IS NULL", workspaceOrDefault(m.WorkspaceID))
+}
+
+// List returns a page of the recipes matching the filter, in its order, along with the number of
+// recipes matching it in all.
+func (m *RecipeModel) List(filter *RecipeFilter) ([]*Recipe, int, error) {
+	condition, args := filter.condition(m.WorkspaceID)
+
+	var total int
+	if err := m.DB.QueryRow("SELECT COUNT(*) FROM recipes WHERE "+condition, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	stmt := "SELECT recipes.id FROM recipes WHERE " + condition + " ORDER BY " + filter.orderBy()
+	if filter.Limit > 0 {
+		stmt += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := m.DB.Query(stmt, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var ids []any
+	position := make(map[int]int)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, err
 		}
+		position[id] = len(ids)
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return []*Recipe{}, total, nil
+	}
 
-		return nil
+	recipes, err := m.getWhere("recipes.id IN (?"+strings.Repeat(", ?", len(ids)-1)+")", ids...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The recipes are loaded by ID, and put back in the order of the page
+	sort.Slice(recipes, func(i, j int) bool {
+		return position[recipes[i].ID] < position[recipes[j].ID]
 	})
 
-	return recipeID, err
+	return recipes, total, nil
 }
 
-func (m *RecipeModel) GetAll() ([]*Recipe, error) {
+// getWhere returns the recipes matching the SQL condition, on the "recipes" table, with their
+// ingredients, tags and images, by ID.
+func (m *RecipeModel) getWhere(condition string, args ...any) ([]*Recipe, error) {
 	var results []*Recipe
 	recipes := make(map[int]*Recipe)
 
@@ -105,13 +314,22 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 		recipes.preparation_time,
 		recipes.cooking_time,
 		recipes.portions,
+		COALESCE(recipes.parent_id, 0),
+		recipes.workspace_id,
 		recipes.created,
+		recipes.rating_average,
+		recipes.rating_count,
 		ingredients.id,
 		ingredients.name,
+		ingredients.allergens,
+		ingredients.diets,
+		ingredients.classified,
 		recipe_ingredients.quantity,
 		recipe_ingredients.unit,
 		tags.id,
-		tags.name
+		tags.name,
+		COALESCE(tags.parent_id, 0),
+		tags.category
 	FROM
 		recipes
 	LEFT JOIN
@@ -121,9 +339,11 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 	LEFT JOIN 
 		recipe_tags ON recipes.id = recipe_tags.recipe_id
 	LEFT JOIN
-		tags ON recipe_tags.tag_id = tags.id`
+		tags ON recipe_tags.tag_id = tags.id
+	WHERE
+		` + condition
 
-	rows, err := m.DB.Query(stmt)
+	rows, err := m.DB.Query(stmt, args...)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -140,10 +360,15 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 		var (
 			ingredientID       sql.NullInt64
 			ingredientName     sql.NullString
+			allergens          sql.NullString
+			diets              sql.NullString
+			classified         sql.NullBool
 			ingredientQuantity sql.NullFloat64
 			ingredientUnit     sql.NullString
 			tagID              sql.NullInt64
 			tagName            sql.NullString
+			tagParentID        int
+			tagCategory        sql.NullString
 			recipe             = &Recipe{}
 		)
 
@@ -155,13 +380,22 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 			&recipe.PreparationTime,
 			&recipe.CookingTime,
 			&recipe.Portions,
+			&recipe.ParentID,
+			&recipe.WorkspaceID,
 			&recipe.CreatedAt,
+			&recipe.RatingAverage,
+			&recipe.RatingCount,
 			&ingredientID,
 			&ingredientName,
+			&allergens,
+			&diets,
+			&classified,
 			&ingredientQuantity,
 			&ingredientUnit,
 			&tagID,
 			&tagName,
+			&tagParentID,
+			&tagCategory,
 		)
 		if err != nil {
 			return nil, err
@@ -176,8 +410,11 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 		if ingredientID.Valid && ingredientName.Valid && ingredientQuantity.Valid && ingredientUnit.Valid {
 			ingredient := &FullIngredient{
 				Ingredient: &Ingredient{
-					ID:   int(ingredientID.Int64),
-					Name: ingredientName.String,
+					ID:         int(ingredientID.Int64),
+					Name:       ingredientName.String,
+					Allergens:  splitSet(allergens.String),
+					Diets:      splitSet(diets.String),
+					Classified: classified.Bool,
 				},
 				Quantity: ingredientQuantity.Float64,
 				Unit:     ingredientUnit.String,
@@ -187,8 +424,10 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 
 		if tagID.Valid && tagName.Valid {
 			tag := &Tag{
-				ID:   int(tagID.Int64),
-				Name: tagName.String,
+				ID:       int(tagID.Int64),
+				Name:     tagName.String,
+				ParentID: tagParentID,
+				Category: tagCategory.String,
 			}
 			recipes[recipe.ID].Tags = append(recipes[recipe.ID].Tags, tag)
 		}
@@ -199,14 +438,25 @@ func (m *RecipeModel) GetAll() ([]*Recipe, error) {
 		return nil, err
 	}
 
+	ids := make([]int, 0, len(recipes))
 	for _, recipe := range recipes {
 		results = append(results, recipe)
+		ids = append(ids, recipe.ID)
 	}
 
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].ID < results[j].ID
 	})
 
+	imagesByRecipe, err := m.RecipeImageModel.GetByRecipeIDs(m.DB, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, recipe := range results {
+		recipe.Images = imagesByRecipe[recipe.ID]
+		recipe.classify()
+	}
+
 	return results, nil
 }
 
@@ -222,14 +472,18 @@ func (m *RecipeModel) GetWithTx(tx transactions.Transaction, id int) (*Recipe, e
         preparation_time, 
         cooking_time, 
         portions, 
-        created
+        COALESCE(parent_id, 0),
+        workspace_id,
+        created,
+        rating_average,
+        rating_count
     FROM 
         recipes 
     WHERE 
-        id = ?
+        id = ? AND workspace_id = ? AND deleted_at IS NULL
 `
 
-	err := tx.QueryRow(stmt, id).Scan(
+	err := tx.QueryRow(stmt, id, workspaceOrDefault(m.WorkspaceID)).Scan(
 		&recipe.ID,
 		&recipe.Name,
 		&recipe.Description,
@@ -237,7 +491,11 @@ func (m *RecipeModel) GetWithTx(tx transactions.Transaction, id int) (*Recipe, e
 		&recipe.PreparationTime,
 		&recipe.CookingTime,
 		&recipe.Portions,
+		&recipe.ParentID,
+		&recipe.WorkspaceID,
 		&recipe.CreatedAt,
+		&recipe.RatingAverage,
+		&recipe.RatingCount,
 	)
 	if err != nil {
 		switch {
@@ -253,6 +511,7 @@ func (m *RecipeModel) GetWithTx(tx transactions.Transaction, id int) (*Recipe, e
 		return nil, err
 	}
 	recipe.Ingredients = ingredients
+	recipe.classify()
 
 	tags, err := m.TagModel.GetByRecipeID(tx, recipe.ID)
 	if err != nil {
@@ -261,6 +520,18 @@ func (m *RecipeModel) GetWithTx(tx transactions.Transaction, id int) (*Recipe, e
 
 	recipe.Tags = tags
 
+	steps, err := m.RecipeStepModel.GetByRecipeID(tx, recipe.ID)
+	if err != nil {
+		return nil, err
+	}
+	recipe.Steps = steps
+
+	imagesByRecipe, err := m.RecipeImageModel.GetByRecipeIDs(tx, []int{recipe.ID})
+	if err != nil {
+		return nil, err
+	}
+	recipe.Images = imagesByRecipe[recipe.ID]
+
 	return recipe, nil
 }
 
@@ -278,111 +549,310 @@ func (m *RecipeModel) Get(id int) (*Recipe, error) {
 	return m.GetWithTx(tx, id)
 }
 
-func (m *RecipeModel) Update(recipe *Recipe) error {
-	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
-		existingRecipe, err := m.GetWithTx(tx, recipe.ID)
+// Fork copies a recipe, with its ingredients, tags and steps, into a new variant of it with the given name,
+// or the name of the original when empty. Images and reviews stay with the original.
+func (m *RecipeModel) Fork(id int, name string) (int, error) {
+	var fork *Recipe
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		recipe, err := m.GetWithTx(tx, id)
 		if err != nil {
 			return err
 		}
 
-		if existingRecipe == nil {
-			return ErrNoRecord
+		recipe.ParentID = recipe.ID
+		if name != "" {
+			recipe.Name = name
 		}
+		recipe.ID, err = m.InsertWithTx(tx, recipe)
+		fork = recipe
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	m.putIndex(fork)
 
-		stmt := `
-		UPDATE recipes
-		SET 
-			name = ?, 
-			description = ?, 
-			instructions = ?, 
-			preparation_time = ?, 
-			cooking_time = ?, 
-			portions = ?
-		WHERE 
-			id = ?
-		`
-		_, err = tx.Exec(
-			stmt,
-			recipe.Name,
-			recipe.Description,
-			recipe.Instructions,
-			recipe.PreparationTime,
-			recipe.CookingTime,
-			recipe.Portions,
-			recipe.ID,
-		)
-		if err != nil {
-			return err
-		}
+	return fork.ID, nil
+}
 
-		// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
-		for _, ingredient := range recipe.Ingredients {
-			ingredientID, err := m.IngredientModel.InsertIfNotExists(tx, ingredient.Name)
-			if err != nil {
-				return err
-			}
-			ingredient.ID = ingredientID
+func (m *RecipeModel) Update(recipe *Recipe) error {
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		return m.UpdateWithTx(tx, recipe)
+	})
+	if err == nil {
+		m.putIndex(recipe)
+	}
+	return err
+}
 
-			if err := m.RecipeIngredientModel.Associate(tx, recipe.ID, ingredient.ID, ingredient.Quantity, ingredient.Unit); err != nil {
-				return err
-			}
-		}
+func (m *RecipeModel) UpdateWithTx(tx transactions.Transaction, recipe *Recipe) error {
+	existingRecipe, err := m.GetWithTx(tx, recipe.ID)
+	if err != nil {
+		return err
+	}
 
-		// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
-		for _, tag := range recipe.Tags {
-			tagID, err := m.TagModel.InsertIfNotExists(tx, tag.Name)
-			if err != nil {
-				return err
-			}
-			tag.ID = tagID
+	if existingRecipe == nil {
+		return ErrNoRecord
+	}
 
-			if err := m.RecipeTagModel.Associate(tx, recipe.ID, tag.ID); err != nil {
-				return err
-			}
+	// Keep a snapshot of the recipe as it was before this update so that it can be restored later on
+	if _, err := m.RecipeRevisionModel.Insert(tx, existingRecipe); err != nil {
+		return err
+	}
+
+	recipe.syncSteps()
+	recipe.WorkspaceID = existingRecipe.WorkspaceID
+
+	stmt := `
+	UPDATE recipes
+	SET 
+		name = ?, 
+		description = ?, 
+		instructions = ?, 
+		preparation_time = ?, 
+		cooking_time = ?, 
+		portions = ?
+	WHERE 
+		id = ? AND workspace_id = ?
+	`
+	_, err = tx.Exec(
+		stmt,
+		recipe.Name,
+		recipe.Description,
+		recipe.Instructions,
+		recipe.PreparationTime,
+		recipe.CookingTime,
+		recipe.Portions,
+		recipe.ID,
+		recipe.WorkspaceID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
+	for _, ingredient := range recipe.Ingredients {
+		ingredientID, err := m.IngredientModel.InsertIfNotExists(tx, ingredient.Name)
+		if err != nil {
+			return err
 		}
+		ingredient.ID = ingredientID
 
-		// Delete any associations in the recipe_ingredients table that are not in the updated Recipe struct
-		if err := m.RecipeIngredientModel.DissociateNotInList(tx, recipe.ID, recipe.Ingredients); err != nil {
+		if err := m.RecipeIngredientModel.Associate(tx, recipe.ID, ingredient.ID, ingredient.Quantity, ingredient.Unit); err != nil {
 			return err
 		}
+	}
 
-		// Delete any associations in the recipe_tags table that are not in the updated Recipe struct
-		if err := m.RecipeTagModel.DissociateNotInList(tx, recipe.ID, recipe.Tags); err != nil {
+	// Must be updated to use batch inserts or reduce the number of SQL inserts through another method
+	for _, tag := range recipe.Tags {
+		tagID, err := m.TagModel.InsertIfNotExists(tx, tag.Name)
+		if err != nil {
 			return err
 		}
+		tag.ID = tagID
 
-		return nil
-	})
+		if err := m.RecipeTagModel.Associate(tx, recipe.ID, tag.ID); err != nil {
+			return err
+		}
+	}
+
+	// Delete any associations in the recipe_ingredients table that are not in the updated Recipe struct
+	if err := m.RecipeIngredientModel.DissociateNotInList(tx, recipe.ID, recipe.Ingredients); err != nil {
+		return err
+	}
+
+	// Delete any associations in the recipe_tags table that are not in the updated Recipe struct
+	if err := m.RecipeTagModel.DissociateNotInList(tx, recipe.ID, recipe.Tags); err != nil {
+		return err
+	}
+
+	if err := recipe.validateSteps(); err != nil {
+		return err
+	}
+
+	return m.RecipeStepModel.Replace(tx, recipe.ID, recipe.Steps)
 }
 
+// Delete moves a recipe to the trash. Trashed recipes are hidden from Get and GetAll
+// until they are either restored or purged.
 func (m *RecipeModel) Delete(id int) error {
-	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
-		stmt := `
-		DELETE FROM recipes
-		WHERE id = ?
-		`
-		results, err := tx.Exec(stmt, id)
+	stmt := `
+	UPDATE recipes
+	SET deleted_at = UTC_TIMESTAMP()
+	WHERE id = ? AND workspace_id = ? AND deleted_at IS NULL
+	`
+	results, err := m.DB.Exec(stmt, id, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNoRecord
+	}
+
+	m.removeIndex(id)
+
+	return nil
+}
+
+func (m *RecipeModel) GetTrashed() ([]*Recipe, error) {
+	var recipes []*Recipe
+
+	stmt := `
+	SELECT
+		id,
+		name,
+		description,
+		instructions,
+		preparation_time,
+		cooking_time,
+		portions,
+		created,
+		deleted_at
+	FROM
+		recipes
+	WHERE
+		workspace_id = ? AND deleted_at IS NOT NULL
+	ORDER BY
+		deleted_at DESC`
+
+	rows, err := m.DB.Query(stmt, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		recipe := &Recipe{}
+
+		err := rows.Scan(
+			&recipe.ID,
+			&recipe.Name,
+			&recipe.Description,
+			&recipe.Instructions,
+			&recipe.PreparationTime,
+			&recipe.CookingTime,
+			&recipe.Portions,
+			&recipe.CreatedAt,
+			&recipe.DeletedAt,
+		)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		recipes = append(recipes, recipe)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+// Restore takes a recipe out of the trash.
+func (m *RecipeModel) Restore(id int) error {
+	stmt := `
+	UPDATE recipes
+	SET deleted_at = NULL
+	WHERE id = ? AND workspace_id = ? AND deleted_at IS NOT NULL
+	`
+	results, err := m.DB.Exec(stmt, id, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNoRecord
+	}
+
+	if m.Index != nil {
+		recipe, err := m.Get(id)
+		if err != nil {
+			log.Printf("could not index restored recipe %d: %v", id, err)
+			return nil
 		}
+		m.Index.Put(recipe)
+	}
 
-		rowsAffected, err := results.RowsAffected()
+	return nil
+}
+
+// Purge permanently deletes the recipes that have been in the trash for longer than the
+// retention period, together with their associations and images, and returns how many were removed.
+// Being a maintenance task, it goes through the trash of every workspace.
+func (m *RecipeModel) Purge(retention time.Duration) (int, error) {
+	var (
+		purged    int
+		imageKeys []string
+	)
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		stmt := `
+		SELECT id
+		FROM recipes
+		WHERE deleted_at IS NOT NULL AND deleted_at < UTC_TIMESTAMP() - INTERVAL ? SECOND
+		FOR UPDATE
+		`
+		rows, err := tx.Query(stmt, int64(retention.Seconds()))
 		if err != nil {
 			return err
 		}
 
-		if rowsAffected == 0 {
-			return ErrNoRecord
+		var ids []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			ids = append(ids, id)
 		}
-
-		if err := m.RecipeIngredientModel.deleteRecordsByRecipe(tx, id); err != nil {
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
 			return err
 		}
+		_ = rows.Close()
 
-		if err := m.RecipeTagModel.deleteRecordsByRecipe(tx, id); err != nil {
-			return err
+		for _, id := range ids {
+			if err := m.RecipeIngredientModel.deleteRecordsByRecipe(tx, id); err != nil {
+				return err
+			}
+
+			if err := m.RecipeTagModel.deleteRecordsByRecipe(tx, id); err != nil {
+				return err
+			}
+
+			keys, err := m.RecipeImageModel.DeleteByRecipeID(tx, id)
+			if err != nil {
+				return err
+			}
+			imageKeys = append(imageKeys, keys...)
+
+			if _, err := tx.Exec("DELETE FROM recipes WHERE id = ?", id); err != nil {
+				return err
+			}
 		}
 
+		purged = len(ids)
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Files can only go once the records pointing to them are gone for good
+	m.RecipeImageModel.RemoveBlobs(imageKeys)
+
+	return purged, nil
 }
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
+	"time"
+)
+
+type ReviewModelInterface interface {
+	Insert(review *Review) (int, error)
+	Get(id int) (*Review, error)
+	GetByRecipeID(recipeID int) ([]*Review, error)
+	GetByUserID(userID int) ([]*Review, error)
+	Update(review *Review) error
+	Delete(id int) error
+}
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Review is the rating, from MinRating to MaxRating stars, and the comment a user left on a recipe.
+// Every user reviews a recipe at most once, and only the author can change or delete a review.
+// The average rating and number of reviews are kept on the recipe, updated along with its reviews.
+type Review struct {
+	ID        int       `json:"id"`
+	RecipeID  int       `json:"recipe_id"`
+	UserID    int       `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ReviewModel struct {
+	DB *sql.DB
+}
+
+// Insert adds the review and updates the rating of the recipe in the same transaction.
+func (m *ReviewModel) Insert(review *Review) (int, error) {
+	var id int
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		if err := lockRecipe(tx, review.RecipeID); err != nil {
+			return err
+		}
+
+		stmt := `
+		INSERT INTO reviews (recipe_id, user_id, rating, comment, created, updated)
+		VALUES (?, ?, ?, ?, UTC_TIMESTAMP(), UTC_TIMESTAMP())
+		`
+
+		result, err := tx.Exec(stmt, review.RecipeID, review.UserID, review.Rating, review.Comment)
+		if err != nil {
+			var mySQLError *mysql.MySQLError
+			if errors.As(err, &mySQLError) {
+				if mySQLError.Number == 1062 && strings.Contains(mySQLError.Message, "review_recipe_user") {
+					return ErrDuplicateReview
+				}
+			}
+			return err
+		}
+
+		id64, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		id = int(id64)
+
+		return refreshRating(tx, review.RecipeID)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (m *ReviewModel) Get(id int) (*Review, error) {
+	review := &Review{}
+
+	stmt := `
+		SELECT r.id, r.recipe_id, r.user_id, u.name, r.rating, r.comment, r.created, r.updated
+		FROM reviews r INNER JOIN users u ON u.id = r.user_id
+		WHERE r.id = ?
+		`
+
+	err := m.DB.QueryRow(stmt, id).Scan(
+		&review.ID,
+		&review.RecipeID,
+		&review.UserID,
+		&review.UserName,
+		&review.Rating,
+		&review.Comment,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	return review, nil
+}
+
+// GetByRecipeID returns the reviews of a recipe, the most recently updated first.
+func (m *ReviewModel) GetByRecipeID(recipeID int) ([]*Review, error) {
+	stmt := `
+		SELECT r.id, r.recipe_id, r.user_id, u.name, r.rating, r.comment, r.created, r.updated
+		FROM reviews r INNER JOIN users u ON u.id = r.user_id
+		WHERE r.recipe_id = ?
+		ORDER BY r.updated DESC, r.id DESC
+		`
+	return m.query(stmt, recipeID)
+}
+
+// GetByUserID returns the reviews a user left, the most recently updated first, leaving out trashed recipes.
+func (m *ReviewModel) GetByUserID(userID int) ([]*Review, error) {
+	stmt := `
+		SELECT r.id, r.recipe_id, r.user_id, u.name, r.rating, r.comment, r.created, r.updated
+		FROM reviews r
+		INNER JOIN users u ON u.id = r.user_id
+		INNER JOIN recipes ON recipes.id = r.recipe_id
+		WHERE r.user_id = ? AND recipes.deleted_at IS NULL
+		ORDER BY r.updated DESC, r.id DESC
+		`
+	return m.query(stmt, userID)
+}
+
+func (m *ReviewModel) query(stmt string, args ...any) ([]*Review, error) {
+	reviews := []*Review{}
+
+	rows, err := m.DB.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		review := &Review{}
+		err := rows.Scan(
+			&review.ID,
+			&review.RecipeID,
+			&review.UserID,
+			&review.UserName,
+			&review.Rating,
+			&review.Comment,
+			&review.CreatedAt,
+			&review.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// Update replaces the rating and comment of the review and updates the rating of the recipe
+// in the same transaction.
+func (m *ReviewModel) Update(review *Review) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		if err := lockRecipe(tx, review.RecipeID); err != nil {
+			return err
+		}
+
+		stmt := `
+		UPDATE reviews
+		SET rating = ?, comment = ?, updated = UTC_TIMESTAMP()
+		WHERE id = ? AND recipe_id = ?
+		`
+
+		result, err := tx.Exec(stmt, review.Rating, review.Comment, review.ID, review.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNoRecord
+		}
+
+		return refreshRating(tx, review.RecipeID)
+	})
+}
+
+// Delete removes the review and updates the rating of the recipe in the same transaction.
+func (m *ReviewModel) Delete(id int) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		var recipeID int
+		if err := tx.QueryRow("SELECT recipe_id FROM reviews WHERE id = ?", id).Scan(&recipeID); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNoRecord
+			default:
+				return err
+			}
+		}
+
+		if err := lockRecipe(tx, recipeID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec("DELETE FROM reviews WHERE id = ?", id); err != nil {
+			return err
+		}
+
+		return refreshRating(tx, recipeID)
+	})
+}
+
+// lockRecipe locks the row of a recipe until the end of the transaction, so that concurrent reviews of
+// the recipe take turns in keeping its rating up to date.
+func lockRecipe(tx transactions.Transaction, recipeID int) error {
+	var id int
+	err := tx.QueryRow("SELECT id FROM recipes WHERE id = ? AND deleted_at IS NULL FOR UPDATE", recipeID).Scan(&id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNoRecord
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshRating recomputes the average rating and the number of reviews stored on the recipe.
+func refreshRating(tx transactions.Transaction, recipeID int) error {
+	stmt := `
+		UPDATE recipes
+		SET
+			rating_average = (SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE recipe_id = ?),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE recipe_id = ?)
+		WHERE id = ?
+		`
+
+	_, err := tx.Exec(stmt, recipeID, recipeID, recipeID)
+	return err
+}
@@ -0,0 +1,324 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vladComan0/tasty-byte/internal/units"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
+	"time"
+)
+
+type ShoppingListModelInterface interface {
+	Insert(list *ShoppingList) (int, error)
+	Get(id int) (*ShoppingList, error)
+	GetAll() ([]*ShoppingList, error)
+	SetChecked(listID, itemID int, checked bool) error
+	Delete(id int) error
+	InWorkspace(workspaceID int) ShoppingListModelInterface
+}
+
+// ShoppingList is the aggregated ingredients of several recipes, sorted by aisle.
+type ShoppingList struct {
+	ID        int                   `json:"id"`
+	Name      string                `json:"name"`
+	CreatedAt time.Time             `json:"created_at"`
+	Recipes   []*ShoppingListRecipe `json:"recipes"`
+	Items     []*ShoppingItem       `json:"items,omitempty"`
+}
+
+// ShoppingListRecipe is a recipe the list was generated from, with the portions it was scaled to.
+// The name is kept so that the list still makes sense once the recipe is gone.
+type ShoppingListRecipe struct {
+	RecipeID int    `json:"recipe_id"`
+	Name     string `json:"name"`
+	Portions int    `json:"portions,omitempty"`
+}
+
+type ShoppingItem struct {
+	ID           int     `json:"id"`
+	IngredientID int     `json:"ingredient_id,omitempty"`
+	Name         string  `json:"name"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Aisle        string  `json:"aisle"`
+	Checked      bool    `json:"checked"`
+}
+
+// String formats the item as a list line, e.g. "1 1/2 cup flour".
+func (i *ShoppingItem) String() string {
+	parts := make([]string, 0, 3)
+	if i.Quantity > 0 {
+		parts = append(parts, units.FormatQuantity(i.Quantity))
+	}
+	if i.Unit != "" {
+		parts = append(parts, i.Unit)
+	}
+	parts = append(parts, i.Name)
+	return strings.Join(parts, " ")
+}
+
+// ShoppingAisle groups the items of a list found in the same aisle.
+type ShoppingAisle struct {
+	Name  string
+	Items []*ShoppingItem
+}
+
+// ByAisle groups the items of the list by aisle, keeping their order.
+func (l *ShoppingList) ByAisle() []*ShoppingAisle {
+	var aisles []*ShoppingAisle
+	for _, item := range l.Items {
+		if len(aisles) == 0 || aisles[len(aisles)-1].Name != item.Aisle {
+			aisles = append(aisles, &ShoppingAisle{Name: item.Aisle})
+		}
+		last := aisles[len(aisles)-1]
+		last.Items = append(last.Items, item)
+	}
+	return aisles
+}
+
+type ShoppingListModel struct {
+	DB *sql.DB
+	// WorkspaceID is the only workspace whose shopping lists the model sees, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only sees the shopping lists of the workspace.
+func (m *ShoppingListModel) InWorkspace(workspaceID int) ShoppingListModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	return &scoped
+}
+
+func (m *ShoppingListModel) Insert(list *ShoppingList) (int, error) {
+	var id int
+
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		result, err := tx.Exec("INSERT INTO shopping_lists (name, workspace_id, created) VALUES (?, ?, UTC_TIMESTAMP())", list.Name, workspaceOrDefault(m.WorkspaceID))
+		if err != nil {
+			return err
+		}
+		id64, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		id = int(id64)
+
+		for position, recipe := range list.Recipes {
+			_, err := tx.Exec(
+				"INSERT INTO shopping_list_recipes (list_id, position, recipe_id, name, portions) VALUES (?, ?, ?, ?, ?)",
+				id, position, recipe.RecipeID, recipe.Name, recipe.Portions,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		stmt := `
+		INSERT INTO shopping_list_items
+			(list_id, position, ingredient_id, name, quantity, unit, aisle, checked)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?)
+		`
+		for position, item := range list.Items {
+			var ingredientID sql.NullInt64
+			if item.IngredientID > 0 {
+				ingredientID = sql.NullInt64{Int64: int64(item.IngredientID), Valid: true}
+			}
+
+			result, err := tx.Exec(stmt, id, position, ingredientID, item.Name, item.Quantity, item.Unit, item.Aisle, item.Checked)
+			if err != nil {
+				return err
+			}
+			itemID, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+			item.ID = int(itemID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	list.ID = id
+	return id, nil
+}
+
+func (m *ShoppingListModel) Get(id int) (*ShoppingList, error) {
+	list := &ShoppingList{}
+
+	err := m.DB.QueryRow("SELECT id, name, created FROM shopping_lists WHERE id = ? AND workspace_id = ?", id, workspaceOrDefault(m.WorkspaceID)).Scan(
+		&list.ID,
+		&list.Name,
+		&list.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	if list.Recipes, err = m.getRecipes(id); err != nil {
+		return nil, err
+	}
+
+	if list.Items, err = m.getItems(id); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetAll returns every shopping list of the workspace, newest first, without their items.
+func (m *ShoppingListModel) GetAll() ([]*ShoppingList, error) {
+	lists := []*ShoppingList{}
+
+	stmt := "SELECT id, name, created FROM shopping_lists WHERE workspace_id = ? ORDER BY created DESC, id DESC"
+
+	rows, err := m.DB.Query(stmt, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		list := &ShoppingList{}
+		if err := rows.Scan(&list.ID, &list.Name, &list.CreatedAt); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, list := range lists {
+		if list.Recipes, err = m.getRecipes(list.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return lists, nil
+}
+
+func (m *ShoppingListModel) getRecipes(listID int) ([]*ShoppingListRecipe, error) {
+	recipes := []*ShoppingListRecipe{}
+
+	rows, err := m.DB.Query("SELECT recipe_id, name, portions FROM shopping_list_recipes WHERE list_id = ? ORDER BY position", listID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		recipe := &ShoppingListRecipe{}
+		if err := rows.Scan(&recipe.RecipeID, &recipe.Name, &recipe.Portions); err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, recipe)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+func (m *ShoppingListModel) getItems(listID int) ([]*ShoppingItem, error) {
+	var items []*ShoppingItem
+
+	stmt := `
+		SELECT id, ingredient_id, name, quantity, unit, aisle, checked
+		FROM shopping_list_items
+		WHERE list_id = ?
+		ORDER BY position
+		`
+
+	rows, err := m.DB.Query(stmt, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			item         = &ShoppingItem{}
+			ingredientID sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&item.ID,
+			&ingredientID,
+			&item.Name,
+			&item.Quantity,
+			&item.Unit,
+			&item.Aisle,
+			&item.Checked,
+		)
+		if err != nil {
+			return nil, err
+		}
+		item.IngredientID = int(ingredientID.Int64)
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// SetChecked checks off an item of the list, or unchecks it.
+func (m *ShoppingListModel) SetChecked(listID, itemID int, checked bool) error {
+	stmt := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM shopping_list_items i INNER JOIN shopping_lists l ON l.id = i.list_id
+			WHERE i.id = ? AND i.list_id = ? AND l.workspace_id = ?
+		)
+		`
+
+	var exists bool
+	err := m.DB.QueryRow(stmt, itemID, listID, workspaceOrDefault(m.WorkspaceID)).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNoRecord
+	}
+
+	_, err = m.DB.Exec("UPDATE shopping_list_items SET checked = ? WHERE id = ? AND list_id = ?", checked, itemID, listID)
+	return err
+}
+
+func (m *ShoppingListModel) Delete(id int) error {
+	result, err := m.DB.Exec("DELETE FROM shopping_lists WHERE id = ? AND workspace_id = ?", id, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/vladComan0/tasty-byte/internal/units"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"math"
+	"slices"
+)
+
+type SubstitutionModelInterface interface {
+	GetByIngredientID(ingredientID int) ([]*Substitution, error)
+	Seed(rules []*SubstitutionRule) error
+	InWorkspace(workspaceID int) SubstitutionModelInterface
+}
+
+// Substitution is an edge of the substitution graph: Substitute can replace the ingredient,
+// Ratio units of it being used for every unit of the ingredient, e.g. 0.8 for butter → oil.
+// Edges are directed, oil doesn't necessarily replace butter the other way around.
+type Substitution struct {
+	IngredientID int         `json:"ingredient_id"`
+	Substitute   *Ingredient `json:"substitute"`
+	Ratio        float64     `json:"ratio"`
+	Note         string      `json:"note,omitempty"`
+}
+
+// SubstitutionRule is a substitution between two ingredients known by name, as bundled with the application.
+type SubstitutionRule struct {
+	Ingredient string
+	Substitute string
+	Ratio      float64
+	Note       string
+}
+
+type SubstitutionModel struct {
+	DB              *sql.DB
+	IngredientModel IngredientModelInterface
+	// WorkspaceID is the only workspace whose substitutions the model sees, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only sees, and seeds, the substitutions between the
+// ingredients of the workspace.
+func (m *SubstitutionModel) InWorkspace(workspaceID int) SubstitutionModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	scoped.IngredientModel = m.IngredientModel.InWorkspace(workspaceID)
+	return &scoped
+}
+
+// GetByIngredientID returns the substitutes of an ingredient, classified so that one free of
+// a given allergen can be picked.
+func (m *SubstitutionModel) GetByIngredientID(ingredientID int) ([]*Substitution, error) {
+	substitutions := []*Substitution{}
+
+	stmt := `
+		SELECT i.id, i.name, i.allergens, i.diets, i.classified, s.ratio, s.note
+		FROM ingredient_substitutions s INNER JOIN ingredients i ON i.id = s.substitute_id
+		WHERE s.ingredient_id = ? AND i.workspace_id = ?
+		ORDER BY i.name
+		`
+
+	rows, err := m.DB.Query(stmt, ingredientID, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var (
+			substitution = &Substitution{
+				IngredientID: ingredientID,
+				Substitute:   &Ingredient{},
+			}
+			allergens string
+			diets     string
+		)
+
+		err := rows.Scan(
+			&substitution.Substitute.ID,
+			&substitution.Substitute.Name,
+			&allergens,
+			&diets,
+			&substitution.Substitute.Classified,
+			&substitution.Ratio,
+			&substitution.Note,
+		)
+		if err != nil {
+			return nil, err
+		}
+		substitution.Substitute.Allergens = splitSet(allergens)
+		substitution.Substitute.Diets = splitSet(diets)
+		substitutions = append(substitutions, substitution)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return substitutions, nil
+}
+
+// Seed adds the given substitutions to the workspace, and the ingredients they are between when they
+// don't exist yet.
+// Existing substitutions get their ratio and note replaced.
+func (m *SubstitutionModel) Seed(rules []*SubstitutionRule) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		stmt := `
+		INSERT INTO ingredient_substitutions
+			(ingredient_id, substitute_id, ratio, note)
+		VALUES
+			(?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			ratio = VALUES(ratio),
+			note = VALUES(note)
+		`
+		for _, rule := range rules {
+			ingredientID, err := m.IngredientModel.InsertIfNotExists(tx, rule.Ingredient)
+			if err != nil {
+				return err
+			}
+			substituteID, err := m.IngredientModel.InsertIfNotExists(tx, rule.Substitute)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(stmt, ingredientID, substituteID, rule.Ratio, rule.Note); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Substitute replaces an ingredient of the recipe with a substitute for it, adjusting the quantity by
+// the ratio of the substitution, and updates the steps using it as well as the allergens and diets.
+// A substitute that is already part of the recipe has the quantity added to it when the units allow.
+func (r *Recipe) Substitute(substitution *Substitution) error {
+	index := -1
+	for i, ingredient := range r.Ingredients {
+		if ingredient.Ingredient != nil && ingredient.ID == substitution.IngredientID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return ErrInvalidSubstitution
+	}
+
+	replaced := r.Ingredients[index]
+	replacement := &FullIngredient{
+		Ingredient: substitution.Substitute,
+		Quantity:   math.Round(replaced.Quantity*substitution.Ratio*100) / 100,
+		Unit:       replaced.Unit,
+	}
+
+	merged := false
+	for _, ingredient := range r.Ingredients {
+		if ingredient.Ingredient == nil || ingredient.ID != replacement.ID {
+			continue
+		}
+		if quantity, ok := units.Convert(replacement.Quantity, replacement.Unit, ingredient.Unit); ok {
+			ingredient.Quantity = math.Round((ingredient.Quantity+quantity)*100) / 100
+			merged = true
+		}
+		break
+	}
+	if merged {
+		r.Ingredients = append(r.Ingredients[:index], r.Ingredients[index+1:]...)
+	} else {
+		r.Ingredients[index] = replacement
+	}
+
+	for _, step := range r.Steps {
+		if !slices.Contains(step.IngredientIDs, substitution.IngredientID) {
+			continue
+		}
+		step.IngredientIDs = slices.DeleteFunc(step.IngredientIDs, func(id int) bool {
+			return id == substitution.IngredientID || id == replacement.ID
+		})
+		step.IngredientIDs = append(step.IngredientIDs, replacement.ID)
+	}
+
+	r.classify()
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipeSubstitute(t *testing.T) {
+	newRecipe := func() *Recipe {
+		return &Recipe{
+			Ingredients: []*FullIngredient{
+				{Ingredient: &Ingredient{ID: 1, Name: "butter", Allergens: []string{"dairy"}, Diets: []string{"vegetarian"}, Classified: true}, Quantity: 100, Unit: "g"},
+				{Ingredient: &Ingredient{ID: 2, Name: "vegetable oil", Diets: []string{"vegan", "vegetarian"}, Classified: true}, Quantity: 1, Unit: "tbsp"},
+				{Ingredient: &Ingredient{ID: 3, Name: "flour", Allergens: []string{"gluten"}, Diets: []string{"vegan", "vegetarian"}, Classified: true}, Quantity: 200, Unit: "g"},
+			},
+			Steps: []*Step{
+				{Position: 1, Text: "Melt the butter in the oil", IngredientIDs: []int{1, 2}},
+				{Position: 2, Text: "Add the flour", IngredientIDs: []int{3}},
+			},
+		}
+	}
+
+	oil := &Ingredient{ID: 2, Name: "vegetable oil", Diets: []string{"vegan", "vegetarian"}, Classified: true}
+	margarine := &Ingredient{ID: 4, Name: "margarine", Diets: []string{"vegan", "vegetarian"}, Classified: true}
+
+	t.Run("Replaced", func(t *testing.T) {
+		recipe := newRecipe()
+		assert.NoError(t, recipe.Substitute(&Substitution{IngredientID: 1, Substitute: margarine, Ratio: 1}))
+
+		assert.Len(t, recipe.Ingredients, 3)
+		assert.Equal(t, "margarine", recipe.Ingredients[0].Name)
+		assert.Equal(t, 100.0, recipe.Ingredients[0].Quantity)
+		assert.Equal(t, []int{2, 4}, recipe.Steps[0].IngredientIDs)
+		assert.Equal(t, []string{"gluten"}, recipe.Allergens)
+		assert.Equal(t, []string{"vegan", "vegetarian"}, recipe.Diets)
+	})
+
+	t.Run("Merged", func(t *testing.T) {
+		recipe := newRecipe()
+		recipe.Ingredients[1].Unit = "g"
+		assert.NoError(t, recipe.Substitute(&Substitution{IngredientID: 1, Substitute: oil, Ratio: 0.8}))
+
+		assert.Len(t, recipe.Ingredients, 2)
+		assert.Equal(t, "vegetable oil", recipe.Ingredients[0].Name)
+		assert.Equal(t, 81.0, recipe.Ingredients[0].Quantity)
+		assert.Equal(t, []int{2}, recipe.Steps[0].IngredientIDs)
+	})
+
+	t.Run("Incompatible Units", func(t *testing.T) {
+		recipe := newRecipe()
+		assert.NoError(t, recipe.Substitute(&Substitution{IngredientID: 1, Substitute: oil, Ratio: 0.8}))
+
+		assert.Len(t, recipe.Ingredients, 3)
+		assert.Equal(t, 80.0, recipe.Ingredients[0].Quantity)
+		assert.Equal(t, "g", recipe.Ingredients[0].Unit)
+	})
+
+	t.Run("Not In Recipe", func(t *testing.T) {
+		recipe := newRecipe()
+		err := recipe.Substitute(&Substitution{IngredientID: 5, Substitute: oil, Ratio: 1})
+		assert.ErrorIs(t, err, ErrInvalidSubstitution)
+	})
+}
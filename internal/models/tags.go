@@ -4,27 +4,47 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"strings"
 )
 
 type TagModelInterface interface {
 	GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*Tag, error)
 	InsertIfNotExists(tx transactions.Transaction, name string) (int, error)
+	Get(id int) (*Tag, error)
+	GetAll() ([]*Tag, error)
+	Update(tag *Tag) error
+	InWorkspace(workspaceID int) TagModelInterface
 }
 
+// Tag labels recipes. Tags can be nested under a parent, e.g. "sicilian" under "italian" under "cuisine",
+// and belong to a category, such as "course" or "cuisine", that tells facets apart.
 type Tag struct {
-	ID   int    `json:"id"`
-	Name string `json:"name,omitempty"`
+	ID       int    `json:"id"`
+	Name     string `json:"name,omitempty"`
+	ParentID int    `json:"parent_id,omitempty"`
+	Category string `json:"category,omitempty"`
+	// Children are only filled in when tags are returned as a tree
+	Children []*Tag `json:"children,omitempty"`
 }
 
 type TagModel struct {
 	DB *sql.DB
+	// WorkspaceID is the only workspace whose tags the model sees, the default one when zero
+	WorkspaceID int
+}
+
+// InWorkspace returns a copy of the model that only sees the tags of the workspace.
+func (m *TagModel) InWorkspace(workspaceID int) TagModelInterface {
+	scoped := *m
+	scoped.WorkspaceID = workspaceID
+	return &scoped
 }
 
 func (m *TagModel) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*Tag, error) {
 	var tags []*Tag
 
 	stmt := `
-		SELECT t.id, t.name
+		SELECT t.id, t.name, COALESCE(t.parent_id, 0), t.category
 		FROM tags t INNER JOIN recipe_tags rt ON rt.tag_id = t.id
 		WHERE rt.recipe_id = ?
 		`
@@ -48,6 +68,8 @@ func (m *TagModel) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*
 		err := rows.Scan(
 			&tag.ID,
 			&tag.Name,
+			&tag.ParentID,
+			&tag.Category,
 		)
 		if err != nil {
 			return nil, err
@@ -64,9 +86,10 @@ func (m *TagModel) GetByRecipeID(tx transactions.Transaction, recipeID int) ([]*
 
 func (m *TagModel) InsertIfNotExists(tx transactions.Transaction, name string) (int, error) {
 	var id int
-	if err := tx.QueryRow("SELECT id FROM tags WHERE name = ?", name).Scan(&id); err != nil {
+	workspaceID := workspaceOrDefault(m.WorkspaceID)
+	if err := tx.QueryRow("SELECT id FROM tags WHERE name = ? AND workspace_id = ?", name, workspaceID).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			result, err := tx.Exec("INSERT INTO tags(name) VALUES (?)", name)
+			result, err := tx.Exec("INSERT INTO tags(name, workspace_id) VALUES (?, ?)", name, workspaceID)
 			if err != nil {
 				return 0, err
 			}
@@ -82,3 +105,197 @@ func (m *TagModel) InsertIfNotExists(tx transactions.Transaction, name string) (
 
 	return id, nil
 }
+
+func (m *TagModel) Get(id int) (*Tag, error) {
+	tag := &Tag{}
+
+	stmt := `
+		SELECT id, name, COALESCE(parent_id, 0), category
+		FROM tags
+		WHERE id = ? AND workspace_id = ?
+		`
+
+	err := m.DB.QueryRow(stmt, id, workspaceOrDefault(m.WorkspaceID)).Scan(&tag.ID, &tag.Name, &tag.ParentID, &tag.Category)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	return tag, nil
+}
+
+// GetAll returns every tag of the workspace, in alphabetical order.
+func (m *TagModel) GetAll() ([]*Tag, error) {
+	tags := []*Tag{}
+
+	stmt := "SELECT id, name, COALESCE(parent_id, 0), category FROM tags WHERE workspace_id = ? ORDER BY name, id"
+	rows, err := m.DB.Query(stmt, workspaceOrDefault(m.WorkspaceID))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		tag := &Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.ParentID, &tag.Category); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Update moves the tag under its parent, or to the top of the hierarchy when it has none, and sets
+// its category. A parent that doesn't exist in the workspace, or that would make the tag its own
+// ancestor, is invalid.
+func (m *TagModel) Update(tag *Tag) error {
+	tag.Category = strings.ToLower(strings.TrimSpace(tag.Category))
+	workspaceID := workspaceOrDefault(m.WorkspaceID)
+
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		var id int
+		if err := tx.QueryRow("SELECT id FROM tags WHERE id = ? AND workspace_id = ? FOR UPDATE", tag.ID, workspaceID).Scan(&id); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNoRecord
+			default:
+				return err
+			}
+		}
+
+		// Walk up from the new parent, making sure the tag isn't met on the way
+		for ancestor := tag.ParentID; ancestor != 0; {
+			if ancestor == tag.ID {
+				return ErrInvalidTagParent
+			}
+
+			var parentID sql.NullInt64
+			err := tx.QueryRow("SELECT parent_id FROM tags WHERE id = ? AND workspace_id = ?", ancestor, workspaceID).Scan(&parentID)
+			if err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					return ErrInvalidTagParent
+				default:
+					return err
+				}
+			}
+			ancestor = int(parentID.Int64)
+		}
+
+		var parentID sql.NullInt64
+		if tag.ParentID > 0 {
+			parentID = sql.NullInt64{Int64: int64(tag.ParentID), Valid: true}
+		}
+
+		_, err := tx.Exec("UPDATE tags SET parent_id = ?, category = ? WHERE id = ?", parentID, tag.Category, tag.ID)
+		return err
+	})
+}
+
+// TagHierarchy answers questions about the tree the tags make up.
+type TagHierarchy struct {
+	tags     map[int]*Tag
+	children map[int][]*Tag
+	roots    []*Tag
+}
+
+// NewTagHierarchy indexes the tags by their parent. Tags whose parent isn't among them are roots.
+func NewTagHierarchy(tags []*Tag) *TagHierarchy {
+	h := &TagHierarchy{
+		tags:     make(map[int]*Tag, len(tags)),
+		children: make(map[int][]*Tag),
+	}
+	for _, tag := range tags {
+		h.tags[tag.ID] = tag
+	}
+
+	for _, tag := range tags {
+		if _, exists := h.tags[tag.ParentID]; exists {
+			h.children[tag.ParentID] = append(h.children[tag.ParentID], tag)
+		} else {
+			h.roots = append(h.roots, tag)
+		}
+	}
+
+	return h
+}
+
+// Find returns the tag with the given name, regardless of case, or nil.
+func (h *TagHierarchy) Find(name string) *Tag {
+	for _, tag := range h.tags {
+		if strings.EqualFold(tag.Name, name) {
+			return tag
+		}
+	}
+	return nil
+}
+
+// Descendants returns the IDs of the tag and of every tag under it.
+func (h *TagHierarchy) Descendants(id int) []int {
+	ids := []int{id}
+	seen := map[int]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range h.children[ids[i]] {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				ids = append(ids, child.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// Ancestors returns the IDs of the tag and of every tag above it, the tag first.
+func (h *TagHierarchy) Ancestors(id int) []int {
+	var ids []int
+	// Tags can't be their own ancestors, the limit only guards against data that was changed by hand
+	for tag, exists := h.tags[id]; exists && len(ids) <= len(h.tags); tag, exists = h.tags[tag.ParentID] {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
+// Category returns the category of the tag, which tags without one of their own take from their
+// closest ancestor that has one.
+func (h *TagHierarchy) Category(id int) string {
+	for _, ancestor := range h.Ancestors(id) {
+		if category := h.tags[ancestor].Category; category != "" {
+			return category
+		}
+	}
+	return ""
+}
+
+// Tree returns copies of the root tags with their children filled in, optionally keeping only the
+// trees of one category.
+func (h *TagHierarchy) Tree(category string) []*Tag {
+	var build func(tag *Tag) *Tag
+	build = func(tag *Tag) *Tag {
+		node := *tag
+		node.Category = h.Category(tag.ID)
+		node.Children = nil
+		for _, child := range h.children[tag.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return &node
+	}
+
+	tree := []*Tag{}
+	for _, root := range h.roots {
+		if category == "" || h.Category(root.ID) == category {
+			tree = append(tree, build(root))
+		}
+	}
+	return tree
+}
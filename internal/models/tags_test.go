@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTags() []*Tag {
+	return []*Tag{
+		{ID: 1, Name: "cuisine", Category: "cuisine"},
+		{ID: 2, Name: "italian", ParentID: 1},
+		{ID: 3, Name: "sicilian", ParentID: 2},
+		{ID: 4, Name: "japanese", ParentID: 1},
+		{ID: 5, Name: "dessert", Category: "course"},
+		{ID: 6, Name: "quick"},
+	}
+}
+
+func TestTagHierarchy(t *testing.T) {
+	h := NewTagHierarchy(newTags())
+
+	assert.Equal(t, []int{1, 2, 4, 3}, h.Descendants(1))
+	assert.Equal(t, []int{3}, h.Descendants(3))
+	assert.Equal(t, []int{3, 2, 1}, h.Ancestors(3))
+	assert.Empty(t, h.Ancestors(42))
+
+	assert.Equal(t, "cuisine", h.Category(3))
+	assert.Equal(t, "course", h.Category(5))
+	assert.Equal(t, "", h.Category(6))
+
+	assert.Equal(t, 2, h.Find("Italian").ID)
+	assert.Nil(t, h.Find("french"))
+}
+
+func TestTagHierarchyTree(t *testing.T) {
+	h := NewTagHierarchy(newTags())
+
+	tree := h.Tree("")
+	assert.Len(t, tree, 3)
+	assert.Equal(t, "cuisine", tree[0].Name)
+	assert.Equal(t, "italian", tree[0].Children[0].Name)
+	assert.Equal(t, "cuisine", tree[0].Children[0].Category)
+	assert.Equal(t, "sicilian", tree[0].Children[0].Children[0].Name)
+	assert.Equal(t, "japanese", tree[0].Children[1].Name)
+
+	courses := h.Tree("course")
+	assert.Len(t, courses, 1)
+	assert.Equal(t, "dessert", courses[0].Name)
+
+	// Building the tree leaves the tags themselves alone
+	assert.Empty(t, newTags()[1].Category)
+	assert.Nil(t, h.Find("cuisine").Children)
+}
+
+func TestTagHierarchyCycle(t *testing.T) {
+	// Tags are never their own ancestors, but a cycle changed by hand must not hang
+	h := NewTagHierarchy([]*Tag{
+		{ID: 1, Name: "a", ParentID: 2},
+		{ID: 2, Name: "b", ParentID: 1},
+	})
+
+	assert.LessOrEqual(t, len(h.Ancestors(1)), 3)
+	assert.Equal(t, []int{1, 2}, h.Descendants(1))
+	assert.Empty(t, h.Tree(""))
+}
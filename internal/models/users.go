@@ -0,0 +1,113 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
+)
+
+type UserModelInterface interface {
+	Insert(name, email, password string) (int, error)
+	Authenticate(email, password string) (int, error)
+	Get(id int) (*User, error)
+}
+
+type User struct {
+	ID             int       `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	HashedPassword []byte    `json:"-"`
+	Admin          bool      `json:"admin,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type UserModel struct {
+	DB *sql.DB
+}
+
+const bcryptCost = 12
+
+func (m *UserModel) Insert(name, email, password string) (int, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := `
+		INSERT INTO users (name, email, hashed_password, created)
+		VALUES (?, ?, ?, UTC_TIMESTAMP())
+		`
+
+	result, err := m.DB.Exec(stmt, name, email, string(hashedPassword))
+	if err != nil {
+		var mySQLError *mysql.MySQLError
+		if errors.As(err, &mySQLError) {
+			if mySQLError.Number == 1062 && strings.Contains(mySQLError.Message, "user_uc_email") {
+				return 0, ErrDuplicateEmail
+			}
+		}
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// Authenticate returns the ID of the user with the given email address and password.
+func (m *UserModel) Authenticate(email, password string) (int, error) {
+	var (
+		id             int
+		hashedPassword []byte
+	)
+
+	err := m.DB.QueryRow("SELECT id, hashed_password FROM users WHERE email = ?", email).Scan(&id, &hashedPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, ErrInvalidCredentials
+		default:
+			return 0, err
+		}
+	}
+
+	err = bcrypt.CompareHashAndPassword(hashedPassword, []byte(password))
+	if err != nil {
+		switch {
+		case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+			return 0, ErrInvalidCredentials
+		default:
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+func (m *UserModel) Get(id int) (*User, error) {
+	user := &User{}
+
+	err := m.DB.QueryRow("SELECT id, name, email, is_admin, created FROM users WHERE id = ?", id).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Admin,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
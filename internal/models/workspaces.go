@@ -0,0 +1,326 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/vladComan0/tasty-byte/pkg/transactions"
+	"time"
+)
+
+type WorkspaceModelInterface interface {
+	Insert(name string, ownerID int) (int, error)
+	Get(id int) (*Workspace, error)
+	GetAll() ([]*Workspace, error)
+	GetByUserID(userID int) ([]*Workspace, error)
+	GetRole(id, userID int) (string, error)
+	GetMembers(id int) ([]*WorkspaceMember, error)
+	SetMember(id, userID int, role string) error
+	RemoveMember(id, userID int) error
+}
+
+// DefaultWorkspaceID is the shared catalogue that has no members, which requests that don't select
+// a workspace use, as do the recipe, tag and ingredient models that weren't scoped to one.
+const DefaultWorkspaceID = 1
+
+// The roles members have in a workspace, each allowing what the ones below it do.
+const (
+	// RoleOwner manages the members on top of editing the catalogue
+	RoleOwner = "owner"
+	// RoleEditor adds, changes and deletes recipes, tags and ingredients
+	RoleEditor = "editor"
+	// RoleViewer only reads the catalogue
+	RoleViewer = "viewer"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// ValidRole tells whether members can have the role.
+func ValidRole(role string) bool {
+	_, exists := roleRanks[role]
+	return exists
+}
+
+// RoleAllows tells whether a member with the role can do what the required role can.
+func RoleAllows(role, required string) bool {
+	return ValidRole(role) && roleRanks[role] >= roleRanks[required]
+}
+
+// workspaceOrDefault returns the workspace a model is scoped to, the default one when it isn't.
+func workspaceOrDefault(id int) int {
+	if id == 0 {
+		return DefaultWorkspaceID
+	}
+	return id
+}
+
+// Workspace has its own catalogue of recipes, tags and ingredients, shared by its members.
+type Workspace struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Role is the one of the user the workspaces were listed for
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkspaceMember is a user with a role in a workspace.
+type WorkspaceMember struct {
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+type WorkspaceModel struct {
+	DB *sql.DB
+}
+
+// Insert creates a workspace owned by the user. The workspace starts with the ingredients of the
+// default workspace that were classified or have substitutes, with their aliases and substitutions,
+// so that dietary filters, the usual spellings of those ingredients and substitutes work there from
+// the start.
+func (m *WorkspaceModel) Insert(name string, ownerID int) (int, error) {
+	var id int
+
+	err := transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		result, err := tx.Exec("INSERT INTO workspaces (name, created) VALUES (?, UTC_TIMESTAMP())", name)
+		if err != nil {
+			return err
+		}
+		id64, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		id = int(id64)
+
+		stmt := `
+		INSERT INTO workspace_members (workspace_id, user_id, role, created)
+		VALUES (?, ?, ?, UTC_TIMESTAMP())
+		`
+		if _, err := tx.Exec(stmt, id, ownerID, RoleOwner); err != nil {
+			return err
+		}
+
+		stmt = `
+		INSERT INTO ingredients (workspace_id, name, allergens, diets, classified)
+		SELECT ?, name, allergens, diets, classified
+		FROM ingredients
+		WHERE workspace_id = ? AND (
+			classified
+			OR id IN (SELECT ingredient_id FROM ingredient_substitutions)
+			OR id IN (SELECT substitute_id FROM ingredient_substitutions)
+		)
+		`
+		if _, err := tx.Exec(stmt, id, DefaultWorkspaceID); err != nil {
+			return err
+		}
+
+		// The aliases point at the copies, found by name as names are unique within a workspace
+		stmt = `
+		INSERT INTO ingredient_aliases (workspace_id, alias, ingredient_id)
+		SELECT ?, a.alias, copied.id
+		FROM ingredient_aliases a
+		INNER JOIN ingredients original ON original.id = a.ingredient_id
+		INNER JOIN ingredients copied ON copied.workspace_id = ? AND copied.name = original.name
+		WHERE a.workspace_id = ?
+		`
+		if _, err := tx.Exec(stmt, id, id, DefaultWorkspaceID); err != nil {
+			return err
+		}
+
+		stmt = `
+		INSERT INTO ingredient_substitutions (ingredient_id, substitute_id, ratio, note)
+		SELECT copied.id, copied_substitute.id, s.ratio, s.note
+		FROM ingredient_substitutions s
+		INNER JOIN ingredients original ON original.id = s.ingredient_id
+		INNER JOIN ingredients original_substitute ON original_substitute.id = s.substitute_id
+		INNER JOIN ingredients copied ON copied.workspace_id = ? AND copied.name = original.name
+		INNER JOIN ingredients copied_substitute ON copied_substitute.workspace_id = ? AND copied_substitute.name = original_substitute.name
+		WHERE original.workspace_id = ?
+		`
+		_, err = tx.Exec(stmt, id, id, DefaultWorkspaceID)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (m *WorkspaceModel) Get(id int) (*Workspace, error) {
+	workspace := &Workspace{}
+
+	err := m.DB.QueryRow("SELECT id, name, created FROM workspaces WHERE id = ?", id).Scan(
+		&workspace.ID,
+		&workspace.Name,
+		&workspace.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoRecord
+		default:
+			return nil, err
+		}
+	}
+
+	return workspace, nil
+}
+
+// GetAll returns every workspace, the default one included, for the tasks that go through all of them.
+func (m *WorkspaceModel) GetAll() ([]*Workspace, error) {
+	return m.query("SELECT id, name, '', created FROM workspaces ORDER BY id")
+}
+
+// GetByUserID returns the workspaces the user is a member of, with their role in each.
+func (m *WorkspaceModel) GetByUserID(userID int) ([]*Workspace, error) {
+	stmt := `
+		SELECT w.id, w.name, wm.role, w.created
+		FROM workspaces w INNER JOIN workspace_members wm ON wm.workspace_id = w.id
+		WHERE wm.user_id = ?
+		ORDER BY w.name, w.id
+		`
+	return m.query(stmt, userID)
+}
+
+func (m *WorkspaceModel) query(stmt string, args ...any) ([]*Workspace, error) {
+	workspaces := []*Workspace{}
+
+	rows, err := m.DB.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		workspace := &Workspace{}
+		if err := rows.Scan(&workspace.ID, &workspace.Name, &workspace.Role, &workspace.CreatedAt); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+// GetRole returns the role of the user in the workspace, or ErrNoRecord when they aren't a member.
+func (m *WorkspaceModel) GetRole(id, userID int) (string, error) {
+	var role string
+
+	err := m.DB.QueryRow("SELECT role FROM workspace_members WHERE workspace_id = ? AND user_id = ?", id, userID).Scan(&role)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return "", ErrNoRecord
+		default:
+			return "", err
+		}
+	}
+
+	return role, nil
+}
+
+// GetMembers returns the members of the workspace, the owners first.
+func (m *WorkspaceModel) GetMembers(id int) ([]*WorkspaceMember, error) {
+	members := []*WorkspaceMember{}
+
+	stmt := `
+		SELECT u.id, u.name, u.email, wm.role
+		FROM workspace_members wm INNER JOIN users u ON u.id = wm.user_id
+		WHERE wm.workspace_id = ?
+		ORDER BY wm.role, u.name, u.id
+		`
+
+	rows, err := m.DB.Query(stmt, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		member := &WorkspaceMember{}
+		if err := rows.Scan(&member.UserID, &member.Name, &member.Email, &member.Role); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// SetMember adds the user to the workspace with the role, or changes the role they have in it.
+// A workspace always keeps an owner, so the last one can't be given another role.
+func (m *WorkspaceModel) SetMember(id, userID int, role string) error {
+	if !ValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		stmt := `
+		INSERT INTO workspace_members (workspace_id, user_id, role, created)
+		VALUES (?, ?, ?, UTC_TIMESTAMP())
+		ON DUPLICATE KEY UPDATE role = VALUES(role)
+		`
+		if _, err := tx.Exec(stmt, id, userID, role); err != nil {
+			// The workspace or the user doesn't exist
+			var mySQLError *mysql.MySQLError
+			if errors.As(err, &mySQLError) && mySQLError.Number == 1452 {
+				return ErrNoRecord
+			}
+			return err
+		}
+
+		return checkOwners(tx, id)
+	})
+}
+
+// RemoveMember takes the user out of the workspace, unless they are its last owner.
+func (m *WorkspaceModel) RemoveMember(id, userID int) error {
+	return transactions.WithTransaction(m.DB, func(tx transactions.Transaction) error {
+		result, err := tx.Exec("DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?", id, userID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNoRecord
+		}
+
+		return checkOwners(tx, id)
+	})
+}
+
+// checkOwners fails with ErrLastOwner, rolling back the change to the members, when the workspace
+// was left without an owner.
+func checkOwners(tx transactions.Transaction, id int) error {
+	var owners int
+	err := tx.QueryRow("SELECT COUNT(*) FROM workspace_members WHERE workspace_id = ? AND role = ?", id, RoleOwner).Scan(&owners)
+	if err != nil {
+		return err
+	}
+	if owners == 0 {
+		return ErrLastOwner
+	}
+	return nil
+}
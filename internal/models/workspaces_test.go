@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, RoleAllows(RoleOwner, RoleEditor))
+	assert.True(t, RoleAllows(RoleEditor, RoleEditor))
+	assert.False(t, RoleAllows(RoleViewer, RoleEditor))
+	assert.True(t, RoleAllows(RoleViewer, RoleViewer))
+	assert.False(t, RoleAllows(RoleEditor, RoleOwner))
+	assert.False(t, RoleAllows("", RoleViewer))
+	assert.False(t, RoleAllows("admin", RoleViewer))
+}
+
+func TestInWorkspace(t *testing.T) {
+	ingredients := &IngredientModel{}
+	tags := &TagModel{}
+	recipes := &RecipeModel{IngredientModel: ingredients, TagModel: tags}
+
+	scoped, ok := recipes.InWorkspace(2).(*RecipeModel)
+	assert.True(t, ok)
+	assert.Equal(t, 2, scoped.WorkspaceID)
+
+	// The models the recipes are stored through see the same workspace
+	assert.Equal(t, 2, scoped.IngredientModel.(*IngredientModel).WorkspaceID)
+	assert.Equal(t, 2, scoped.TagModel.(*TagModel).WorkspaceID)
+
+	// The models that were scoped from are left as they were, in the default workspace
+	assert.Zero(t, recipes.WorkspaceID)
+	assert.Zero(t, ingredients.WorkspaceID)
+	assert.Zero(t, tags.WorkspaceID)
+	assert.Equal(t, DefaultWorkspaceID, workspaceOrDefault(recipes.WorkspaceID))
+	assert.Equal(t, 2, workspaceOrDefault(scoped.WorkspaceID))
+
+	// Substitutions are seeded through the ingredients of their workspace
+	substitutions := &SubstitutionModel{IngredientModel: ingredients}
+	scopedSubstitutions, ok := substitutions.InWorkspace(2).(*SubstitutionModel)
+	assert.True(t, ok)
+	assert.Equal(t, 2, scopedSubstitutions.WorkspaceID)
+	assert.Equal(t, 2, scopedSubstitutions.IngredientModel.(*IngredientModel).WorkspaceID)
+	assert.Zero(t, substitutions.WorkspaceID)
+}
@@ -0,0 +1,270 @@
+// Package nutrition computes the calories and macronutrients of recipes from per-100 g
+// ingredient values, the bundled ones being loaded from nutrition.csv.
+package nutrition
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/units"
+)
+
+//go:embed nutrition.csv
+var bundled []byte
+
+var csvHeader = []string{"name", "calories", "protein", "carbohydrates", "sugar", "fat", "fiber", "density", "piece_weight"}
+
+// Reasons for which an ingredient couldn't be converted to grams.
+const (
+	ReasonNoData        = "no nutrition data for this ingredient"
+	ReasonNoDensity     = "no density to convert this volume to grams"
+	ReasonNoPieceWeight = "no piece weight to convert this count to grams"
+)
+
+// RecipeNutrition is the nutrition of a whole recipe and of a single portion of it.
+// Totals only cover the resolved ingredients, Complete tells whether that is all of them.
+type RecipeNutrition struct {
+	Portions    int                    `json:"portions"`
+	Total       models.Nutrients       `json:"total"`
+	PerPortion  *models.Nutrients      `json:"per_portion,omitempty"`
+	Complete    bool                   `json:"complete"`
+	Ingredients []*IngredientNutrition `json:"ingredients"`
+}
+
+// IngredientNutrition is the contribution of a single ingredient to the recipe.
+type IngredientNutrition struct {
+	ID        int               `json:"id"`
+	Name      string            `json:"name"`
+	Quantity  float64           `json:"quantity"`
+	Unit      string            `json:"unit"`
+	Grams     *float64          `json:"grams,omitempty"`
+	Nutrients *models.Nutrients `json:"nutrients,omitempty"`
+	Resolved  bool              `json:"resolved"`
+	Reason    string            `json:"reason,omitempty"`
+}
+
+// Bundled returns the nutrition facts shipped with the application.
+func Bundled() ([]*models.NutritionFacts, error) {
+	return ParseCSV(bytes.NewReader(bundled))
+}
+
+// ParseCSV reads nutrition facts from a CSV file with the columns of nutrition.csv.
+// Density and piece weight may be left empty.
+func ParseCSV(r io.Reader) ([]*models.NutritionFacts, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("nutrition: unexpected column %q, expected %q", header[i], column)
+		}
+	}
+
+	var results []*models.NutritionFacts
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		facts, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("nutrition: line %d: %w", line, err)
+		}
+		results = append(results, facts)
+	}
+}
+
+func parseRecord(record []string) (*models.NutritionFacts, error) {
+	facts := &models.NutritionFacts{Name: strings.ToLower(strings.TrimSpace(record[0]))}
+	if facts.Name == "" {
+		return nil, errors.New("missing name")
+	}
+
+	values := []*float64{
+		&facts.Per100g.Calories,
+		&facts.Per100g.Protein,
+		&facts.Per100g.Carbohydrates,
+		&facts.Per100g.Sugar,
+		&facts.Per100g.Fat,
+		&facts.Per100g.Fiber,
+	}
+	for i, value := range values {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(record[i+1]), 64)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid %s %q", csvHeader[i+1], record[i+1])
+		}
+		*value = parsed
+	}
+
+	optional := []**float64{&facts.Density, &facts.PieceWeight}
+	for i, value := range optional {
+		field := strings.TrimSpace(record[i+7])
+		if field == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(field, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid %s %q", csvHeader[i+7], field)
+		}
+		*value = &parsed
+	}
+
+	return facts, nil
+}
+
+// LookupNames returns the names to look nutrition facts up by for the given ingredients:
+// their lower-cased names along with their singular forms, so that "eggs" finds "egg".
+func LookupNames(ingredients []*models.FullIngredient) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, ingredient := range ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil {
+			continue
+		}
+		for _, name := range candidates(ingredient.Name) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func candidates(name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := []string{name}
+	if singular, ok := strings.CutSuffix(name, "es"); ok {
+		names = append(names, singular)
+	}
+	if singular, ok := strings.CutSuffix(name, "s"); ok {
+		names = append(names, singular)
+	}
+	return names
+}
+
+// Calculate works out the nutrition of a recipe with the given facts, keyed by lower-cased name.
+func Calculate(recipe *models.Recipe, facts map[string]*models.NutritionFacts) *RecipeNutrition {
+	result := &RecipeNutrition{
+		Portions:    recipe.Portions,
+		Complete:    true,
+		Ingredients: make([]*IngredientNutrition, 0, len(recipe.Ingredients)),
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil {
+			continue
+		}
+
+		item := &IngredientNutrition{
+			ID:       ingredient.ID,
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		}
+		result.Ingredients = append(result.Ingredients, item)
+
+		var known *models.NutritionFacts
+		for _, name := range candidates(ingredient.Name) {
+			if known = facts[name]; known != nil {
+				break
+			}
+		}
+		if known == nil {
+			item.Reason = ReasonNoData
+			result.Complete = false
+			continue
+		}
+
+		grams, reason := toGrams(ingredient, known)
+		if reason != "" {
+			item.Reason = reason
+			result.Complete = false
+			continue
+		}
+
+		nutrients := scale(known.Per100g, grams/100)
+		item.Grams = &grams
+		item.Nutrients = &nutrients
+		item.Resolved = true
+		result.Total = add(result.Total, nutrients)
+	}
+
+	// Rounded once more to get rid of the floating point noise of the sum
+	result.Total = scale(result.Total, 1)
+	if recipe.Portions > 0 {
+		perPortion := scale(result.Total, 1/float64(recipe.Portions))
+		result.PerPortion = &perPortion
+	}
+
+	return result
+}
+
+func toGrams(ingredient *models.FullIngredient, facts *models.NutritionFacts) (float64, string) {
+	// Amounts such as "salt, to taste" don't count towards the totals
+	if ingredient.Quantity == 0 {
+		return 0, ""
+	}
+
+	switch units.KindOf(ingredient.Unit) {
+	case units.Mass:
+		grams, _ := units.ToGrams(ingredient.Quantity, ingredient.Unit)
+		return round(grams), ""
+	case units.Volume:
+		if facts.Density == nil {
+			return 0, ReasonNoDensity
+		}
+		milliliters, _ := units.ToMilliliters(ingredient.Quantity, ingredient.Unit)
+		return round(milliliters * *facts.Density), ""
+	default:
+		if facts.PieceWeight == nil {
+			return 0, ReasonNoPieceWeight
+		}
+		return round(ingredient.Quantity * *facts.PieceWeight), ""
+	}
+}
+
+func add(a, b models.Nutrients) models.Nutrients {
+	return models.Nutrients{
+		Calories:      a.Calories + b.Calories,
+		Protein:       a.Protein + b.Protein,
+		Carbohydrates: a.Carbohydrates + b.Carbohydrates,
+		Sugar:         a.Sugar + b.Sugar,
+		Fat:           a.Fat + b.Fat,
+		Fiber:         a.Fiber + b.Fiber,
+	}
+}
+
+// scale multiplies every value by factor, rounding the results to one decimal.
+func scale(n models.Nutrients, factor float64) models.Nutrients {
+	return models.Nutrients{
+		Calories:      round(n.Calories * factor),
+		Protein:       round(n.Protein * factor),
+		Carbohydrates: round(n.Carbohydrates * factor),
+		Sugar:         round(n.Sugar * factor),
+		Fat:           round(n.Fat * factor),
+		Fiber:         round(n.Fiber * factor),
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
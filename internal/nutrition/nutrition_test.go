@@ -0,0 +1,112 @@
+package nutrition
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func TestBundled(t *testing.T) {
+	facts, err := Bundled()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, facts)
+
+	seen := make(map[string]bool)
+	for _, f := range facts {
+		assert.False(t, seen[f.Name], "duplicate entry %q", f.Name)
+		seen[f.Name] = true
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			data: "name,calories,protein,carbohydrates,sugar,fat,fiber,density,piece_weight\nEgg,143,12.6,0.7,0.4,9.5,0,,50\n",
+		},
+		{
+			name:    "Wrong Header",
+			data:    "name,kcal,protein,carbohydrates,sugar,fat,fiber,density,piece_weight\n",
+			wantErr: true,
+		},
+		{
+			name:    "Negative Value",
+			data:    "name,calories,protein,carbohydrates,sugar,fat,fiber,density,piece_weight\negg,-1,0,0,0,0,0,,\n",
+			wantErr: true,
+		},
+		{
+			name:    "Zero Density",
+			data:    "name,calories,protein,carbohydrates,sugar,fat,fiber,density,piece_weight\nmilk,61,3.2,4.8,5.1,3.3,0,0,\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			facts, err := ParseCSV(strings.NewReader(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, facts, 1)
+			assert.Equal(t, "egg", facts[0].Name)
+			assert.Nil(t, facts[0].Density)
+			assert.Equal(t, 50.0, *facts[0].PieceWeight)
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	density := 1.0
+	pieceWeight := 50.0
+	facts := map[string]*models.NutritionFacts{
+		"flour": {Name: "flour", Per100g: models.Nutrients{Calories: 364, Protein: 10}},
+		"milk":  {Name: "milk", Per100g: models.Nutrients{Calories: 60, Fat: 3}, Density: &density},
+		"egg":   {Name: "egg", Per100g: models.Nutrients{Calories: 140, Protein: 12}, PieceWeight: &pieceWeight},
+		"sugar": {Name: "sugar", Per100g: models.Nutrients{Calories: 400}},
+	}
+
+	ingredient := func(id int, name string, quantity float64, unit string) *models.FullIngredient {
+		return &models.FullIngredient{
+			Ingredient: &models.Ingredient{ID: id, Name: name},
+			Quantity:   quantity,
+			Unit:       unit,
+		}
+	}
+
+	recipe := &models.Recipe{
+		Portions: 2,
+		Ingredients: []*models.FullIngredient{
+			ingredient(1, "Flour", 200, "g"),
+			ingredient(2, "milk", 250, "ml"),
+			ingredient(3, "Eggs", 2, ""),
+			ingredient(4, "sugar", 1, "cup"),
+			ingredient(5, "saffron", 1, "pinch"),
+		},
+	}
+
+	result := Calculate(recipe, facts)
+
+	assert.False(t, result.Complete)
+	assert.Equal(t, 2, result.Portions)
+	assert.Len(t, result.Ingredients, 5)
+
+	assert.True(t, result.Ingredients[0].Resolved)
+	assert.Equal(t, 200.0, *result.Ingredients[0].Grams)
+	assert.True(t, result.Ingredients[1].Resolved)
+	assert.Equal(t, 250.0, *result.Ingredients[1].Grams)
+	assert.True(t, result.Ingredients[2].Resolved)
+	assert.Equal(t, 100.0, *result.Ingredients[2].Grams)
+	assert.Equal(t, ReasonNoDensity, result.Ingredients[3].Reason)
+	assert.Equal(t, ReasonNoData, result.Ingredients[4].Reason)
+
+	assert.Equal(t, models.Nutrients{Calories: 1018, Protein: 32, Fat: 7.5}, result.Total)
+	assert.Equal(t, &models.Nutrients{Calories: 509, Protein: 16, Fat: 3.8}, result.PerPortion)
+}
@@ -0,0 +1,120 @@
+// Package pantry matches recipes against the ingredients a user has at home.
+package pantry
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/units"
+)
+
+// Match tells how much of a recipe the pantry covers.
+type Match struct {
+	RecipeID int        `json:"recipe_id"`
+	Name     string     `json:"name"`
+	Coverage float64    `json:"coverage"`
+	Covered  int        `json:"covered"`
+	Total    int        `json:"total"`
+	Missing  []*Missing `json:"missing"`
+}
+
+// Missing is what is lacking of an ingredient: all of it, or only part of it when the pantry
+// has some but not enough.
+type Missing struct {
+	IngredientID int     `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Partial      bool    `json:"partial"`
+}
+
+// MatchRecipe works out which ingredients of the recipe the pantry items cover. An ingredient is
+// covered when the pantry has enough of it, or has it in an amount that isn't tracked or that can't
+// be compared with the one of the recipe, such as "2 onions" against "200 g" of onion.
+func MatchRecipe(recipe *models.Recipe, items []*models.PantryItem) *Match {
+	byID := make(map[int]*models.PantryItem, len(items))
+	byName := make(map[string]*models.PantryItem, len(items))
+	for _, item := range items {
+		byID[item.IngredientID] = item
+		byName[strings.ToLower(item.Name)] = item
+	}
+
+	match := &Match{
+		RecipeID: recipe.ID,
+		Name:     recipe.Name,
+		Missing:  []*Missing{},
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil {
+			continue
+		}
+		match.Total++
+
+		item, ok := byID[ingredient.ID]
+		if !ok {
+			item, ok = byName[strings.ToLower(ingredient.Name)]
+		}
+		if !ok {
+			match.Missing = append(match.Missing, &Missing{
+				IngredientID: ingredient.ID,
+				Name:         ingredient.Name,
+				Quantity:     ingredient.Quantity,
+				Unit:         ingredient.Unit,
+			})
+			continue
+		}
+
+		if item.Quantity > 0 && ingredient.Quantity > 0 {
+			needed, ok := units.Convert(ingredient.Quantity, ingredient.Unit, item.Unit)
+			if ok && needed > item.Quantity {
+				shortfall, _ := units.Convert(needed-item.Quantity, item.Unit, ingredient.Unit)
+				match.Missing = append(match.Missing, &Missing{
+					IngredientID: ingredient.ID,
+					Name:         ingredient.Name,
+					Quantity:     math.Round(shortfall*100) / 100,
+					Unit:         ingredient.Unit,
+					Partial:      true,
+				})
+				continue
+			}
+		}
+
+		match.Covered++
+	}
+
+	match.Coverage = 1
+	if match.Total > 0 {
+		match.Coverage = math.Round(float64(match.Covered)/float64(match.Total)*100) / 100
+	}
+
+	return match
+}
+
+// Rank matches every recipe against the pantry and sorts them from the most to the least cookable:
+// fewest missing ingredients first, then highest coverage. Recipes missing more than maxMissing
+// ingredients are left out, unless maxMissing is negative.
+func Rank(recipes []*models.Recipe, items []*models.PantryItem, maxMissing int) []*Match {
+	matches := make([]*Match, 0, len(recipes))
+	for _, recipe := range recipes {
+		match := MatchRecipe(recipe, items)
+		if maxMissing >= 0 && len(match.Missing) > maxMissing {
+			continue
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if len(matches[i].Missing) != len(matches[j].Missing) {
+			return len(matches[i].Missing) < len(matches[j].Missing)
+		}
+		if matches[i].Coverage != matches[j].Coverage {
+			return matches[i].Coverage > matches[j].Coverage
+		}
+		return matches[i].RecipeID < matches[j].RecipeID
+	})
+
+	return matches
+}
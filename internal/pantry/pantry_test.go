@@ -0,0 +1,78 @@
+package pantry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func ingredient(id int, name string, quantity float64, unit string) *models.FullIngredient {
+	return &models.FullIngredient{
+		Ingredient: &models.Ingredient{ID: id, Name: name},
+		Quantity:   quantity,
+		Unit:       unit,
+	}
+}
+
+var items = []*models.PantryItem{
+	{IngredientID: 1, Name: "flour", Quantity: 1, Unit: "kg"},
+	{IngredientID: 2, Name: "milk", Quantity: 200, Unit: "ml"},
+	{IngredientID: 3, Name: "salt", Quantity: 0, Unit: ""},
+	{IngredientID: 4, Name: "onion", Quantity: 2, Unit: ""},
+}
+
+func TestMatchRecipe(t *testing.T) {
+	recipe := &models.Recipe{
+		ID:   1,
+		Name: "Pancakes",
+		Ingredients: []*models.FullIngredient{
+			ingredient(1, "flour", 250, "g"),
+			ingredient(2, "milk", 300, "ml"),
+			ingredient(3, "salt", 1, "pinch"),
+			ingredient(4, "onion", 100, "g"),
+			ingredient(5, "eggs", 2, ""),
+		},
+	}
+
+	match := MatchRecipe(recipe, items)
+
+	assert.Equal(t, 5, match.Total)
+	assert.Equal(t, 3, match.Covered)
+	assert.Equal(t, 0.6, match.Coverage)
+	assert.Equal(t, []*Missing{
+		{IngredientID: 2, Name: "milk", Quantity: 100, Unit: "ml", Partial: true},
+		{IngredientID: 5, Name: "eggs", Quantity: 2, Unit: ""},
+	}, match.Missing)
+}
+
+func TestRank(t *testing.T) {
+	recipes := []*models.Recipe{
+		{ID: 1, Name: "Omelette", Ingredients: []*models.FullIngredient{
+			ingredient(5, "eggs", 3, ""),
+			ingredient(3, "salt", 0, ""),
+		}},
+		{ID: 2, Name: "Flatbread", Ingredients: []*models.FullIngredient{
+			ingredient(1, "flour", 300, "g"),
+			ingredient(3, "salt", 1, "tsp"),
+		}},
+		{ID: 3, Name: "Crepes", Ingredients: []*models.FullIngredient{
+			ingredient(1, "flour", 100, "g"),
+			ingredient(2, "milk", 1, "l"),
+			ingredient(5, "eggs", 2, ""),
+			ingredient(6, "butter", 10, "g"),
+		}},
+	}
+
+	ids := func(matches []*Match) []int {
+		result := make([]int, 0, len(matches))
+		for _, match := range matches {
+			result = append(result, match.RecipeID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int{2, 1, 3}, ids(Rank(recipes, items, -1)))
+	assert.Equal(t, []int{2, 1}, ids(Rank(recipes, items, 1)))
+	assert.Equal(t, []int{2}, ids(Rank(recipes, items, 0)))
+}
@@ -0,0 +1,247 @@
+// Package recommend recommends recipes by their content. Every recipe is described by the TF-IDF
+// vector of its ingredients and tags, so that rare ingredients and tags tell more about a recipe
+// than the ones most recipes have, and recipes are ranked by the cosine similarity of their vector
+// to the vectors of the recipes someone liked.
+package recommend
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// Weights of what a user did with a recipe in their preferences.
+const (
+	favoriteWeight = 1.0
+	// Every time a recipe was cooked counts, up to maxCookedTimes times
+	cookedWeight   = 0.25
+	maxCookedTimes = 4
+)
+
+// Recommendation is a recommended recipe and how well it matches what it was recommended for, between 0 and 1.
+type Recommendation struct {
+	RecipeID int     `json:"recipe_id"`
+	Name     string  `json:"name"`
+	Score    float64 `json:"score"`
+}
+
+// Preferences weigh recipes by how much someone likes them, negative weights meaning that they don't.
+type Preferences map[int]float64
+
+// Favorite counts the recipe as a favorite.
+func (p Preferences) Favorite(recipeID int) {
+	p[recipeID] += favoriteWeight
+}
+
+// Rate counts the rating given to the recipe, from a dislike for models.MinRating to a like for
+// models.MaxRating, an average rating being neutral.
+func (p Preferences) Rate(recipeID, rating int) {
+	middle := float64(models.MinRating+models.MaxRating) / 2
+	p[recipeID] += (float64(rating) - middle) / (float64(models.MaxRating) - middle)
+}
+
+// Cook counts the times the recipe was cooked.
+func (p Preferences) Cook(recipeID, times int) {
+	p[recipeID] += cookedWeight * float64(min(times, maxCookedTimes))
+}
+
+// document is what the index knows about a recipe.
+type document struct {
+	name        string
+	workspaceID int
+	terms       []string
+}
+
+// Index holds the terms of every recipe, along with the number of recipes each term appears in.
+// Recipes of every workspace share the index, but are only ever recommended within their own workspace.
+// It is safe for concurrent use, and satisfies models.RecipeIndex to be refreshed as recipes change.
+type Index struct {
+	mu          sync.RWMutex
+	documents   map[int]*document
+	frequencies map[string]int
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{
+		documents:   make(map[int]*document),
+		frequencies: make(map[string]int),
+	}
+}
+
+// Put adds the recipe to the index, replacing what was indexed for it before.
+func (i *Index) Put(recipe *models.Recipe) {
+	doc := &document{
+		name:        recipe.Name,
+		workspaceID: recipe.WorkspaceID,
+		terms:       terms(recipe),
+	}
+	if doc.workspaceID == 0 {
+		doc.workspaceID = models.DefaultWorkspaceID
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(recipe.ID)
+	i.documents[recipe.ID] = doc
+	for _, term := range doc.terms {
+		i.frequencies[term]++
+	}
+}
+
+// Remove takes the recipe out of the index.
+func (i *Index) Remove(id int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+}
+
+func (i *Index) remove(id int) {
+	doc, exists := i.documents[id]
+	if !exists {
+		return
+	}
+
+	for _, term := range doc.terms {
+		if i.frequencies[term]--; i.frequencies[term] == 0 {
+			delete(i.frequencies, term)
+		}
+	}
+	delete(i.documents, id)
+}
+
+// Contains tells whether the recipe is in the index, as one of the recipes of the workspace.
+func (i *Index) Contains(workspaceID, id int) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc, exists := i.documents[id]
+	return exists && doc.workspaceID == workspaceID
+}
+
+// Similar returns up to limit recipes of the workspace, the most similar to the given one first.
+func (i *Index) Similar(workspaceID, id, limit int) []*Recommendation {
+	return i.Recommend(workspaceID, Preferences{id: 1}, limit)
+}
+
+// Recommend returns up to limit recipes of the workspace, the best match for the preferences first.
+// The preferences can include recipes of any workspace. Recipes that are part of the preferences,
+// whether liked or not, are never recommended, and neither are the ones that don't match at all.
+func (i *Index) Recommend(workspaceID int, preferences Preferences, limit int) []*Recommendation {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// Adding the weighted vectors up in a fixed order keeps the scores, and so the ranking, stable
+	ids := make([]int, 0, len(preferences))
+	for id := range preferences {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	profile := make(map[string]float64)
+	for _, id := range ids {
+		doc, exists := i.documents[id]
+		if !exists {
+			continue
+		}
+		for term, weight := range i.vector(doc) {
+			profile[term] += preferences[id] * weight
+		}
+	}
+
+	norm := 0.0
+	for _, weight := range profile {
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+
+	recommendations := []*Recommendation{}
+	if norm == 0 {
+		return recommendations
+	}
+
+	for id, doc := range i.documents {
+		if _, exists := preferences[id]; exists || doc.workspaceID != workspaceID {
+			continue
+		}
+
+		// Going through the terms in the order of the recipe keeps equal recipes scored equally
+		vector := i.vector(doc)
+		score := 0.0
+		for _, term := range doc.terms {
+			score += profile[term] * vector[term]
+		}
+		if score <= 0 {
+			continue
+		}
+
+		recommendations = append(recommendations, &Recommendation{
+			RecipeID: id,
+			Name:     doc.name,
+			Score:    score / norm,
+		})
+	}
+
+	sort.Slice(recommendations, func(a, b int) bool {
+		if recommendations[a].Score != recommendations[b].Score {
+			return recommendations[a].Score > recommendations[b].Score
+		}
+		return recommendations[a].RecipeID < recommendations[b].RecipeID
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations
+}
+
+// vector returns the unit TF-IDF vector of the document. Terms appear at most once in a recipe,
+// so a term weighs its smoothed inverse document frequency.
+func (i *Index) vector(doc *document) map[string]float64 {
+	vector := make(map[string]float64, len(doc.terms))
+	norm := 0.0
+	for _, term := range doc.terms {
+		idf := math.Log(float64(1+len(i.documents))/float64(1+i.frequencies[term])) + 1
+		vector[term] = idf
+		norm += idf * idf
+	}
+
+	norm = math.Sqrt(norm)
+	for term := range vector {
+		vector[term] /= norm
+	}
+	return vector
+}
+
+// terms returns the distinct ingredients and tags of the recipe, prefixed so that an ingredient
+// and a tag with the same name, such as "chocolate", are different terms.
+func terms(recipe *models.Recipe) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	add := func(prefix, name string) {
+		name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+		if name == "" || seen[prefix+name] {
+			return
+		}
+		seen[prefix+name] = true
+		terms = append(terms, prefix+name)
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient != nil && ingredient.Ingredient != nil {
+			add("ingredient:", ingredient.Name)
+		}
+	}
+	for _, tag := range recipe.Tags {
+		if tag != nil {
+			add("tag:", tag.Name)
+		}
+	}
+
+	return terms
+}
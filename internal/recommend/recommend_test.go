@@ -0,0 +1,119 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func recipe(id int, name string, tags []string, ingredients ...string) *models.Recipe {
+	r := &models.Recipe{ID: id, Name: name}
+	for _, ingredient := range ingredients {
+		r.Ingredients = append(r.Ingredients, &models.FullIngredient{Ingredient: &models.Ingredient{Name: ingredient}})
+	}
+	for _, tag := range tags {
+		r.Tags = append(r.Tags, &models.Tag{Name: tag})
+	}
+	return r
+}
+
+func newCatalogue() *Index {
+	index := NewIndex()
+	index.Put(recipe(1, "Pancakes", []string{"breakfast", "sweet"}, "flour", "milk", "eggs"))
+	index.Put(recipe(2, "Crepes", []string{"breakfast", "sweet"}, "flour", "milk", "eggs", "butter"))
+	index.Put(recipe(3, "Omelette", []string{"breakfast"}, "eggs", "cheese"))
+	index.Put(recipe(4, "Tomato soup", []string{"dinner"}, "tomatoes", "onion"))
+	index.Put(recipe(5, "Waffles", []string{"Breakfast", "sweet"}, "Flour", "milk", "eggs", "sugar"))
+	return index
+}
+
+// workspace is the one recipes without a workspace are in.
+const workspace = models.DefaultWorkspaceID
+
+func ids(recommendations []*Recommendation) []int {
+	ids := make([]int, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		ids = append(ids, recommendation.RecipeID)
+	}
+	return ids
+}
+
+func TestSimilar(t *testing.T) {
+	index := newCatalogue()
+
+	recommendations := index.Similar(workspace, 1, 10)
+	assert.Equal(t, []int{2, 5, 3}, ids(recommendations))
+	assert.Equal(t, "Crepes", recommendations[0].Name)
+	assert.Equal(t, recommendations[0].Score, recommendations[1].Score)
+	assert.Greater(t, recommendations[1].Score, recommendations[2].Score)
+	assert.LessOrEqual(t, recommendations[0].Score, 1.0+1e-9)
+
+	assert.Equal(t, []int{2}, ids(index.Similar(workspace, 1, 1)))
+	assert.Empty(t, index.Similar(workspace, 42, 10))
+}
+
+func TestIncrementalRefresh(t *testing.T) {
+	index := newCatalogue()
+
+	index.Remove(2)
+	assert.False(t, index.Contains(workspace, 2))
+	assert.Equal(t, []int{5, 3}, ids(index.Similar(workspace, 1, 10)))
+
+	// Updating a recipe replaces its terms rather than adding to them
+	index.Put(recipe(4, "Breakfast soup", []string{"breakfast"}, "tomatoes"))
+	assert.Contains(t, ids(index.Similar(workspace, 1, 10)), 4)
+	index.Put(recipe(4, "Tomato soup", []string{"dinner"}, "tomatoes", "onion"))
+	assert.NotContains(t, ids(index.Similar(workspace, 1, 10)), 4)
+	assert.Equal(t, newCatalogue().frequencies["tag:breakfast"]-1, index.frequencies["tag:breakfast"])
+
+	index.Remove(42)
+	assert.True(t, index.Contains(workspace, 1))
+}
+
+func TestWorkspaces(t *testing.T) {
+	index := newCatalogue()
+
+	other := recipe(6, "Flapjacks", []string{"breakfast", "sweet"}, "flour", "milk", "eggs")
+	other.WorkspaceID = 2
+	index.Put(other)
+
+	// The recipe only exists in its own workspace, and is only ever recommended there
+	assert.True(t, index.Contains(2, 6))
+	assert.False(t, index.Contains(workspace, 6))
+	assert.False(t, index.Contains(2, 1))
+	assert.NotContains(t, ids(index.Similar(workspace, 1, 10)), 6)
+	assert.Empty(t, index.Similar(2, 6, 10))
+
+	// Preferences from another workspace still count towards what is recommended in this one
+	assert.Equal(t, []int{6}, ids(index.Recommend(2, Preferences{1: 1}, 10)))
+	assert.Equal(t, []int{1, 2, 5, 3}, ids(index.Recommend(workspace, Preferences{6: 1}, 10)))
+}
+
+func TestPreferences(t *testing.T) {
+	preferences := Preferences{}
+	preferences.Rate(1, models.MaxRating)
+	preferences.Rate(2, models.MinRating)
+	preferences.Rate(3, 3)
+	preferences.Cook(4, 10)
+	preferences.Favorite(5)
+	preferences.Cook(5, 2)
+
+	assert.Equal(t, Preferences{1: 1, 2: -1, 3: 0, 4: 1, 5: 1.5}, preferences)
+}
+
+func TestRecommend(t *testing.T) {
+	index := newCatalogue()
+
+	// Recipes the user already knows are never recommended
+	preferences := Preferences{}
+	preferences.Favorite(1)
+	preferences.Rate(3, models.MinRating)
+	recommendations := index.Recommend(workspace, preferences, 10)
+	assert.Equal(t, []int{2, 5}, ids(recommendations))
+
+	// Nothing to go on means nothing to recommend
+	assert.Empty(t, index.Recommend(workspace, Preferences{}, 10))
+	assert.Empty(t, index.Recommend(workspace, Preferences{3: 0}, 10))
+	assert.Empty(t, index.Recommend(workspace, Preferences{42: 1}, 10))
+}
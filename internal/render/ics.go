@@ -0,0 +1,85 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// mealTimes are the local times meals are put at in calendars, along with how long they last.
+var mealTimes = map[string]struct {
+	hour, minute int
+	duration     time.Duration
+}{
+	"breakfast": {8, 0, 30 * time.Minute},
+	"lunch":     {12, 30, time.Hour},
+	"dinner":    {19, 0, time.Hour},
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// ICS writes the planned meals as an iCalendar (RFC 5545) calendar with one event per meal.
+// Events are in floating time, so that breakfast stays at 8 o'clock wherever the calendar is opened.
+func ICS(w io.Writer, plans []*models.MealPlan) error {
+	bw := bufio.NewWriter(w)
+
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//tasty-byte//meal plans//EN")
+	writeICSLine(bw, "CALSCALE:GREGORIAN")
+
+	for _, plan := range plans {
+		date, err := time.Parse(models.DateLayout, plan.Date)
+		if err != nil {
+			return err
+		}
+
+		meal := mealTimes[plan.Slot]
+		start := date.Add(time.Duration(meal.hour)*time.Hour + time.Duration(meal.minute)*time.Minute)
+
+		summary := fmt.Sprintf("%s: %s", strings.ToUpper(plan.Slot[:1])+plan.Slot[1:], plan.RecipeName)
+		if plan.Portions > 0 {
+			summary += fmt.Sprintf(" (%d portions)", plan.Portions)
+		}
+
+		writeICSLine(bw, "BEGIN:VEVENT")
+		writeICSLine(bw, fmt.Sprintf("UID:meal-plan-%d@tasty-byte", plan.ID))
+		writeICSLine(bw, "DTSTAMP:"+plan.CreatedAt.UTC().Format("20060102T150405Z"))
+		writeICSLine(bw, "DTSTART:"+start.Format("20060102T150405"))
+		writeICSLine(bw, "DTEND:"+start.Add(meal.duration).Format("20060102T150405"))
+		writeICSLine(bw, "SUMMARY:"+icsEscaper.Replace(summary))
+		if plan.Note != "" {
+			writeICSLine(bw, "DESCRIPTION:"+icsEscaper.Replace(plan.Note))
+		}
+		writeICSLine(bw, "END:VEVENT")
+	}
+
+	writeICSLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// writeICSLine writes a content line ending with CRLF, folded so that no line is longer than
+// 75 octets without splitting UTF-8 sequences.
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = 74
+	}
+	_, _ = w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
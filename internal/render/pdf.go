@@ -0,0 +1,162 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/units"
+)
+
+const (
+	pdfMargin     = 15.0
+	pdfLineHeight = 6.0
+	pdfFont       = "Helvetica"
+)
+
+// PDF writes the recipes as printable A4 recipe cards, each recipe starting on a new page.
+// Long ingredient lists and instructions continue on the following pages.
+func PDF(w io.Writer, recipes ...*models.Recipe) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.AliasNbPages("")
+
+	// The core fonts only cover cp1252, so UTF-8 text is translated before being written
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if len(recipes) == 1 {
+		pdf.SetTitle(recipes[0].Name, true)
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont(pdfFont, "I", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+
+	for _, recipe := range recipes {
+		pdf.AddPage()
+		writeCard(pdf, tr, recipe)
+	}
+
+	if len(recipes) == 0 {
+		pdf.AddPage()
+	}
+
+	return pdf.Output(w)
+}
+
+func writeCard(pdf *fpdf.Fpdf, tr func(string) string, recipe *models.Recipe) {
+	pageWidth, _ := pdf.GetPageSize()
+	width := pageWidth - 2*pdfMargin
+
+	pdf.SetFont(pdfFont, "B", 20)
+	pdf.MultiCell(width, 9, tr(recipe.Name), "", "L", false)
+
+	if recipe.Description != "" {
+		pdf.Ln(1)
+		pdf.SetFont(pdfFont, "I", 11)
+		pdf.MultiCell(width, pdfLineHeight, tr(recipe.Description), "", "L", false)
+	}
+
+	var details []string
+	if recipe.PreparationTime != "" {
+		details = append(details, "Preparation: "+recipe.PreparationTime)
+	}
+	if recipe.CookingTime != "" {
+		details = append(details, "Cooking: "+recipe.CookingTime)
+	}
+	if recipe.Portions > 0 {
+		details = append(details, "Portions: "+strconv.Itoa(recipe.Portions))
+	}
+	if len(details) > 0 {
+		pdf.Ln(2)
+		pdf.SetFont(pdfFont, "", 10)
+		pdf.MultiCell(width, pdfLineHeight, tr(strings.Join(details, "   |   ")), "TB", "L", false)
+	}
+
+	if len(recipe.Ingredients) > 0 {
+		writeHeading(pdf, "Ingredients")
+		writeIngredientTable(pdf, tr, width, recipe.Ingredients)
+	}
+
+	if steps := recipe.StepTexts(); len(steps) > 0 {
+		writeHeading(pdf, "Instructions")
+		pdf.SetFont(pdfFont, "", 11)
+		for i, step := range steps {
+			pdf.CellFormat(10, pdfLineHeight, fmt.Sprintf("%d.", i+1), "", 0, "R", false, 0, "")
+			pdf.SetX(pdf.GetX() + 2)
+			pdf.MultiCell(width-12, pdfLineHeight, tr(step), "", "L", false)
+			pdf.Ln(1)
+		}
+	}
+
+	if len(recipe.Tags) > 0 {
+		names := make([]string, 0, len(recipe.Tags))
+		for _, tag := range recipe.Tags {
+			names = append(names, tag.Name)
+		}
+		pdf.Ln(4)
+		pdf.SetFont(pdfFont, "I", 9)
+		pdf.MultiCell(width, 5, tr("Tags: "+strings.Join(names, ", ")), "", "L", false)
+	}
+}
+
+func writeHeading(pdf *fpdf.Fpdf, text string) {
+	pdf.Ln(5)
+	pdf.SetFont(pdfFont, "B", 14)
+	pdf.CellFormat(0, 8, text, "", 1, "L", false, 0, "")
+	pdf.Ln(1)
+}
+
+// writeIngredientTable writes the ingredients as a two columns table, repeating the header row
+// whenever the table continues on a new page.
+func writeIngredientTable(pdf *fpdf.Fpdf, tr func(string) string, width float64, ingredients []*models.FullIngredient) {
+	const amountWidth = 40.0
+	nameWidth := width - amountWidth
+	_, pageHeight := pdf.GetPageSize()
+
+	header := func() {
+		pdf.SetFont(pdfFont, "B", 10)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(amountWidth, 7, "Amount", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(nameWidth, 7, "Ingredient", "1", 1, "L", true, 0, "")
+		pdf.SetFont(pdfFont, "", 10)
+	}
+	header()
+
+	for _, ingredient := range ingredients {
+		var amount []string
+		if ingredient.Quantity > 0 {
+			amount = append(amount, units.FormatQuantity(ingredient.Quantity))
+		}
+		if ingredient.Unit != "" {
+			amount = append(amount, ingredient.Unit)
+		}
+
+		var name string
+		if ingredient.Ingredient != nil {
+			name = tr(ingredient.Name)
+		}
+		lines := pdf.SplitLines([]byte(name), nameWidth-2)
+		height := float64(max(len(lines), 1)) * pdfLineHeight
+
+		if pdf.GetY()+height > pageHeight-pdfMargin {
+			pdf.AddPage()
+			header()
+		}
+
+		x, y := pdf.GetXY()
+		pdf.Rect(x, y, amountWidth, height, "D")
+		pdf.Rect(x+amountWidth, y, nameWidth, height, "D")
+		pdf.CellFormat(amountWidth, pdfLineHeight, tr(strings.Join(amount, " ")), "", 0, "L", false, 0, "")
+		pdf.MultiCell(nameWidth, pdfLineHeight, name, "", "L", false)
+		pdf.SetXY(x, y+height)
+	}
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+var functions = template.FuncMap{
+	"upper": strings.ToUpper,
+	"underline": func(text string) string {
+		return strings.Repeat("=", len([]rune(text)))
+	},
+	"tagNames": func(tags []*models.Tag) string {
+		names := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return strings.Join(names, ", ")
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(functions).Parse(`
+{{- range $i, $recipe := . -}}
+{{- if $i }}
+---
+
+{{ end -}}
+# {{ .Name }}
+{{ if .Description }}
+_{{ .Description }}_
+{{ end }}
+{{ if .PreparationTime }}- **Preparation:** {{ .PreparationTime }}
+{{ end -}}
+{{ if .CookingTime }}- **Cooking:** {{ .CookingTime }}
+{{ end -}}
+{{ if .Portions }}- **Portions:** {{ .Portions }}
+{{ end -}}
+{{ if .Ingredients }}
+## Ingredients
+
+{{ range .Ingredients }}- {{ . }}
+{{ end -}}
+{{ end -}}
+{{ with .StepTexts }}
+## Instructions
+
+{{ range $j, $step := . }}{{ inc $j }}. {{ $step }}
+{{ end -}}
+{{ end -}}
+{{ if .Tags }}
+_Tags: {{ tagNames .Tags }}_
+{{ end -}}
+{{ end -}}
+`))
+
+var textTemplate = template.Must(template.New("text").Funcs(functions).Parse(`
+{{- range $i, $recipe := . -}}
+{{- if $i }}
+----------------------------------------
+
+{{ end -}}
+{{ upper .Name }}
+{{ underline .Name }}
+{{ if .Description }}
+{{ .Description }}
+{{ end }}
+{{ if .PreparationTime }}Preparation: {{ .PreparationTime }}
+{{ end -}}
+{{ if .CookingTime }}Cooking: {{ .CookingTime }}
+{{ end -}}
+{{ if .Portions }}Portions: {{ .Portions }}
+{{ end -}}
+{{ if .Ingredients }}
+INGREDIENTS
+{{ range .Ingredients }}  * {{ . }}
+{{ end -}}
+{{ end -}}
+{{ with .StepTexts }}
+INSTRUCTIONS
+{{ range $j, $step := . }}  {{ inc $j }}. {{ $step }}
+{{ end -}}
+{{ end -}}
+{{ if .Tags }}
+Tags: {{ tagNames .Tags }}
+{{ end -}}
+{{ end -}}
+`))
+
+// Markdown writes the recipes as printable Markdown cards, separated by horizontal rules.
+func Markdown(w io.Writer, recipes ...*models.Recipe) error {
+	return markdownTemplate.Execute(w, recipes)
+}
+
+// Text writes the recipes as plain text.
+func Text(w io.Writer, recipes ...*models.Recipe) error {
+	return textTemplate.Execute(w, recipes)
+}
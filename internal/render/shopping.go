@@ -0,0 +1,46 @@
+package render
+
+import (
+	"io"
+	"text/template"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+var shoppingMarkdownTemplate = template.Must(template.New("shopping-markdown").Funcs(functions).Parse(`
+{{- "" -}}
+# {{ .Name }}
+{{ if .Recipes }}
+_For: {{ range $i, $recipe := .Recipes }}{{ if $i }}, {{ end }}{{ .Name }}{{ if .Portions }} ({{ .Portions }} portions){{ end }}{{ end }}_
+{{ end -}}
+{{ range .ByAisle }}
+## {{ .Name }}
+
+{{ range .Items }}- [{{ if .Checked }}x{{ else }} {{ end }}] {{ . }}
+{{ end -}}
+{{ end -}}
+`))
+
+var shoppingTextTemplate = template.Must(template.New("shopping-text").Funcs(functions).Parse(`
+{{- "" -}}
+{{ upper .Name }}
+{{ underline .Name }}
+{{ if .Recipes }}
+For: {{ range $i, $recipe := .Recipes }}{{ if $i }}, {{ end }}{{ .Name }}{{ if .Portions }} ({{ .Portions }} portions){{ end }}{{ end }}
+{{ end -}}
+{{ range .ByAisle }}
+{{ upper .Name }}
+{{ range .Items }}  [{{ if .Checked }}x{{ else }} {{ end }}] {{ . }}
+{{ end -}}
+{{ end -}}
+`))
+
+// ShoppingListMarkdown writes the shopping list as a Markdown task list, one section per aisle.
+func ShoppingListMarkdown(w io.Writer, list *models.ShoppingList) error {
+	return shoppingMarkdownTemplate.Execute(w, list)
+}
+
+// ShoppingListText writes the shopping list as plain text, one section per aisle.
+func ShoppingListText(w io.Writer, list *models.ShoppingList) error {
+	return shoppingTextTemplate.Execute(w, list)
+}
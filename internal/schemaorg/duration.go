@@ -0,0 +1,99 @@
+package schemaorg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidDuration = errors.New("schemaorg: invalid ISO 8601 duration")
+
+	isoDurationRx   = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	shortDurationRx = regexp.MustCompile(`(\d+)\s*([hms])`)
+)
+
+// ParseISODuration parses the ISO 8601 durations used by schema.org, e.g. "PT1H30M" or "P1DT2H".
+func ParseISODuration(value string) (time.Duration, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	match := isoDurationRx.FindStringSubmatch(value)
+	if match == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+	}
+
+	var total time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+1] == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+		}
+		total += time.Duration(amount * float64(unit))
+	}
+
+	return total, nil
+}
+
+// FormatISODuration formats a duration the way schema.org expects it, e.g. "PT1H30M".
+func FormatISODuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("PT%dH%dM", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("PT%dH", hours)
+	default:
+		return fmt.Sprintf("PT%dM", minutes)
+	}
+}
+
+// FormatDuration formats a duration in the short form recipes are stored with, e.g. "1h 30m" or "45m".
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// ParseDuration parses the short form recipes are stored with, e.g. "1h 30m", "45m" or "1h".
+// It returns false when the text contains no recognisable duration.
+func ParseDuration(value string) (time.Duration, bool) {
+	matches := shortDurationRx.FindAllStringSubmatch(strings.ToLower(value), -1)
+	if matches == nil {
+		return 0, false
+	}
+
+	var total time.Duration
+	for _, match := range matches {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, false
+		}
+		switch match[2] {
+		case "h":
+			total += time.Duration(amount) * time.Hour
+		case "m":
+			total += time.Duration(amount) * time.Minute
+		case "s":
+			total += time.Duration(amount) * time.Second
+		}
+	}
+
+	return total, true
+}
@@ -0,0 +1,125 @@
+package schemaorg
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+const Context = "https://schema.org"
+
+// Recipe is the schema.org representation of a recipe, see https://schema.org/Recipe.
+type Recipe struct {
+	Context            string       `json:"@context,omitempty"`
+	Type               string       `json:"@type"`
+	Identifier         string       `json:"identifier,omitempty"`
+	Name               string       `json:"name"`
+	Description        string       `json:"description,omitempty"`
+	DateCreated        string       `json:"dateCreated,omitempty"`
+	PrepTime           string       `json:"prepTime,omitempty"`
+	CookTime           string       `json:"cookTime,omitempty"`
+	TotalTime          string       `json:"totalTime,omitempty"`
+	RecipeYield        string       `json:"recipeYield,omitempty"`
+	RecipeIngredient   []string     `json:"recipeIngredient,omitempty"`
+	RecipeInstructions []*HowToStep `json:"recipeInstructions,omitempty"`
+	Keywords           string       `json:"keywords,omitempty"`
+	SuitableForDiet    []string     `json:"suitableForDiet,omitempty"`
+}
+
+type HowToStep struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
+// diets maps the diets of recipes to the schema.org RestrictedDiet enumeration.
+var diets = map[string]string{
+	"halal":      "https://schema.org/HalalDiet",
+	"vegan":      "https://schema.org/VeganDiet",
+	"vegetarian": "https://schema.org/VegetarianDiet",
+}
+
+// Graph wraps several recipes into a single JSON-LD document.
+type Graph struct {
+	Context string    `json:"@context"`
+	Graph   []*Recipe `json:"@graph"`
+}
+
+// NewRecipe converts a recipe into a standalone schema.org Recipe document.
+func NewRecipe(recipe *models.Recipe) *Recipe {
+	result := &Recipe{
+		Context:     Context,
+		Type:        "Recipe",
+		Name:        recipe.Name,
+		Description: recipe.Description,
+	}
+
+	if recipe.ID > 0 {
+		result.Identifier = strconv.Itoa(recipe.ID)
+	}
+
+	if !recipe.CreatedAt.IsZero() {
+		result.DateCreated = recipe.CreatedAt.UTC().Format("2006-01-02")
+	}
+
+	prepTime, hasPrepTime := ParseDuration(recipe.PreparationTime)
+	if hasPrepTime {
+		result.PrepTime = FormatISODuration(prepTime)
+	}
+
+	cookTime, hasCookTime := ParseDuration(recipe.CookingTime)
+	if hasCookTime {
+		result.CookTime = FormatISODuration(cookTime)
+	}
+
+	if hasPrepTime || hasCookTime {
+		result.TotalTime = FormatISODuration(prepTime + cookTime)
+	}
+
+	if recipe.Portions > 0 {
+		result.RecipeYield = strconv.Itoa(recipe.Portions)
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		result.RecipeIngredient = append(result.RecipeIngredient, ingredient.String())
+	}
+
+	for i, step := range recipe.StepTexts() {
+		result.RecipeInstructions = append(result.RecipeInstructions, &HowToStep{
+			Type:     "HowToStep",
+			Position: i + 1,
+			Text:     step,
+		})
+	}
+
+	keywords := make([]string, 0, len(recipe.Tags))
+	for _, tag := range recipe.Tags {
+		keywords = append(keywords, tag.Name)
+	}
+	result.Keywords = strings.Join(keywords, ", ")
+
+	for _, diet := range recipe.Diets {
+		if value, ok := diets[diet]; ok {
+			result.SuitableForDiet = append(result.SuitableForDiet, value)
+		}
+	}
+
+	return result
+}
+
+// NewGraph converts a list of recipes into a JSON-LD "@graph" document.
+func NewGraph(recipes []*models.Recipe) *Graph {
+	graph := &Graph{
+		Context: Context,
+		Graph:   make([]*Recipe, 0, len(recipes)),
+	}
+
+	for _, recipe := range recipes {
+		result := NewRecipe(recipe)
+		result.Context = ""
+		graph.Graph = append(graph.Graph, result)
+	}
+
+	return graph
+}
@@ -0,0 +1,226 @@
+package schemaorg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+var (
+	ErrNoRecipe = errors.New("schemaorg: no Recipe found")
+
+	jsonLDScriptRx = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+	integerRx      = regexp.MustCompile(`\d+`)
+	tagsRx         = regexp.MustCompile(`<[^>]*>`)
+)
+
+// ParseHTML extracts the first schema.org Recipe embedded as JSON-LD in an HTML page.
+// Script blocks that are not valid JSON are skipped, as pages often carry unrelated broken markup.
+func ParseHTML(page []byte) (*models.Recipe, error) {
+	for _, match := range jsonLDScriptRx.FindAllSubmatch(page, -1) {
+		recipe, err := ParseJSONLD(match[1])
+		if err == nil {
+			return recipe, nil
+		}
+	}
+
+	return nil, ErrNoRecipe
+}
+
+// ParseJSONLD extracts the first schema.org Recipe from a JSON-LD document. The recipe may be the
+// document itself, part of a top-level array or nested in an "@graph".
+func ParseJSONLD(document []byte) (*models.Recipe, error) {
+	var doc any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("schemaorg: invalid JSON-LD: %w", err)
+	}
+
+	node := findRecipe(doc)
+	if node == nil {
+		return nil, ErrNoRecipe
+	}
+
+	return toRecipe(node)
+}
+
+func findRecipe(doc any) map[string]any {
+	switch node := doc.(type) {
+	case []any:
+		for _, item := range node {
+			if recipe := findRecipe(item); recipe != nil {
+				return recipe
+			}
+		}
+	case map[string]any:
+		if isRecipe(node["@type"]) {
+			return node
+		}
+		for _, key := range []string{"@graph", "mainEntity", "mainEntityOfPage"} {
+			if recipe := findRecipe(node[key]); recipe != nil {
+				return recipe
+			}
+		}
+	}
+
+	return nil
+}
+
+func isRecipe(nodeType any) bool {
+	switch t := nodeType.(type) {
+	case string:
+		return t == "Recipe" || strings.HasSuffix(t, "schema.org/Recipe") || t == "schema:Recipe"
+	case []any:
+		for _, item := range t {
+			if isRecipe(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func toRecipe(node map[string]any) (*models.Recipe, error) {
+	recipe := &models.Recipe{
+		Name:         text(node["name"]),
+		Description:  text(node["description"]),
+		Instructions: instructions(node["recipeInstructions"]),
+		Portions:     portions(node["recipeYield"]),
+	}
+
+	if recipe.Name == "" {
+		return nil, fmt.Errorf("%w: the recipe has no name", ErrNoRecipe)
+	}
+
+	if value := text(node["prepTime"]); value != "" {
+		prepTime, err := ParseISODuration(value)
+		if err != nil {
+			return nil, err
+		}
+		recipe.PreparationTime = FormatDuration(prepTime)
+	}
+
+	if value := text(node["cookTime"]); value != "" {
+		cookTime, err := ParseISODuration(value)
+		if err != nil {
+			return nil, err
+		}
+		recipe.CookingTime = FormatDuration(cookTime)
+	}
+
+	for _, line := range texts(node["recipeIngredient"]) {
+		ingredient := ParseIngredientLine(line)
+		if ingredient.Name == "" {
+			continue
+		}
+		recipe.Ingredients = append(recipe.Ingredients, ingredient)
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range []string{"recipeCategory", "recipeCuisine", "keywords"} {
+		for _, value := range texts(node[key]) {
+			for _, name := range strings.Split(value, ",") {
+				name = strings.ToLower(strings.TrimSpace(name))
+				if name == "" || seen[name] {
+					continue
+				}
+				seen[name] = true
+				recipe.Tags = append(recipe.Tags, &models.Tag{Name: name})
+			}
+		}
+	}
+
+	return recipe, nil
+}
+
+// text returns a cleaned-up string for a JSON-LD value, which may be given as a plain
+// string, a number, a single-item array or an object with a "name"/"text" property.
+func text(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.Join(strings.Fields(html.UnescapeString(tagsRx.ReplaceAllString(v, " "))), " ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		if len(v) > 0 {
+			return text(v[0])
+		}
+	case map[string]any:
+		if name := text(v["text"]); name != "" {
+			return name
+		}
+		return text(v["name"])
+	}
+
+	return ""
+}
+
+func texts(value any) []string {
+	var values []string
+
+	items, ok := value.([]any)
+	if !ok {
+		items = []any{value}
+	}
+
+	for _, item := range items {
+		if t := text(item); t != "" {
+			values = append(values, t)
+		}
+	}
+
+	return values
+}
+
+// instructions flattens recipeInstructions, which may be plain text, a list of strings,
+// HowToStep objects or HowToSection objects grouping further steps, into one step per line.
+func instructions(value any) string {
+	var steps []string
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case string:
+			for _, line := range strings.Split(v, "\n") {
+				if step := text(line); step != "" {
+					steps = append(steps, step)
+				}
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		case map[string]any:
+			if elements, ok := v["itemListElement"]; ok {
+				walk(elements)
+				return
+			}
+			if step := text(v); step != "" {
+				steps = append(steps, step)
+			}
+		}
+	}
+	walk(value)
+
+	return strings.Join(steps, "\n")
+}
+
+// portions takes the first whole number out of recipeYield, e.g. "4 servings" -> 4.
+func portions(value any) int {
+	for _, yield := range texts(value) {
+		if match := integerRx.FindString(yield); match != "" {
+			portions, err := strconv.Atoi(match)
+			if err == nil {
+				return portions
+			}
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,131 @@
+package schemaorg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIngredientLine(t *testing.T) {
+	testCases := []struct {
+		line     string
+		name     string
+		quantity float64
+		unit     string
+	}{
+		{line: "1 1/2 cups flour", name: "flour", quantity: 1.5, unit: "cup"},
+		{line: "2 tablespoons olive oil", name: "olive oil", quantity: 2, unit: "tbsp"},
+		{line: "½ tsp salt", name: "salt", quantity: 0.5, unit: "tsp"},
+		{line: "1½ cups of milk", name: "milk", quantity: 1.5, unit: "cup"},
+		{line: "200g dark chocolate, chopped", name: "dark chocolate", quantity: 200, unit: "g"},
+		{line: "3/4 cup sugar", name: "sugar", quantity: 0.75, unit: "cup"},
+		{line: "2-3 cloves garlic", name: "garlic", quantity: 2, unit: "clove"},
+		{line: "1 (14 oz) can diced tomatoes", name: "diced tomatoes", quantity: 1, unit: "can"},
+		{line: "8 fl oz cream", name: "cream", quantity: 8, unit: "fl oz"},
+		{line: "3 large eggs", name: "large eggs", quantity: 3},
+		{line: "1/3 cup water", name: "water", quantity: 0.33, unit: "cup"},
+		{line: "Salt and pepper to taste", name: "Salt and pepper to taste"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			ingredient := ParseIngredientLine(tc.line)
+			assert.Equal(t, tc.name, ingredient.Name)
+			assert.Equal(t, tc.quantity, ingredient.Quantity)
+			assert.Equal(t, tc.unit, ingredient.Unit)
+		})
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+		short    string
+		valid    bool
+	}{
+		{value: "PT30M", expected: 30 * time.Minute, short: "30m", valid: true},
+		{value: "PT1H30M", expected: 90 * time.Minute, short: "1h 30m", valid: true},
+		{value: "PT2H", expected: 2 * time.Hour, short: "2h", valid: true},
+		{value: "P1DT1H", expected: 25 * time.Hour, short: "25h", valid: true},
+		{value: "PT", valid: false},
+		{value: "30 minutes", valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			d, err := ParseISODuration(tc.value)
+			if !tc.valid {
+				assert.ErrorIs(t, err, ErrInvalidDuration)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, d)
+			assert.Equal(t, tc.short, FormatDuration(d))
+		})
+	}
+}
+
+const testJSONLD = `{
+	"@context": "https://schema.org",
+	"@graph": [
+		{"@type": "WebPage", "name": "Grandma's blog"},
+		{
+			"@type": ["Recipe", "NewsArticle"],
+			"name": "Banana Bread",
+			"description": "Moist &amp; easy",
+			"prepTime": "PT15M",
+			"cookTime": "PT1H",
+			"recipeYield": ["8", "8 slices"],
+			"recipeCategory": "Dessert",
+			"keywords": "baking, banana, Dessert",
+			"recipeIngredient": ["3 ripe bananas", "1 1/2 cups flour", "1/2 cup sugar"],
+			"recipeInstructions": [
+				{"@type": "HowToSection", "name": "Batter", "itemListElement": [
+					{"@type": "HowToStep", "text": "Mash the bananas."},
+					{"@type": "HowToStep", "text": "Mix in the flour and sugar."}
+				]},
+				{"@type": "HowToStep", "text": "Bake for an hour."}
+			]
+		}
+	]
+}`
+
+func TestParseJSONLD(t *testing.T) {
+	recipe, err := ParseJSONLD([]byte(testJSONLD))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Banana Bread", recipe.Name)
+	assert.Equal(t, "Moist & easy", recipe.Description)
+	assert.Equal(t, "15m", recipe.PreparationTime)
+	assert.Equal(t, "1h", recipe.CookingTime)
+	assert.Equal(t, 8, recipe.Portions)
+	assert.Equal(t, "Mash the bananas.\nMix in the flour and sugar.\nBake for an hour.", recipe.Instructions)
+
+	assert.Len(t, recipe.Ingredients, 3)
+	assert.Equal(t, "flour", recipe.Ingredients[1].Name)
+	assert.Equal(t, 1.5, recipe.Ingredients[1].Quantity)
+	assert.Equal(t, "cup", recipe.Ingredients[1].Unit)
+
+	var tags []string
+	for _, tag := range recipe.Tags {
+		tags = append(tags, tag.Name)
+	}
+	assert.Equal(t, []string{"dessert", "baking", "banana"}, tags)
+}
+
+func TestParseHTML(t *testing.T) {
+	page := `<html><head>
+		<script type="application/ld+json">{ not json </script>
+		<script type="application/ld+json">` + testJSONLD + `</script>
+	</head><body>...</body></html>`
+
+	recipe, err := ParseHTML([]byte(page))
+	assert.NoError(t, err)
+	assert.Equal(t, "Banana Bread", recipe.Name)
+
+	_, err = ParseHTML([]byte(`<html><body>No recipe here</body></html>`))
+	assert.ErrorIs(t, err, ErrNoRecipe)
+}
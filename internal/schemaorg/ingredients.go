@@ -0,0 +1,106 @@
+package schemaorg
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/units"
+)
+
+var (
+	vulgarFractions = strings.NewReplacer(
+		"½", " 1/2", "⅓", " 1/3", "⅔", " 2/3", "¼", " 1/4", "¾", " 3/4",
+		"⅕", " 1/5", "⅖", " 2/5", "⅗", " 3/5", "⅘", " 4/5", "⅙", " 1/6", "⅚", " 5/6",
+		"⅛", " 1/8", "⅜", " 3/8", "⅝", " 5/8", "⅞", " 7/8", "⁄", "/",
+	)
+	parenthesesRx = regexp.MustCompile(`\([^)]*\)`)
+	// quantityRx matches a leading quantity such as "2", "1.5", "1 1/2", "3/4" or a range like "2-3" / "2 to 3",
+	// optionally glued to its unit as in "200g".
+	quantityRx = regexp.MustCompile(`^(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?)(?:\s*(?:-|–|to)\s*(?:\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?))?(?:\s+|$|([a-zA-Z]))`)
+)
+
+// ParseIngredientLine turns a free-text ingredient line, e.g. "1 1/2 cups all-purpose flour, sifted",
+// into a FullIngredient (name "all-purpose flour", quantity 1.5, unit "cup").
+// Lines without a leading quantity, such as "salt to taste", keep the whole text as the name.
+func ParseIngredientLine(line string) *models.FullIngredient {
+	line = vulgarFractions.Replace(line)
+	line = parenthesesRx.ReplaceAllString(line, " ")
+	line = strings.Join(strings.Fields(line), " ")
+
+	ingredient := &models.FullIngredient{
+		Ingredient: &models.Ingredient{},
+	}
+
+	match := quantityRx.FindStringSubmatchIndex(line)
+	if match == nil {
+		ingredient.Name = ingredientName(line)
+		return ingredient
+	}
+
+	ingredient.Quantity = parseQuantity(line[match[2]:match[3]])
+
+	// When the unit is glued to the quantity ("200g"), the regexp consumed its first letter
+	rest := line[match[1]:]
+	if match[4] != -1 {
+		rest = line[match[4]:]
+	}
+
+	rest = strings.TrimSpace(rest)
+	if unit, remainder, ok := leadingUnit(rest); ok {
+		ingredient.Unit = unit
+		rest = remainder
+	}
+
+	rest = strings.TrimPrefix(rest, "of ")
+	ingredient.Name = ingredientName(rest)
+
+	return ingredient
+}
+
+// leadingUnit recognises a unit at the start of the text, trying two-word units such as "fl oz" first.
+// A unit is only accepted when it is followed by a name, so "2 cups" alone keeps "cups" as the name.
+func leadingUnit(text string) (string, string, bool) {
+	words := strings.Fields(text)
+	for n := 2; n >= 1; n-- {
+		if len(words) <= n {
+			continue
+		}
+
+		if unit, ok := units.Normalize(strings.Join(words[:n], " ")); ok {
+			return unit, strings.Join(words[n:], " "), true
+		}
+	}
+
+	return "", text, false
+}
+
+// parseQuantity parses "2", "1.5", "1,5", "3/4" and "1 1/2", rounding to the two decimals stored in the database.
+func parseQuantity(text string) float64 {
+	var total float64
+	for _, part := range strings.Fields(text) {
+		if numerator, denominator, isFraction := strings.Cut(part, "/"); isFraction {
+			n, err1 := strconv.ParseFloat(numerator, 64)
+			d, err2 := strconv.ParseFloat(denominator, 64)
+			if err1 == nil && err2 == nil && d != 0 {
+				total += n / d
+			}
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err == nil {
+			total += value
+		}
+	}
+
+	return math.Round(total*100) / 100
+}
+
+// ingredientName drops preparation notes after the first comma, e.g. "onions, finely chopped" -> "onions".
+func ingredientName(text string) string {
+	name, _, _ := strings.Cut(text, ",")
+	return strings.TrimSpace(name)
+}
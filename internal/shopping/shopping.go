@@ -0,0 +1,177 @@
+// Package shopping turns recipes into shopping lists: identical ingredients are merged, converting
+// their units when needed, and the items are sorted the way a supermarket is walked through.
+package shopping
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+	"github.com/vladComan0/tasty-byte/internal/units"
+)
+
+//go:embed aisles.csv
+var bundled []byte
+
+// Other is the aisle of the ingredients that aren't in the aisle table.
+const Other = "other"
+
+// Aisles lists the aisles in the order they come up in a store, Other being last.
+var Aisles = []string{
+	"produce",
+	"bakery",
+	"dairy & eggs",
+	"meat & seafood",
+	"baking",
+	"spices",
+	"pantry",
+	"oils & condiments",
+	"frozen",
+	"beverages",
+	Other,
+}
+
+var aisles = mustParseAisles(bundled)
+
+func mustParseAisles(data []byte) map[string]string {
+	parsed, err := ParseAisles(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// ParseAisles reads the aisle of every ingredient from a CSV file with the columns of aisles.csv.
+func ParseAisles(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if header[0] != "name" || header[1] != "aisle" {
+		return nil, fmt.Errorf("shopping: unexpected columns %q", header)
+	}
+
+	results := make(map[string]string)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		name := strings.ToLower(strings.TrimSpace(record[0]))
+		aisle := strings.ToLower(strings.TrimSpace(record[1]))
+		if name == "" || aisleRank(aisle) == len(Aisles) {
+			return nil, fmt.Errorf("shopping: line %d: invalid entry %q", line, record)
+		}
+		results[name] = aisle
+	}
+}
+
+// Aisle returns the aisle an ingredient is found in, trying its singular form too.
+func Aisle(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	candidates := []string{name}
+	if singular, ok := strings.CutSuffix(name, "es"); ok {
+		candidates = append(candidates, singular)
+	}
+	if singular, ok := strings.CutSuffix(name, "s"); ok {
+		candidates = append(candidates, singular)
+	}
+
+	for _, candidate := range candidates {
+		if aisle, ok := aisles[candidate]; ok {
+			return aisle
+		}
+	}
+	return Other
+}
+
+func aisleRank(aisle string) int {
+	for i, known := range Aisles {
+		if known == aisle {
+			return i
+		}
+	}
+	return len(Aisles)
+}
+
+// Aggregate merges the ingredients of the recipes into shopping items sorted by aisle and name.
+// Amounts of the same ingredient are added up in the larger of their units when they can be
+// converted into each other, and listed separately otherwise, e.g. "2 cloves" and "1 tbsp" of garlic.
+func Aggregate(recipes ...*models.Recipe) []*models.ShoppingItem {
+	items := []*models.ShoppingItem{}
+	byName := make(map[string][]*models.ShoppingItem)
+
+	for _, recipe := range recipes {
+		for _, ingredient := range recipe.Ingredients {
+			if ingredient == nil || ingredient.Ingredient == nil {
+				continue
+			}
+
+			key := strings.ToLower(strings.TrimSpace(ingredient.Name))
+			if merge(byName[key], ingredient) {
+				continue
+			}
+
+			item := &models.ShoppingItem{
+				IngredientID: ingredient.ID,
+				Name:         ingredient.Name,
+				Quantity:     ingredient.Quantity,
+				Unit:         ingredient.Unit,
+				Aisle:        Aisle(ingredient.Name),
+			}
+			byName[key] = append(byName[key], item)
+			items = append(items, item)
+		}
+	}
+
+	for _, item := range items {
+		item.Quantity = math.Round(item.Quantity*100) / 100
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if rankI, rankJ := aisleRank(items[i].Aisle), aisleRank(items[j].Aisle); rankI != rankJ {
+			return rankI < rankJ
+		}
+		return strings.ToLower(items[i].Name) < strings.ToLower(items[j].Name)
+	})
+
+	return items
+}
+
+// merge adds the ingredient to the first item whose unit it converts to, reporting whether there was one.
+func merge(items []*models.ShoppingItem, ingredient *models.FullIngredient) bool {
+	for _, item := range items {
+		quantity, ok := units.Convert(ingredient.Quantity, ingredient.Unit, item.Unit)
+		if !ok {
+			continue
+		}
+
+		// Switch to the larger unit so that 1 cup and 2 tbsp make 1.13 cup rather than 18 tbsp
+		if factor, _ := units.Convert(1, ingredient.Unit, item.Unit); factor > 1 {
+			item.Quantity, _ = units.Convert(item.Quantity, item.Unit, ingredient.Unit)
+			item.Unit = ingredient.Unit
+			item.Quantity += ingredient.Quantity
+			return true
+		}
+
+		item.Quantity += quantity
+		return true
+	}
+	return false
+}
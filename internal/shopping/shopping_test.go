@@ -0,0 +1,68 @@
+package shopping
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func ingredient(id int, name string, quantity float64, unit string) *models.FullIngredient {
+	return &models.FullIngredient{
+		Ingredient: &models.Ingredient{ID: id, Name: name},
+		Quantity:   quantity,
+		Unit:       unit,
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	pancakes := &models.Recipe{
+		Ingredients: []*models.FullIngredient{
+			ingredient(1, "flour", 200, "g"),
+			ingredient(2, "milk", 1, "cup"),
+			ingredient(3, "eggs", 2, ""),
+			ingredient(4, "saffron", 1, "pinch"),
+		},
+	}
+	bread := &models.Recipe{
+		Ingredients: []*models.FullIngredient{
+			ingredient(1, "Flour", 0.5, "kg"),
+			ingredient(2, "milk", 2, "tbsp"),
+			ingredient(3, "eggs", 1, "piece"),
+		},
+	}
+
+	items := Aggregate(pancakes, bread)
+
+	type line struct {
+		name, unit, aisle string
+		quantity          float64
+	}
+	var lines []line
+	for _, item := range items {
+		lines = append(lines, line{item.Name, item.Unit, item.Aisle, item.Quantity})
+	}
+
+	assert.Equal(t, []line{
+		{"eggs", "", "dairy & eggs", 2},
+		{"eggs", "piece", "dairy & eggs", 1},
+		{"milk", "cup", "dairy & eggs", 1.13},
+		{"flour", "kg", "baking", 0.7},
+		{"saffron", "pinch", Other, 1},
+	}, lines)
+}
+
+func TestAisle(t *testing.T) {
+	assert.Equal(t, "produce", Aisle("Tomatoes"))
+	assert.Equal(t, "dairy & eggs", Aisle("eggs"))
+	assert.Equal(t, Other, Aisle("dragon fruit"))
+}
+
+func TestParseAisles(t *testing.T) {
+	_, err := ParseAisles(strings.NewReader("name,aisle\nflour,baking\n"))
+	assert.NoError(t, err)
+
+	_, err = ParseAisles(strings.NewReader("name,aisle\nflour,attic\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+// Package similarity finds recipes that are likely duplicates of each other, comparing their
+// normalized names, their sets of ingredients and the shingles of their instructions.
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+// DefaultThreshold is the score from which two recipes are considered likely duplicates.
+const DefaultThreshold = 0.6
+
+// Weights of the signals in the score. A signal that both recipes lack, such as instructions,
+// is left out and the others are weighted up accordingly.
+const (
+	nameWeight         = 0.3
+	ingredientsWeight  = 0.4
+	instructionsWeight = 0.3
+)
+
+// shingleSize is the number of consecutive words compared at once in instructions.
+const shingleSize = 3
+
+// stopWords don't tell recipe names or instructions apart.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "of": true, "with": true, "in": true,
+	"to": true, "for": true, "on": true, "my": true, "easy": true, "best": true, "homemade": true,
+}
+
+// Scores tells how similar two recipes are, overall and by signal, between 0 and 1.
+type Scores struct {
+	Score             float64 `json:"score"`
+	NameScore         float64 `json:"name_score"`
+	IngredientsScore  float64 `json:"ingredients_score"`
+	InstructionsScore float64 `json:"instructions_score"`
+}
+
+// Recipe identifies a recipe in the results.
+type Recipe struct {
+	RecipeID int    `json:"recipe_id"`
+	Name     string `json:"name"`
+}
+
+// Match is a recipe similar to another one.
+type Match struct {
+	Recipe
+	Scores
+}
+
+// Pair is two recipes that are likely duplicates of each other, the one with the lower ID first.
+type Pair struct {
+	First  Recipe `json:"first"`
+	Second Recipe `json:"second"`
+	Scores
+}
+
+// features are what recipes are compared on, worked out once per recipe.
+type features struct {
+	recipe       *models.Recipe
+	name         string
+	nameWords    map[string]bool
+	ingredients  map[string]bool
+	instructions map[string]bool
+}
+
+func newFeatures(recipe *models.Recipe) *features {
+	f := &features{
+		recipe:      recipe,
+		name:        NormalizeName(recipe.Name),
+		ingredients: make(map[string]bool, len(recipe.Ingredients)),
+	}
+	f.nameWords = set(strings.Fields(f.name))
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient == nil || ingredient.Ingredient == nil {
+			continue
+		}
+		if name := strings.Join(words(ingredient.Name), " "); name != "" {
+			f.ingredients[name] = true
+		}
+	}
+
+	f.instructions = shingles(words(strings.Join(recipe.StepTexts(), " ")), shingleSize)
+
+	return f
+}
+
+// NormalizeName lower-cases a recipe name and keeps its significant words only, so that
+// "The Best Pancakes!" and "pancakes" are the same name.
+func NormalizeName(name string) string {
+	return strings.Join(words(name), " ")
+}
+
+// words splits text into lower-cased words, dropping punctuation and stop words.
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := fields[:0]
+	for _, field := range fields {
+		if !stopWords[field] {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
+
+func set(values []string) map[string]bool {
+	s := make(map[string]bool, len(values))
+	for _, value := range values {
+		s[value] = true
+	}
+	return s
+}
+
+// shingles returns the sequences of size consecutive words, or the text as a whole when it is shorter.
+func shingles(words []string, size int) map[string]bool {
+	s := make(map[string]bool)
+	if len(words) == 0 {
+		return s
+	}
+	if len(words) < size {
+		s[strings.Join(words, " ")] = true
+		return s
+	}
+	for i := 0; i+size <= len(words); i++ {
+		s[strings.Join(words[i:i+size], " ")] = true
+	}
+	return s
+}
+
+// jaccard returns the size of the intersection of two sets over the size of their union.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for value := range a {
+		if b[value] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func compare(a, b *features) Scores {
+	scores := Scores{
+		NameScore:         jaccard(a.nameWords, b.nameWords),
+		IngredientsScore:  jaccard(a.ingredients, b.ingredients),
+		InstructionsScore: jaccard(a.instructions, b.instructions),
+	}
+	if a.name != "" && a.name == b.name {
+		scores.NameScore = 1
+	}
+
+	var score, weights float64
+	signals := []struct {
+		score  float64
+		weight float64
+		absent bool
+	}{
+		{scores.NameScore, nameWeight, len(a.nameWords) == 0 && len(b.nameWords) == 0},
+		{scores.IngredientsScore, ingredientsWeight, len(a.ingredients) == 0 && len(b.ingredients) == 0},
+		{scores.InstructionsScore, instructionsWeight, len(a.instructions) == 0 && len(b.instructions) == 0},
+	}
+	for _, signal := range signals {
+		if signal.absent {
+			continue
+		}
+		score += signal.score * signal.weight
+		weights += signal.weight
+	}
+	if weights > 0 {
+		scores.Score = round(score / weights)
+	}
+	scores.NameScore = round(scores.NameScore)
+	scores.IngredientsScore = round(scores.IngredientsScore)
+	scores.InstructionsScore = round(scores.InstructionsScore)
+
+	return scores
+}
+
+func round(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
+
+// Similar returns the candidates scoring at least threshold against the recipe, the most similar first.
+// The recipe itself is skipped when it is among the candidates.
+func Similar(recipe *models.Recipe, candidates []*models.Recipe, threshold float64) []*Match {
+	target := newFeatures(recipe)
+
+	matches := []*Match{}
+	for _, candidate := range candidates {
+		if recipe.ID != 0 && candidate.ID == recipe.ID {
+			continue
+		}
+		scores := compare(target, newFeatures(candidate))
+		if scores.Score >= threshold {
+			matches = append(matches, &Match{Recipe: Recipe{RecipeID: candidate.ID, Name: candidate.Name}, Scores: scores})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].RecipeID < matches[j].RecipeID
+	})
+
+	return matches
+}
+
+// Duplicates returns every pair of recipes scoring at least threshold against each other,
+// the most similar first.
+func Duplicates(recipes []*models.Recipe, threshold float64) []*Pair {
+	all := make([]*features, 0, len(recipes))
+	for _, recipe := range recipes {
+		all = append(all, newFeatures(recipe))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].recipe.ID < all[j].recipe.ID
+	})
+
+	pairs := []*Pair{}
+	for i, a := range all {
+		for _, b := range all[i+1:] {
+			scores := compare(a, b)
+			if scores.Score < threshold {
+				continue
+			}
+			pairs = append(pairs, &Pair{
+				First:  Recipe{RecipeID: a.recipe.ID, Name: a.recipe.Name},
+				Second: Recipe{RecipeID: b.recipe.ID, Name: b.recipe.Name},
+				Scores: scores,
+			})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Score > pairs[j].Score
+	})
+
+	return pairs
+}
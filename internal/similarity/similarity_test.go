@@ -0,0 +1,64 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+func recipe(id int, name, instructions string, ingredients ...string) *models.Recipe {
+	r := &models.Recipe{ID: id, Name: name, Instructions: instructions}
+	for _, ingredient := range ingredients {
+		r.Ingredients = append(r.Ingredients, &models.FullIngredient{Ingredient: &models.Ingredient{Name: ingredient}})
+	}
+	return r
+}
+
+var catalogue = []*models.Recipe{
+	recipe(1, "Pancakes", "Whisk the flour, milk and eggs.\nFry in a hot pan.", "flour", "milk", "eggs"),
+	recipe(2, "The Best Pancakes!", "Whisk the flour, milk and eggs.\nFry in a hot pan until golden.", "Flour", "milk", "eggs", "sugar"),
+	recipe(3, "Tomato soup", "Roast the tomatoes.\nBlend with the stock.", "tomatoes", "stock", "onion"),
+	recipe(4, "Crepes", "Whisk the flour, milk and eggs into a thin batter.\nFry thinly.", "flour", "milk", "eggs", "butter"),
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "pancakes", NormalizeName("The Best Pancakes!"))
+	assert.Equal(t, "mac cheese", NormalizeName("Mac & Cheese"))
+	assert.Equal(t, "crème brûlée", NormalizeName("  Crème   Brûlée "))
+}
+
+func TestSimilar(t *testing.T) {
+	matches := Similar(catalogue[0], catalogue, 0.3)
+
+	ids := make([]int, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.RecipeID)
+	}
+	assert.Equal(t, []int{2, 4}, ids)
+
+	assert.Equal(t, 1.0, matches[0].NameScore)
+	assert.Equal(t, 0.75, matches[0].IngredientsScore)
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+
+	assert.Empty(t, Similar(catalogue[2], catalogue, 0.5))
+}
+
+func TestSimilarWithoutInstructions(t *testing.T) {
+	// Instructions that neither recipe has don't drag the score down
+	a := recipe(1, "Pancakes", "", "flour", "milk")
+	b := recipe(2, "Pancakes", "", "flour", "milk")
+
+	matches := Similar(a, []*models.Recipe{b}, 0)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, 1.0, matches[0].Score)
+}
+
+func TestDuplicates(t *testing.T) {
+	pairs := Duplicates(catalogue, DefaultThreshold)
+
+	assert.Len(t, pairs, 1)
+	assert.Equal(t, 1, pairs[0].First.RecipeID)
+	assert.Equal(t, 2, pairs[0].Second.RecipeID)
+	assert.GreaterOrEqual(t, pairs[0].Score, DefaultThreshold)
+}
@@ -0,0 +1,83 @@
+// Package substitutions ships a graph of common ingredient substitutions, loaded from substitutions.csv.
+package substitutions
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vladComan0/tasty-byte/internal/models"
+)
+
+//go:embed substitutions.csv
+var bundled []byte
+
+var csvHeader = []string{"ingredient", "substitute", "ratio", "note"}
+
+// Bundled returns the substitutions shipped with the application.
+func Bundled() ([]*models.SubstitutionRule, error) {
+	return ParseCSV(bytes.NewReader(bundled))
+}
+
+// ParseCSV reads substitutions from a CSV file with the columns of substitutions.csv. The ratio is the
+// quantity of substitute to use for one unit of the ingredient, in the unit the recipe uses.
+func ParseCSV(r io.Reader) ([]*models.SubstitutionRule, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("substitutions: unexpected column %q, expected %q", header[i], column)
+		}
+	}
+
+	var results []*models.SubstitutionRule
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rule, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("substitutions: line %d: %w", line, err)
+		}
+		results = append(results, rule)
+	}
+}
+
+func parseRecord(record []string) (*models.SubstitutionRule, error) {
+	rule := &models.SubstitutionRule{
+		Ingredient: strings.ToLower(strings.TrimSpace(record[0])),
+		Substitute: strings.ToLower(strings.TrimSpace(record[1])),
+		Note:       strings.TrimSpace(record[3]),
+	}
+	if rule.Ingredient == "" || rule.Substitute == "" {
+		return nil, errors.New("missing ingredient or substitute")
+	}
+	if rule.Ingredient == rule.Substitute {
+		return nil, fmt.Errorf("%q substitutes itself", rule.Ingredient)
+	}
+
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+	if err != nil || ratio <= 0 {
+		return nil, fmt.Errorf("invalid ratio %q", record[2])
+	}
+	rule.Ratio = ratio
+
+	return rule, nil
+}
@@ -0,0 +1,65 @@
+package substitutions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundled(t *testing.T) {
+	rules, err := Bundled()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rules)
+
+	seen := make(map[[2]string]bool)
+	for _, rule := range rules {
+		edge := [2]string{rule.Ingredient, rule.Substitute}
+		assert.False(t, seen[edge], "duplicate entry %q → %q", rule.Ingredient, rule.Substitute)
+		seen[edge] = true
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			data: "ingredient,substitute,ratio,note\nButter, Vegetable Oil ,0.8, Denser cakes. \n",
+		},
+		{
+			name:    "Wrong Header",
+			data:    "ingredient,replacement,ratio,note\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Ratio",
+			data:    "ingredient,substitute,ratio,note\nbutter,vegetable oil,0,\n",
+			wantErr: true,
+		},
+		{
+			name:    "Self Substitution",
+			data:    "ingredient,substitute,ratio,note\nbutter,Butter,1,\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := ParseCSV(strings.NewReader(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, rules, 1)
+			assert.Equal(t, "butter", rules[0].Ingredient)
+			assert.Equal(t, "vegetable oil", rules[0].Substitute)
+			assert.Equal(t, 0.8, rules[0].Ratio)
+			assert.Equal(t, "Denser cakes.", rules[0].Note)
+		})
+	}
+}
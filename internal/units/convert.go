@@ -0,0 +1,94 @@
+package units
+
+import "strings"
+
+// Kind is the physical dimension a unit measures.
+type Kind int
+
+const (
+	// Count units, such as "piece", "clove" or no unit at all, only make sense for a given ingredient.
+	Count Kind = iota
+	Mass
+	Volume
+)
+
+// grams and milliliters hold the size of every canonical mass and volume unit, US customary
+// units included. Pinches and dashes are the usual kitchen approximations.
+var (
+	grams = map[string]float64{
+		"mg": 0.001,
+		"g":  1,
+		"kg": 1000,
+		"oz": 28.349523,
+		"lb": 453.59237,
+	}
+
+	milliliters = map[string]float64{
+		"ml":    1,
+		"cl":    10,
+		"dl":    100,
+		"l":     1000,
+		"tsp":   4.928922,
+		"tbsp":  14.786765,
+		"fl oz": 29.573530,
+		"cup":   236.588237,
+		"pint":  473.176473,
+		"quart": 946.352946,
+		"pinch": 0.308058,
+		"dash":  0.616115,
+	}
+)
+
+// KindOf returns what the unit measures. Unknown units are treated as counts.
+func KindOf(unit string) Kind {
+	canonical, _ := Normalize(unit)
+	switch {
+	case grams[canonical] > 0:
+		return Mass
+	case milliliters[canonical] > 0:
+		return Volume
+	default:
+		return Count
+	}
+}
+
+// ToGrams converts a quantity expressed in a mass unit to grams.
+func ToGrams(quantity float64, unit string) (float64, bool) {
+	canonical, _ := Normalize(unit)
+	factor, ok := grams[canonical]
+	return quantity * factor, ok
+}
+
+// ToMilliliters converts a quantity expressed in a volume unit to milliliters.
+func ToMilliliters(quantity float64, unit string) (float64, bool) {
+	canonical, _ := Normalize(unit)
+	factor, ok := milliliters[canonical]
+	return quantity * factor, ok
+}
+
+// Convert converts a quantity from one unit to another of the same kind. Count units only
+// convert to themselves, "2 cloves" can't be expressed in "pieces" without knowing the ingredient.
+func Convert(quantity float64, from, to string) (float64, bool) {
+	fromCanonical, fromKnown := Normalize(from)
+	toCanonical, toKnown := Normalize(to)
+	if !fromKnown {
+		fromCanonical = strings.ToLower(strings.TrimSpace(from))
+	}
+	if !toKnown {
+		toCanonical = strings.ToLower(strings.TrimSpace(to))
+	}
+
+	if fromCanonical == toCanonical {
+		return quantity, true
+	}
+
+	for _, sizes := range []map[string]float64{grams, milliliters} {
+		fromSize, fromOK := sizes[fromCanonical]
+		toSize, toOK := sizes[toCanonical]
+		if fromOK && toOK {
+			return quantity * fromSize / toSize, true
+		}
+	}
+
+	return 0, false
+}
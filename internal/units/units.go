@@ -0,0 +1,76 @@
+package units
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// aliases maps the spellings found in recipes to the canonical unit names stored alongside ingredients.
+var aliases = map[string]string{
+	"mg": "mg", "milligram": "mg", "milligrams": "mg",
+	"g": "g", "gr": "g", "gram": "g", "grams": "g", "gramme": "g", "grammes": "g",
+	"kg": "kg", "kilo": "kg", "kilos": "kg", "kilogram": "kg", "kilograms": "kg",
+	"ml": "ml", "milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+	"cl": "cl", "centiliter": "cl", "centiliters": "cl", "centilitre": "cl", "centilitres": "cl",
+	"dl": "dl", "deciliter": "dl", "deciliters": "dl", "decilitre": "dl", "decilitres": "dl",
+	"l": "l", "liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"tsp": "tsp", "tsps": "tsp", "teaspoon": "tsp", "teaspoons": "tsp", "t": "tsp",
+	"tbsp": "tbsp", "tbsps": "tbsp", "tbs": "tbsp", "tablespoon": "tbsp", "tablespoons": "tbsp", "T": "tbsp",
+	"cup": "cup", "cups": "cup", "c": "cup",
+	"fl oz": "fl oz", "fluid ounce": "fl oz", "fluid ounces": "fl oz",
+	"oz": "oz", "ounce": "oz", "ounces": "oz",
+	"lb": "lb", "lbs": "lb", "pound": "lb", "pounds": "lb",
+	"pint": "pint", "pints": "pint", "pt": "pint",
+	"quart": "quart", "quarts": "quart", "qt": "quart",
+	"pinch": "pinch", "pinches": "pinch",
+	"dash": "dash", "dashes": "dash",
+	"clove": "clove", "cloves": "clove",
+	"can": "can", "cans": "can", "tin": "can", "tins": "can",
+	"slice": "slice", "slices": "slice",
+	"piece": "piece", "pieces": "piece", "pc": "piece", "pcs": "piece",
+	"bunch": "bunch", "bunches": "bunch",
+	"sprig": "sprig", "sprigs": "sprig",
+	"stick": "stick", "sticks": "stick",
+	"package": "package", "packages": "package", "pkg": "package", "packet": "package", "packets": "package",
+	"handful": "handful", "handfuls": "handful",
+}
+
+// Normalize returns the canonical name of a unit, e.g. "Tablespoons" -> "tbsp".
+// The second return value reports whether the unit is known at all.
+func Normalize(unit string) (string, bool) {
+	unit = strings.TrimSuffix(strings.TrimSpace(unit), ".")
+
+	// Single letter abbreviations are case-sensitive in recipes: "T" is a tablespoon, "t" a teaspoon
+	if canonical, ok := aliases[unit]; ok {
+		return canonical, true
+	}
+
+	canonical, ok := aliases[strings.ToLower(unit)]
+	return canonical, ok
+}
+
+// fractions are the vulgar fractions used when printing quantities, keyed by hundredths
+// since quantities are stored with two decimals.
+var fractions = map[int]string{
+	12: "1/8", 13: "1/8", 25: "1/4", 33: "1/3", 38: "3/8", 50: "1/2",
+	62: "5/8", 63: "5/8", 66: "2/3", 67: "2/3", 75: "3/4", 87: "7/8", 88: "7/8",
+}
+
+// FormatQuantity prints a quantity the way recipes usually do, e.g. 1.5 -> "1 1/2", 0.33 -> "1/3" or 2.2 -> "2.2".
+func FormatQuantity(quantity float64) string {
+	whole := int(quantity)
+	hundredths := int(math.Round((quantity - float64(whole)) * 100))
+	if hundredths == 100 {
+		whole, hundredths = whole+1, 0
+	}
+
+	if fraction, ok := fractions[hundredths]; ok {
+		if whole == 0 {
+			return fraction
+		}
+		return strconv.Itoa(whole) + " " + fraction
+	}
+
+	return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64)
+}
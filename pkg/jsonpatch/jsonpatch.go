@@ -0,0 +1,303 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidOperation is returned when an operation is malformed or unknown.
+	ErrInvalidOperation = errors.New("jsonpatch: invalid operation")
+	// ErrPathNotFound is returned when an operation addresses a location that does not exist.
+	ErrPathNotFound = errors.New("jsonpatch: path not found")
+	// ErrTestFailed is returned when a "test" operation does not match the document.
+	ErrTestFailed = errors.New("jsonpatch: test operation failed")
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+// Value is kept raw so that an explicit null can be told apart from a missing value.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an ordered list of operations, applied one after the other by Apply.
+type Patch []Operation
+
+// Apply applies the patch to a document decoded with encoding/json into `any`
+// (i.e. made of map[string]any, []any, float64, string, bool and nil) and returns the
+// patched document. The input document may be modified, so callers that need to keep
+// it around should pass a copy.
+func (p Patch) Apply(doc any) (any, error) {
+	var err error
+	for i, op := range p {
+		doc, err = op.apply(doc)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func (op Operation) apply(doc any) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "remove":
+		return remove(doc, path)
+	case "replace":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		return replace(doc, path, value)
+	case "move":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.From != op.Path && strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move a value into one of its children", ErrInvalidOperation)
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		value, err = deepCopy(value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "test":
+		expected, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidOperation, op.Op)
+	}
+}
+
+func (op Operation) value() (any, error) {
+	if len(op.Value) == 0 {
+		return nil, fmt.Errorf("%w: missing value", ErrInvalidOperation)
+	}
+
+	var value any
+	if err := json.Unmarshal(op.Value, &value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOperation, err)
+	}
+	return value, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: invalid JSON pointer %q", ErrInvalidOperation, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func get(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, exists := n[token]
+			if !exists {
+				return nil, ErrPathNotFound
+			}
+			node = child
+		case []any:
+			index, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[index]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return node, nil
+}
+
+func add(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+	if len(path) > 1 {
+		return updateChild(node, token, func(child any) (any, error) {
+			return add(child, path[1:], value)
+		})
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		n[token] = value
+		return n, nil
+	case []any:
+		index := len(n)
+		if token != "-" {
+			var err error
+			if index, err = arrayIndex(token, len(n)); err != nil {
+				return nil, err
+			}
+		}
+		n = append(n, nil)
+		copy(n[index+1:], n[index:])
+		n[index] = value
+		return n, nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+func remove(node any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidOperation)
+	}
+
+	token := path[0]
+	if len(path) > 1 {
+		return updateChild(node, token, func(child any) (any, error) {
+			return remove(child, path[1:])
+		})
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		if _, exists := n[token]; !exists {
+			return nil, ErrPathNotFound
+		}
+		delete(n, token)
+		return n, nil
+	case []any:
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		return append(n[:index], n[index+1:]...), nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+func replace(node any, path []string, value any) (any, error) {
+	if _, err := get(node, path); err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateChild(node, path[0], func(child any) (any, error) {
+		if len(path) == 1 {
+			return value, nil
+		}
+		return replace(child, path[1:], value)
+	})
+}
+
+// updateChild replaces the child of node addressed by token with the result of fn.
+func updateChild(node any, token string, fn func(child any) (any, error)) (any, error) {
+	switch n := node.(type) {
+	case map[string]any:
+		child, exists := n[token]
+		if !exists {
+			return nil, ErrPathNotFound
+		}
+		updated, err := fn(child)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []any:
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := fn(n[index])
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// arrayIndex parses an array index token, which must not exceed last.
+func arrayIndex(token string, last int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidOperation, token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidOperation, token)
+	}
+	if index > last {
+		return 0, ErrPathNotFound
+	}
+	return index, nil
+}
+
+func deepCopy(value any) (any, error) {
+	js, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal(js, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
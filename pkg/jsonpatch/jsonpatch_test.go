@@ -0,0 +1,182 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decode(t *testing.T, js string) any {
+	t.Helper()
+
+	var value any
+	if err := json.Unmarshal([]byte(js), &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name        string
+		doc         string
+		patch       string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "Add Object Member",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			expected: `{"baz": "qux", "foo": "bar"}`,
+		},
+		{
+			name:     "Add Array Element",
+			doc:      `{"foo": ["bar", "baz"]}`,
+			patch:    `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			expected: `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			name:     "Append To Array",
+			doc:      `{"foo": ["bar"]}`,
+			patch:    `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			expected: `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+		{
+			name:     "Remove Object Member",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "remove", "path": "/baz"}]`,
+			expected: `{"foo": "bar"}`,
+		},
+		{
+			name:     "Remove Array Element",
+			doc:      `{"foo": ["bar", "qux", "baz"]}`,
+			patch:    `[{"op": "remove", "path": "/foo/1"}]`,
+			expected: `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			name:     "Replace Value",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			expected: `{"baz": "boo", "foo": "bar"}`,
+		},
+		{
+			name:     "Move Value",
+			doc:      `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch:    `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			expected: `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{
+			name:     "Copy Value",
+			doc:      `{"foo": {"bar": 1}}`,
+			patch:    `[{"op": "copy", "from": "/foo", "path": "/baz"}]`,
+			expected: `{"foo": {"bar": 1}, "baz": {"bar": 1}}`,
+		},
+		{
+			name:     "Escaped Pointer",
+			doc:      `{"a/b": {"m~n": 1}}`,
+			patch:    `[{"op": "replace", "path": "/a~1b/m~0n", "value": 2}]`,
+			expected: `{"a/b": {"m~n": 2}}`,
+		},
+		{
+			name:     "Successful Test",
+			doc:      `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			patch:    `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			expected: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		},
+		{
+			name:        "Failed Test",
+			doc:         `{"baz": "qux"}`,
+			patch:       `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			expectedErr: ErrTestFailed,
+		},
+		{
+			name:        "Remove Missing Member",
+			doc:         `{"foo": "bar"}`,
+			patch:       `[{"op": "remove", "path": "/baz"}]`,
+			expectedErr: ErrPathNotFound,
+		},
+		{
+			name:        "Add To Missing Parent",
+			doc:         `{"foo": "bar"}`,
+			patch:       `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			expectedErr: ErrPathNotFound,
+		},
+		{
+			name:        "Missing Value",
+			doc:         `{"foo": "bar"}`,
+			patch:       `[{"op": "add", "path": "/baz"}]`,
+			expectedErr: ErrInvalidOperation,
+		},
+		{
+			name:        "Unknown Operation",
+			doc:         `{"foo": "bar"}`,
+			patch:       `[{"op": "frobnicate", "path": "/foo"}]`,
+			expectedErr: ErrInvalidOperation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var patch Patch
+			assert.NoError(t, json.Unmarshal([]byte(tc.patch), &patch))
+
+			result, err := patch.Apply(decode(t, tc.doc))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, decode(t, tc.expected), result)
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "Replace Member",
+			target:   `{"a": "b"}`,
+			patch:    `{"a": "c"}`,
+			expected: `{"a": "c"}`,
+		},
+		{
+			name:     "Remove Member",
+			target:   `{"a": "b", "b": "c"}`,
+			patch:    `{"a": null}`,
+			expected: `{"b": "c"}`,
+		},
+		{
+			name:     "Replace Array",
+			target:   `{"a": ["b"]}`,
+			patch:    `{"a": ["c", "d"]}`,
+			expected: `{"a": ["c", "d"]}`,
+		},
+		{
+			name:     "Nested Merge",
+			target:   `{"a": {"b": "c", "d": "e"}}`,
+			patch:    `{"a": {"b": "x", "d": null, "f": "g"}}`,
+			expected: `{"a": {"b": "x", "f": "g"}}`,
+		},
+		{
+			name:     "Non Object Patch",
+			target:   `{"a": "b"}`,
+			patch:    `["c"]`,
+			expected: `["c"]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := MergePatch(decode(t, tc.target), decode(t, tc.patch))
+			assert.Equal(t, decode(t, tc.expected), result)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package jsonpatch
+
+// MergePatch applies an RFC 7396 JSON Merge Patch to a document decoded with encoding/json
+// into `any` and returns the result. Objects are merged recursively, null removes a member
+// and any other value (arrays included) replaces the target wholesale.
+func MergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = MergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}